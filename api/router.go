@@ -6,18 +6,7 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsapigateway"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
 	"github.com/aws/jsii-runtime-go"
-)
-
-const (
-	UploadLambdaName   = "upload"
-	DownloadLambdaName = "download"
-	ListLambdaName     = "list"
-	DeleteLambdaName   = "delete"
-	LoginLambdaName    = "login"
-	RegisterLambdaName = "register"
-	MeLambdaName       = "me"
-	ConfirmLambdaName  = "confirm"
-	ResendLambdaName   = "resend"
+	"mentorship-app-backend/api/routes"
 )
 
 func InitializeAPI(stack awscdk.Stack, lambdas map[string]awslambda.Function, cognitoAuthorizer awsapigateway.IAuthorizer, environment string) awsapigateway.RestApi {
@@ -33,29 +22,27 @@ func InitializeAPI(stack awscdk.Stack, lambdas map[string]awslambda.Function, co
 		},
 	})
 
-	SetupPublicEndpoints(api, lambdas)
-	SetupProtectedEndpoints(api, lambdas, cognitoAuthorizer)
+	for _, route := range routes.All() {
+		var authorizer awsapigateway.IAuthorizer
+		if route.Protected {
+			authorizer = cognitoAuthorizer
+		}
+		addApiMethod(api, route, lambdas[route.Name], authorizer)
+	}
 
 	return api
 }
 
-func SetupPublicEndpoints(api awsapigateway.RestApi, lambdas map[string]awslambda.Function) {
-	addApiResource(api, "POST", RegisterLambdaName, lambdas[RegisterLambdaName], nil)
-	addApiResource(api, "POST", LoginLambdaName, lambdas[LoginLambdaName], nil)
-	addApiResource(api, "POST", UploadLambdaName, lambdas[UploadLambdaName], nil)
-	addApiResource(api, "POST", ConfirmLambdaName, lambdas[ConfirmLambdaName], nil)
-	addApiResource(api, "GET", ResendLambdaName, lambdas[ResendLambdaName], nil)
-}
-
-func SetupProtectedEndpoints(api awsapigateway.RestApi, lambdas map[string]awslambda.Function, cognitoAuthorizer awsapigateway.IAuthorizer) {
-	addApiResource(api, "GET", DownloadLambdaName, lambdas[DownloadLambdaName], cognitoAuthorizer)
-	addApiResource(api, "GET", ListLambdaName, lambdas[ListLambdaName], cognitoAuthorizer)
-	addApiResource(api, "DELETE", DeleteLambdaName, lambdas[DeleteLambdaName], cognitoAuthorizer)
-	addApiResource(api, "GET", MeLambdaName, lambdas[MeLambdaName], cognitoAuthorizer)
-}
-
-func addApiResource(api awsapigateway.RestApi, method, resourceName string, lambdaFunction awslambda.Function, cognitoAuthorizer awsapigateway.IAuthorizer) {
-	resource := api.Root().AddResource(jsii.String(resourceName), nil)
+// addApiMethod mounts the route's method on its path, creating the nested resources it passes through.
+func addApiMethod(api awsapigateway.RestApi, route routes.Route, lambdaFunction awslambda.Function, cognitoAuthorizer awsapigateway.IAuthorizer) {
+	resource := api.Root()
+	for _, segment := range route.Segments() {
+		child := resource.GetResource(jsii.String(segment))
+		if child == nil {
+			child = resource.AddResource(jsii.String(segment), nil)
+		}
+		resource = child
+	}
 	methodOptions := &awsapigateway.MethodOptions{}
 	if cognitoAuthorizer != nil {
 		methodOptions = &awsapigateway.MethodOptions{
@@ -63,5 +50,5 @@ func addApiResource(api awsapigateway.RestApi, method, resourceName string, lamb
 			Authorizer:        cognitoAuthorizer,
 		}
 	}
-	resource.AddMethod(jsii.String(method), awsapigateway.NewLambdaIntegration(lambdaFunction, nil), methodOptions)
+	resource.AddMethod(jsii.String(route.Method), awsapigateway.NewLambdaIntegration(lambdaFunction, nil), methodOptions)
 }
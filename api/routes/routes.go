@@ -0,0 +1,222 @@
+// Package routes is the registry of every endpoint: the Lambda behind it, where it is mounted, how it is sized
+// and what it may access. It does not depend on CDK, so the deployed stack and the local development server are
+// both generated from the same table.
+package routes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	UploadLambdaName           = "upload"
+	DownloadLambdaName         = "download"
+	ListLambdaName             = "list"
+	DeleteLambdaName           = "delete"
+	LoginLambdaName            = "login"
+	RegisterLambdaName         = "register"
+	MeLambdaName               = "me"
+	ConfirmLambdaName          = "confirm"
+	ResendLambdaName           = "resend"
+	RefreshLambdaName          = "refresh"
+	ForgotPasswordLambdaName   = "forgot-password"
+	ResetPasswordLambdaName    = "reset-password"
+	UpdateProfileLambdaName    = "update-profile"
+	MentorsLambdaName          = "mentors"
+	SendRequestLambdaName      = "send-request"
+	ListRequestsLambdaName     = "list-requests"
+	UpdateRequestLambdaName    = "update-request"
+	SetAvailabilityLambdaName  = "set-availability"
+	GetAvailabilityLambdaName  = "get-availability"
+	BookSessionLambdaName      = "book-session"
+	ListBookingsLambdaName     = "list-bookings"
+	CancelBookingLambdaName    = "cancel-booking"
+	UploadURLLambdaName        = "upload-url"
+	DownloadURLLambdaName      = "download-url"
+	RespondChallengeLambdaName = "respond-challenge"
+	MFAAssociateLambdaName     = "mfa-associate"
+	MFAVerifyLambdaName        = "mfa-verify"
+	MFAPreferenceLambdaName    = "mfa-preference"
+	LogoutLambdaName           = "logout"
+	LogoutAllLambdaName        = "logout-all"
+	ChangePasswordLambdaName   = "change-password"
+	ChangeEmailLambdaName      = "change-email"
+	VerifyEmailLambdaName      = "verify-email"
+	DeleteAccountLambdaName    = "delete-account"
+	ExportLambdaName           = "export"
+	ExportStatusLambdaName     = "export-status"
+	ExportWorkerLambdaName     = "export-worker"
+
+	MentorshipRequestsResource = "mentorship-requests"
+	AvailabilityResource       = "availability"
+	BookingsResource           = "bookings"
+	MFAResource                = "mfa"
+)
+
+// Notification channels, one per handler group. Failures go to the channel's -alerts counterpart.
+const (
+	AuthChannel       = "#auth-cognito"
+	MentorshipChannel = "#mentorship"
+	FilesChannel      = "#s3-bucket"
+)
+
+const (
+	DefaultMemoryMB = 128
+	DefaultTimeout  = 15 * time.Second
+	// APIGatewayTimeout is how long API Gateway waits for a Lambda before answering 504 itself. A mounted route
+	// gains nothing from a longer Timeout; work that can take longer goes to a worker or resumes on the next call.
+	APIGatewayTimeout = 29 * time.Second
+)
+
+// Permission names an access grant a Lambda needs. The stack translates each one into IAM statements through
+// the permissions package. Every Lambda also reads and writes profiles, reads the notification secret and, when
+// a topic is configured, publishes to it, so those grants are not declared per route.
+type Permission string
+
+const (
+	CognitoRegister        Permission = "cognito-register"
+	CognitoLogin           Permission = "cognito-login"
+	CognitoConfirm         Permission = "cognito-confirm"
+	CognitoResend          Permission = "cognito-resend"
+	CognitoRefresh         Permission = "cognito-refresh"
+	CognitoPasswordReset   Permission = "cognito-password-reset"
+	CognitoDescribe        Permission = "cognito-describe"
+	CognitoTokenValidation Permission = "cognito-token-validation"
+	CognitoChallenge       Permission = "cognito-challenge"
+	CognitoMFA             Permission = "cognito-mfa"
+	CognitoSignOut         Permission = "cognito-sign-out"
+	CognitoAccount         Permission = "cognito-account"
+	CognitoDeleteUser      Permission = "cognito-delete-user"
+	CognitoReadUser        Permission = "cognito-read-user"
+
+	BucketRead           Permission = "bucket-read"
+	BucketReadWrite      Permission = "bucket-read-write"
+	BucketPut            Permission = "bucket-put"
+	ProfilePictureUpload Permission = "profile-picture-upload"
+
+	RequestsTable     Permission = "requests-table"
+	AvailabilityTable Permission = "availability-table"
+	BookingsTable     Permission = "bookings-table"
+	// RevokedTokensTable lets a Lambda add to the token denylist. Every protected Lambda can read it, since
+	// its token verifier consults it on each request.
+	RevokedTokensTable Permission = "revoked-tokens-table"
+	AccountJobsTable   Permission = "account-jobs-table"
+)
+
+// Route is one endpoint and the Lambda that serves it. Path is relative to the API root and may be nested, with
+// path parameters in braces, e.g. "bookings/{bookingId}". Protected routes sit behind the Cognito authorizer.
+// A zero MemoryMB or Timeout falls back to DefaultMemoryMB and DefaultTimeout. Invokes names the workers the
+// Lambda hands work to. Handler names the Lambda in logs, notifications and the per-handler notification policies
+// of the config, and Channel is where its notifications go.
+type Route struct {
+	Name        string
+	Handler     string
+	Channel     string
+	Method      string
+	Path        string
+	Protected   bool
+	MemoryMB    int
+	Timeout     time.Duration
+	Permissions []Permission
+	Invokes     []string
+}
+
+// Segments splits Path into its resource names.
+func (r Route) Segments() []string {
+	return strings.Split(strings.Trim(r.Path, "/"), "/")
+}
+
+// userPoolLookup is what every handler that resolves the caller from the user pool needs.
+var userPoolLookup = []Permission{CognitoDescribe, CognitoTokenValidation}
+
+func withUserPoolLookup(permissions ...Permission) []Permission {
+	return append(append([]Permission{}, userPoolLookup...), permissions...)
+}
+
+var registry = []Route{
+	{Name: RegisterLambdaName, Handler: "RegisterHandler", Channel: AuthChannel, Method: "POST", Path: RegisterLambdaName, Permissions: []Permission{CognitoRegister, ProfilePictureUpload}},
+	{Name: LoginLambdaName, Handler: "LoginHandler", Channel: AuthChannel, Method: "POST", Path: LoginLambdaName, Permissions: []Permission{CognitoLogin}},
+	{Name: ConfirmLambdaName, Handler: "ConfirmHandler", Channel: AuthChannel, Method: "POST", Path: ConfirmLambdaName, Permissions: []Permission{CognitoConfirm}},
+	{Name: ResendLambdaName, Handler: "ResendHandler", Channel: AuthChannel, Method: "GET", Path: ResendLambdaName, Permissions: []Permission{CognitoResend}},
+	{Name: RefreshLambdaName, Handler: "RefreshHandler", Channel: AuthChannel, Method: "POST", Path: RefreshLambdaName, Permissions: []Permission{CognitoRefresh}},
+	{Name: ForgotPasswordLambdaName, Handler: "ForgotPasswordHandler", Channel: AuthChannel, Method: "POST", Path: ForgotPasswordLambdaName, Permissions: []Permission{CognitoPasswordReset}},
+	{Name: ResetPasswordLambdaName, Handler: "ResetPasswordHandler", Channel: AuthChannel, Method: "POST", Path: ResetPasswordLambdaName, Permissions: []Permission{CognitoPasswordReset}},
+	{Name: RespondChallengeLambdaName, Handler: "RespondChallengeHandler", Channel: AuthChannel, Method: "POST", Path: RespondChallengeLambdaName, Permissions: []Permission{CognitoChallenge}},
+
+	{Name: UploadLambdaName, Handler: "UploadHandler", Channel: FilesChannel, Method: "POST", Path: UploadLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketReadWrite)},
+	{Name: DownloadLambdaName, Handler: "DownloadHandler", Channel: FilesChannel, Method: "GET", Path: DownloadLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketRead)},
+	// Presigned URLs carry the signer's permissions, so the uploader role only needs to put objects.
+	{Name: UploadURLLambdaName, Handler: "UploadURLHandler", Channel: FilesChannel, Method: "POST", Path: UploadURLLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketPut)},
+	{Name: DownloadURLLambdaName, Handler: "DownloadURLHandler", Channel: FilesChannel, Method: "GET", Path: DownloadURLLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketRead)},
+	{Name: ListLambdaName, Handler: "ListHandler", Channel: FilesChannel, Method: "GET", Path: ListLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketRead)},
+	{Name: DeleteLambdaName, Handler: "DeleteHandler", Channel: FilesChannel, Method: "DELETE", Path: DeleteLambdaName, Protected: true, Permissions: withUserPoolLookup(BucketReadWrite)},
+	{Name: MeLambdaName, Handler: "MeHandler", Channel: AuthChannel, Method: "GET", Path: MeLambdaName, Protected: true, Permissions: withUserPoolLookup()},
+	{Name: UpdateProfileLambdaName, Handler: "UpdateProfileHandler", Channel: AuthChannel, Method: "PATCH", Path: MeLambdaName, Protected: true, Permissions: withUserPoolLookup()},
+	{Name: MentorsLambdaName, Handler: "MentorsHandler", Channel: MentorshipChannel, Method: "GET", Path: MentorsLambdaName, Protected: true, Permissions: withUserPoolLookup()},
+	{Name: MFAAssociateLambdaName, Handler: "MFAAssociateHandler", Channel: AuthChannel, Method: "POST", Path: MFAResource + "/associate", Protected: true, Permissions: []Permission{CognitoMFA}},
+	{Name: MFAVerifyLambdaName, Handler: "MFAVerifyHandler", Channel: AuthChannel, Method: "POST", Path: MFAResource + "/verify", Protected: true, Permissions: []Permission{CognitoMFA}},
+	{Name: MFAPreferenceLambdaName, Handler: "MFAPreferenceHandler", Channel: AuthChannel, Method: "PUT", Path: MFAResource + "/preference", Protected: true, Permissions: []Permission{CognitoMFA}},
+	{Name: LogoutLambdaName, Handler: "LogoutHandler", Channel: AuthChannel, Method: "POST", Path: LogoutLambdaName, Protected: true, Permissions: []Permission{CognitoSignOut, RevokedTokensTable}},
+	{Name: LogoutAllLambdaName, Handler: "LogoutAllHandler", Channel: AuthChannel, Method: "POST", Path: LogoutAllLambdaName, Protected: true, Permissions: []Permission{CognitoSignOut, RevokedTokensTable}},
+	{Name: ChangePasswordLambdaName, Handler: "ChangePasswordHandler", Channel: AuthChannel, Method: "PUT", Path: MeLambdaName + "/password", Protected: true, Permissions: []Permission{CognitoAccount}},
+	{Name: ChangeEmailLambdaName, Handler: "ChangeEmailHandler", Channel: AuthChannel, Method: "PUT", Path: MeLambdaName + "/email", Protected: true, Permissions: []Permission{CognitoAccount}},
+	{Name: VerifyEmailLambdaName, Handler: "VerifyEmailHandler", Channel: AuthChannel, Method: "POST", Path: MeLambdaName + "/email/verify", Protected: true, Permissions: []Permission{CognitoAccount}},
+	// Deleting an account walks every store the user has data in, so it gets as long as API Gateway waits and
+	// carries on over further calls when that is not enough.
+	{
+		Name: DeleteAccountLambdaName, Handler: "DeleteAccountHandler", Channel: AuthChannel, Method: "DELETE", Path: MeLambdaName, Protected: true, Timeout: APIGatewayTimeout,
+		Permissions: []Permission{CognitoDeleteUser, BucketReadWrite, RequestsTable, AvailabilityTable, BookingsTable, RevokedTokensTable, AccountJobsTable},
+	},
+	// Small exports are built in the request; the rest go to the export worker.
+	{
+		Name: ExportLambdaName, Handler: "ExportHandler", Channel: AuthChannel, Method: "POST", Path: MeLambdaName + "/export", Protected: true, MemoryMB: 512, Timeout: APIGatewayTimeout,
+		Permissions: exportPermissions, Invokes: []string{ExportWorkerLambdaName},
+	},
+	{Name: ExportStatusLambdaName, Handler: "ExportStatusHandler", Channel: AuthChannel, Method: "GET", Path: MeLambdaName + "/export", Protected: true, Permissions: []Permission{BucketRead, AccountJobsTable}},
+
+	{Name: SendRequestLambdaName, Handler: "SendRequestHandler", Channel: MentorshipChannel, Method: "POST", Path: MentorshipRequestsResource, Protected: true, Permissions: []Permission{RequestsTable}},
+	{Name: ListRequestsLambdaName, Handler: "ListRequestsHandler", Channel: MentorshipChannel, Method: "GET", Path: MentorshipRequestsResource, Protected: true, Permissions: []Permission{RequestsTable}},
+	{Name: UpdateRequestLambdaName, Handler: "UpdateRequestHandler", Channel: MentorshipChannel, Method: "PATCH", Path: MentorshipRequestsResource, Protected: true, Permissions: []Permission{RequestsTable}},
+
+	{Name: SetAvailabilityLambdaName, Handler: "SetAvailabilityHandler", Channel: MentorshipChannel, Method: "PUT", Path: AvailabilityResource, Protected: true, Permissions: []Permission{AvailabilityTable, BookingsTable}},
+	{Name: GetAvailabilityLambdaName, Handler: "GetAvailabilityHandler", Channel: MentorshipChannel, Method: "GET", Path: AvailabilityResource, Protected: true, Permissions: []Permission{AvailabilityTable, BookingsTable}},
+	// Booking checks that the mentorship request was accepted before taking the slot.
+	{Name: BookSessionLambdaName, Handler: "BookSessionHandler", Channel: MentorshipChannel, Method: "POST", Path: BookingsResource, Protected: true, Permissions: []Permission{RequestsTable, AvailabilityTable, BookingsTable}},
+	{Name: ListBookingsLambdaName, Handler: "ListBookingsHandler", Channel: MentorshipChannel, Method: "GET", Path: BookingsResource, Protected: true, Permissions: []Permission{AvailabilityTable, BookingsTable}},
+	{Name: CancelBookingLambdaName, Handler: "CancelBookingHandler", Channel: MentorshipChannel, Method: "DELETE", Path: BookingsResource, Protected: true, Permissions: []Permission{AvailabilityTable, BookingsTable}},
+}
+
+// exportPermissions reads everything an export collects and writes the archive.
+var exportPermissions = []Permission{CognitoReadUser, BucketReadWrite, RequestsTable, AvailabilityTable, BookingsTable, AccountJobsTable}
+
+// workers are Lambdas no route serves. Other Lambdas invoke them asynchronously, declared in Invokes, with
+// work that outlasts an API Gateway request.
+var workers = []Route{
+	{Name: ExportWorkerLambdaName, MemoryMB: 1024, Timeout: 15 * time.Minute, Permissions: exportPermissions},
+}
+
+// All returns every route, public first. The slice is a copy, so callers may not change the registry.
+func All() []Route {
+	return append([]Route{}, registry...)
+}
+
+// Lookup returns the route served by the Lambda name.
+func Lookup(name string) (Route, bool) {
+	for _, route := range registry {
+		if route.Name == name {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// Workers returns the Lambdas that are not behind the API.
+func Workers() []Route {
+	return append([]Route{}, workers...)
+}
+
+// FunctionName is the deployed name of the Lambda name in environment, which is how Lambdas address workers.
+func FunctionName(name, environment string) string {
+	return fmt.Sprintf("%s-%s", name, environment)
+}
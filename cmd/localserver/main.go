@@ -0,0 +1,104 @@
+// Command localserver runs every Lambda handler behind a single HTTP port, mounted on the same routes and
+// methods as the API Gateway stack. Point the AWS clients at local stand-ins through the endpoints section of
+// the config, e.g. the "local" environment in config/config.yaml:
+//
+//	ENVIRONMENT=local go run ./cmd/localserver -addr :8080
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/handlers/account"
+	"mentorship-app-backend/handlers/auth"
+	"mentorship-app-backend/handlers/bootstrap"
+	"mentorship-app-backend/handlers/mentorship"
+	"mentorship-app-backend/handlers/profile"
+	"mentorship-app-backend/handlers/s3"
+	"mentorship-app-backend/handlers/scheduling"
+	"mentorship-app-backend/handlers/wrapper"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	if os.Getenv("ENVIRONMENT") == "" {
+		os.Setenv("ENVIRONMENT", "local")
+	}
+	cfg := bootstrap.Init()
+	clients := bootstrap.Clients()
+
+	authHandlers := auth.New(cfg, clients)
+	accountHandlers := account.New(cfg, clients)
+	// There is no Lambda service locally, so the export worker runs in-process, within the request.
+	functions := awsapi.NewMemoryLambda()
+	functions.Register(accountHandlers.ExportWorker, accountHandlers.ExportWorkerFunction())
+	accountHandlers.Functions = functions
+	profileHandlers := profile.New(cfg, clients)
+	mentorshipHandlers := mentorship.New(cfg, clients)
+	schedulingHandlers := scheduling.New(cfg, clients)
+	s3Handlers := s3.New(cfg, clients)
+
+	handlers := map[string]wrapper.Handler{
+		routes.RegisterLambdaName:         authHandlers.Register,
+		routes.LoginLambdaName:            authHandlers.Login,
+		routes.ConfirmLambdaName:          authHandlers.Confirm,
+		routes.ResendLambdaName:           authHandlers.Resend,
+		routes.RefreshLambdaName:          authHandlers.Refresh,
+		routes.ForgotPasswordLambdaName:   authHandlers.ForgotPassword,
+		routes.ResetPasswordLambdaName:    authHandlers.ResetPassword,
+		routes.RespondChallengeLambdaName: authHandlers.RespondChallenge,
+		routes.MFAAssociateLambdaName:     authHandlers.MFAAssociate,
+		routes.MFAVerifyLambdaName:        authHandlers.MFAVerify,
+		routes.MFAPreferenceLambdaName:    authHandlers.MFAPreference,
+		routes.LogoutLambdaName:           authHandlers.Logout,
+		routes.LogoutAllLambdaName:        authHandlers.LogoutAll,
+		routes.ChangePasswordLambdaName:   authHandlers.ChangePassword,
+		routes.ChangeEmailLambdaName:      authHandlers.ChangeEmail,
+		routes.VerifyEmailLambdaName:      authHandlers.VerifyEmail,
+		routes.DeleteAccountLambdaName:    accountHandlers.DeleteAccount,
+		routes.ExportLambdaName:           accountHandlers.StartExport,
+		routes.ExportStatusLambdaName:     accountHandlers.ExportStatus,
+		routes.MeLambdaName:               authHandlers.Me,
+		routes.UpdateProfileLambdaName:    profileHandlers.UpdateProfile,
+		routes.MentorsLambdaName:          profileHandlers.Mentors,
+		routes.SendRequestLambdaName:      mentorshipHandlers.SendRequest,
+		routes.ListRequestsLambdaName:     mentorshipHandlers.ListRequests,
+		routes.UpdateRequestLambdaName:    mentorshipHandlers.UpdateRequest,
+		routes.SetAvailabilityLambdaName:  schedulingHandlers.SetAvailability,
+		routes.GetAvailabilityLambdaName:  schedulingHandlers.GetAvailability,
+		routes.BookSessionLambdaName:      schedulingHandlers.BookSession,
+		routes.ListBookingsLambdaName:     schedulingHandlers.ListBookings,
+		routes.CancelBookingLambdaName:    schedulingHandlers.CancelBooking,
+		routes.UploadLambdaName:           s3Handlers.Upload,
+		routes.DownloadLambdaName:         s3Handlers.Download,
+		routes.ListLambdaName:             s3Handlers.List,
+		routes.DeleteLambdaName:           s3Handlers.Delete,
+		routes.UploadURLLambdaName:        s3Handlers.UploadURL,
+		routes.DownloadURLLambdaName:      s3Handlers.DownloadURL,
+	}
+
+	// Every route gets the chain its deployed Lambda runs: the same notifier wrapping, and for protected routes
+	// ID token verification standing in for the API Gateway authorizer.
+	wrapped := map[string]wrapper.Handler{}
+	for _, route := range routes.All() {
+		handler, ok := handlers[route.Name]
+		if !ok {
+			log.Fatalf("no handler for lambda %q", route.Name)
+		}
+		wrapped[routeKey(route)] = bootstrap.Wrap(route, handler)
+	}
+
+	mux, err := newMux(routes.All(), wrapped)
+	if err != nil {
+		log.Fatalf("failed to mount routes: %v", err)
+	}
+
+	log.Printf("Serving %d routes for environment %s on %s", len(wrapped), cfg.Environment, *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
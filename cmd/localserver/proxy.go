@@ -0,0 +1,264 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/wrapper"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const stageName = "local"
+
+// corsHeaders mirrors DefaultCorsPreflightOptions in api/router.go.
+var corsHeaders = map[string]string{
+	"Access-Control-Allow-Origin":  "*",
+	"Access-Control-Allow-Headers": "Content-Type,Authorization,x-file-content-type",
+}
+
+func routeKey(route routes.Route) string {
+	return route.Method + " /" + route.Path
+}
+
+// resource is an API Gateway resource: a path template, whose segments in braces are path parameters, and the
+// handlers of its methods.
+type resource struct {
+	path     string
+	segments []string
+	params   int
+	methods  map[string]wrapper.Handler
+}
+
+// match returns the path parameters if path fits the template.
+func (res *resource) match(path string) (map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(res.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, segment := range res.segments {
+		if name, ok := pathParameter(segment); ok && segments[i] != "" {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[name] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func pathParameter(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// router dispatches on the path and then the method, as API Gateway does. Resources are kept most specific
+// first, so a literal segment wins over a path parameter in the same position.
+type router []*resource
+
+func (rt router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, res := range rt {
+		if params, ok := res.match(r.URL.Path); ok {
+			serveResource(res, params, w, r)
+			return
+		}
+	}
+	_ = writeProxyResponse(w, gatewayError(http.StatusNotFound, "Not found"))
+}
+
+// newMux mounts one resource per route path. Every route must have a handler, so a Lambda added to the route
+// table cannot be silently missing locally.
+func newMux(routeTable []routes.Route, handlers map[string]wrapper.Handler) (http.Handler, error) {
+	byPath := map[string]*resource{}
+	var rt router
+	for _, route := range routeTable {
+		handler, ok := handlers[routeKey(route)]
+		if !ok {
+			return nil, fmt.Errorf("no handler mounted for %s /%s", route.Method, route.Path)
+		}
+		res, ok := byPath[route.Path]
+		if !ok {
+			res = &resource{path: route.Path, segments: route.Segments(), methods: map[string]wrapper.Handler{}}
+			for _, segment := range res.segments {
+				if _, isParam := pathParameter(segment); isParam {
+					res.params++
+				}
+			}
+			byPath[route.Path] = res
+			rt = append(rt, res)
+		}
+		res.methods[route.Method] = handler
+	}
+
+	sort.SliceStable(rt, func(i, j int) bool { return rt[i].params < rt[j].params })
+	return rt, nil
+}
+
+func serveResource(res *resource, params map[string]string, w http.ResponseWriter, r *http.Request) {
+	allowed := make([]string, 0, len(res.methods)+1)
+	for method := range res.methods {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	allowed = append(allowed, http.MethodOptions)
+
+	if r.Method == http.MethodOptions {
+		for name, value := range corsHeaders {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowed, ","))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	handler, ok := res.methods[r.Method]
+	if !ok {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		_ = writeProxyResponse(w, gatewayError(http.StatusMethodNotAllowed, "Method not allowed"))
+		return
+	}
+
+	request, err := toProxyRequest(r, res.path, params)
+	if err != nil {
+		_ = writeProxyResponse(w, gatewayError(http.StatusBadRequest, "Failed to read request body"))
+		return
+	}
+
+	response, err := handler(r.Context(), request)
+	if err != nil {
+		// The Lambda runtime reports a returned error to API Gateway, which answers 502.
+		log.Printf("%s /%s returned an error: %v", r.Method, res.path, err)
+		response = gatewayError(http.StatusBadGateway, "Internal server error")
+	}
+	if err = writeProxyResponse(w, response); err != nil {
+		log.Printf("failed to write response for %s /%s: %v", r.Method, res.path, err)
+	}
+}
+
+// toProxyRequest builds the event API Gateway's Lambda proxy integration would send for r. net/http
+// canonicalises header names while API Gateway passes them as the client sent them, so each header is also
+// exposed in lower case for handlers that look up names such as x-file-content-type. resource is the path
+// template the request matched and pathParameters the values of its parameters.
+func toProxyRequest(r *http.Request, resource string, pathParameters map[string]string) (events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, err
+	}
+
+	request := events.APIGatewayProxyRequest{
+		Resource:       "/" + resource,
+		Path:           r.URL.Path,
+		HTTPMethod:     r.Method,
+		Body:           string(body),
+		PathParameters: pathParameters,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID:    newRequestID(),
+			Stage:        stageName,
+			ResourcePath: "/" + resource,
+			HTTPMethod:   r.Method,
+			Path:         "/" + stageName + r.URL.Path,
+			Identity:     events.APIGatewayRequestIdentity{SourceIP: sourceIP(r.RemoteAddr)},
+		},
+	}
+	if !utf8.Valid(body) {
+		request.Body = base64.StdEncoding.EncodeToString(body)
+		request.IsBase64Encoded = true
+	}
+
+	if len(r.Header) > 0 {
+		request.Headers = map[string]string{}
+		request.MultiValueHeaders = map[string][]string{}
+		for name, values := range r.Header {
+			for _, key := range []string{name, strings.ToLower(name)} {
+				request.Headers[key] = values[len(values)-1]
+				request.MultiValueHeaders[key] = values
+			}
+		}
+	}
+
+	if query := r.URL.Query(); len(query) > 0 {
+		request.QueryStringParameters = map[string]string{}
+		request.MultiValueQueryStringParameters = map[string][]string{}
+		for name, values := range query {
+			request.QueryStringParameters[name] = values[len(values)-1]
+			request.MultiValueQueryStringParameters[name] = values
+		}
+	}
+
+	return request, nil
+}
+
+// writeProxyResponse writes a proxy integration response the way API Gateway does, decoding binary bodies.
+func writeProxyResponse(w http.ResponseWriter, response events.APIGatewayProxyResponse) error {
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return fmt.Errorf("response body is not valid base64: %w", err)
+		}
+		body = decoded
+	}
+
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		w.Header().Del(name)
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	status := response.StatusCode
+	if status == 0 {
+		// API Gateway treats a response without a status code as a malformed integration response.
+		status = http.StatusBadGateway
+	}
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+
+// gatewayError is the body API Gateway itself answers with, as opposed to a handler's error envelope.
+func gatewayError(status int, message string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       fmt.Sprintf(`{"message":%q}`, message),
+	}
+}
+
+func newRequestID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return fmt.Sprintf("local-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func sourceIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
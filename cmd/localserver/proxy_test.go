@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/wrapper"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestToProxyRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/bookings?from=2024-06-01&tag=a&tag=b", strings.NewReader(`{"slot":"x"}`))
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("x-file-content-type", "image/png")
+
+	request, err := toProxyRequest(r, routes.BookingsResource, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if request.HTTPMethod != http.MethodPost || request.Path != "/bookings" || request.Resource != "/bookings" {
+		t.Fatalf("unexpected request line %s %s %s", request.HTTPMethod, request.Path, request.Resource)
+	}
+	if request.Body != `{"slot":"x"}` || request.IsBase64Encoded {
+		t.Fatalf("unexpected body %q", request.Body)
+	}
+	if request.Headers["Authorization"] != "Bearer token" || request.Headers["x-file-content-type"] != "image/png" {
+		t.Fatalf("unexpected headers %v", request.Headers)
+	}
+	if request.QueryStringParameters["from"] != "2024-06-01" || request.QueryStringParameters["tag"] != "b" {
+		t.Fatalf("unexpected query %v", request.QueryStringParameters)
+	}
+	if len(request.MultiValueQueryStringParameters["tag"]) != 2 {
+		t.Fatalf("unexpected multi-value query %v", request.MultiValueQueryStringParameters)
+	}
+	if request.RequestContext.RequestID == "" || request.RequestContext.Stage != stageName {
+		t.Fatalf("unexpected request context %+v", request.RequestContext)
+	}
+}
+
+func TestToProxyRequestEncodesBinaryBody(t *testing.T) {
+	binary := []byte{0xff, 0xfe, 0x00}
+	request, err := toProxyRequest(httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(string(binary))), routes.UploadLambdaName, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !request.IsBase64Encoded || request.Body != base64.StdEncoding.EncodeToString(binary) {
+		t.Fatalf("expected a base64 body, got %q", request.Body)
+	}
+}
+
+func TestWriteProxyResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := writeProxyResponse(recorder, events.APIGatewayProxyResponse{
+		StatusCode:      http.StatusOK,
+		Headers:         map[string]string{"Content-Type": "image/png"},
+		Body:            base64.StdEncoding.EncodeToString([]byte("png")),
+		IsBase64Encoded: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "png" || recorder.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("unexpected response %d %q %v", recorder.Code, recorder.Body.String(), recorder.Header())
+	}
+}
+
+func TestMuxDispatchesOnMethod(t *testing.T) {
+	handlers := map[string]wrapper.Handler{}
+	for _, route := range routes.All() {
+		lambda := route.Name
+		handlers[routeKey(route)] = func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: lambda}, nil
+		}
+	}
+	mux, err := newMux(routes.All(), handlers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		method, path string
+		wantStatus   int
+		wantBody     string
+	}{
+		{http.MethodGet, "/me", http.StatusOK, routes.MeLambdaName},
+		{http.MethodPatch, "/me", http.StatusOK, routes.UpdateProfileLambdaName},
+		{http.MethodDelete, "/bookings", http.StatusOK, routes.CancelBookingLambdaName},
+		{http.MethodPut, "/login", http.StatusMethodNotAllowed, ""},
+		{http.MethodOptions, "/availability", http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+		if recorder.Code != tt.wantStatus {
+			t.Fatalf("%s %s: expected %d, got %d", tt.method, tt.path, tt.wantStatus, recorder.Code)
+		}
+		if tt.wantBody != "" && recorder.Body.String() != tt.wantBody {
+			t.Fatalf("%s %s: routed to %q", tt.method, tt.path, recorder.Body.String())
+		}
+	}
+
+	delete(handlers, routeKey(routes.All()[0]))
+	if _, err = newMux(routes.All(), handlers); err == nil {
+		t.Fatal("expected an error for a route without a handler")
+	}
+}
+
+func TestMuxMatchesNestedPaths(t *testing.T) {
+	routeTable := []routes.Route{
+		{Name: "list", Method: http.MethodGet, Path: "bookings"},
+		{Name: "cancel", Method: http.MethodDelete, Path: "bookings/{bookingId}"},
+		{Name: "upcoming", Method: http.MethodGet, Path: "bookings/upcoming"},
+		{Name: "note", Method: http.MethodPut, Path: "bookings/{bookingId}/notes/{noteId}"},
+	}
+	handlers := map[string]wrapper.Handler{}
+	for _, route := range routeTable {
+		name := route.Name
+		handlers[routeKey(route)] = func(_ context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			body := name + " " + request.Resource
+			for _, key := range []string{"bookingId", "noteId"} {
+				if value, ok := request.PathParameters[key]; ok {
+					body += " " + key + "=" + value
+				}
+			}
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: body}, nil
+		}
+	}
+	mux, err := newMux(routeTable, handlers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		method, path string
+		wantStatus   int
+		wantBody     string
+	}{
+		{http.MethodGet, "/bookings", http.StatusOK, "list /bookings"},
+		{http.MethodDelete, "/bookings/b-1", http.StatusOK, "cancel /bookings/{bookingId} bookingId=b-1"},
+		{http.MethodGet, "/bookings/upcoming", http.StatusOK, "upcoming /bookings/upcoming"},
+		{http.MethodPut, "/bookings/b-1/notes/n-2", http.StatusOK, "note /bookings/{bookingId}/notes/{noteId} bookingId=b-1 noteId=n-2"},
+		{http.MethodGet, "/bookings/b-1", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/bookings/b-1/notes", http.StatusNotFound, ""},
+		{http.MethodGet, "/unknown", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+		if recorder.Code != tt.wantStatus {
+			t.Fatalf("%s %s: expected %d, got %d", tt.method, tt.path, tt.wantStatus, recorder.Code)
+		}
+		if tt.wantBody != "" && recorder.Body.String() != tt.wantBody {
+			t.Fatalf("%s %s: got %q, want %q", tt.method, tt.path, recorder.Body.String(), tt.wantBody)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Command migrate-user-ids re-keys rows written while users were identified by email onto their Cognito sub,
+// which never changes when a user changes their email. It is safe to run repeatedly: rows already keyed by sub
+// are left alone, so a second run only picks up what the first could not resolve.
+//
+//	ENVIRONMENT=staging go run ./cmd/migrate-user-ids -dry-run
+//	ENVIRONMENT=staging go run ./cmd/migrate-user-ids
+//
+// Run it while writes are paused. A row changed between the scan and its move keeps the scanned values.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"mentorship-app-backend/config"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(os.Getenv("ENVIRONMENT"))
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	clients, err := config.NewClients(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize AWS clients: %v", err)
+	}
+
+	m := &migrator{
+		db:         clients.DynamoDB,
+		users:      clients.Cognito,
+		userPoolID: cfg.UserPoolID(),
+		dryRun:     *dryRun,
+		subs:       map[string]string{},
+	}
+
+	report, err := m.run(context.Background(), tablesFor(cfg))
+	if err != nil {
+		log.Fatalf("migration stopped: %v", err)
+	}
+	report.log()
+	if len(report.Unresolved) > 0 || report.Conflicts > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// dynamoAPI is the part of the DynamoDB client the migration uses. Scan is not in awsapi.DynamoDBAPI because no
+// handler should ever need it.
+type dynamoAPI interface {
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+type userLookup interface {
+	AdminGetUser(ctx context.Context, params *cognitoidentityprovider.AdminGetUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminGetUserOutput, error)
+}
+
+// table describes where a table stores user IDs. Key holds the key attributes; an ID in the first one moves the
+// row, since DynamoDB cannot update a key in place. References are rewritten without moving the row.
+type table struct {
+	Name       string
+	Key        []string
+	References []string
+}
+
+func tablesFor(cfg config.Config) []table {
+	return []table{
+		{Name: cfg.UserProfileDDBTableName, Key: []string{"UserId", "ProfileType"}},
+		{Name: cfg.AvailabilityDDBTableName, Key: []string{"MentorId"}},
+		{Name: cfg.BookingsDDBTableName, Key: []string{"UserId", "StartTime"}, References: []string{"MentorId", "MenteeId"}},
+		{Name: cfg.MentorshipRequestsDDBTableName, Key: []string{"RequestId"}, References: []string{"MentorId", "MenteeId"}},
+	}
+}
+
+type report struct {
+	Moved      map[string]int
+	Rewritten  map[string]int
+	Conflicts  int
+	Unresolved map[string]bool
+}
+
+func (r report) log() {
+	for _, name := range sortedKeys(r.Moved, r.Rewritten) {
+		log.Printf("%s: %d rows moved to a sub key, %d rows with references rewritten", name, r.Moved[name], r.Rewritten[name])
+	}
+	if r.Conflicts > 0 {
+		log.Printf("%d rows were left in place because a row already exists under the sub or the row changed", r.Conflicts)
+	}
+	for _, email := range sortedKeys(r.Unresolved) {
+		log.Printf("no Cognito user for %s, its rows were left in place", email)
+	}
+}
+
+type migrator struct {
+	db         dynamoAPI
+	users      userLookup
+	userPoolID string
+	dryRun     bool
+	// subs caches resolved emails, since one user appears in many rows.
+	subs map[string]string
+}
+
+func (m *migrator) run(ctx context.Context, tables []table) (report, error) {
+	result := report{Moved: map[string]int{}, Rewritten: map[string]int{}, Unresolved: map[string]bool{}}
+	for _, t := range tables {
+		if err := m.migrateTable(ctx, t, &result); err != nil {
+			return result, fmt.Errorf("%s: %w", t.Name, err)
+		}
+	}
+	return result, nil
+}
+
+func (m *migrator) migrateTable(ctx context.Context, t table, result *report) error {
+	var startKey map[string]types.AttributeValue
+	for {
+		page, err := m.db.Scan(ctx, &dynamodb.ScanInput{TableName: aws.String(t.Name), ExclusiveStartKey: startKey})
+		if err != nil {
+			return fmt.Errorf("failed to scan: %w", err)
+		}
+		for _, item := range page.Items {
+			if err = m.migrateItem(ctx, t, item, result); err != nil {
+				return err
+			}
+		}
+		if len(page.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		startKey = page.LastEvaluatedKey
+	}
+}
+
+func (m *migrator) migrateItem(ctx context.Context, t table, item map[string]types.AttributeValue, result *report) error {
+	changes := map[string]string{}
+	for _, attribute := range append([]string{t.Key[0]}, t.References...) {
+		id := stringValue(item[attribute])
+		if !isEmailID(id) {
+			continue
+		}
+		sub, err := m.resolve(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sub == "" {
+			result.Unresolved[id] = true
+			continue
+		}
+		changes[attribute] = sub
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, moves := changes[t.Key[0]]
+	if m.dryRun {
+		log.Printf("%s: would change %v in %s", t.Name, changes, describeKey(t, item))
+	} else {
+		var err error
+		if moves {
+			err = m.move(ctx, t, item, changes)
+		} else {
+			err = m.rewrite(ctx, t, item, changes)
+		}
+		if isConditionFailure(err) {
+			log.Printf("%s: skipped: %v", t.Name, err)
+			result.Conflicts++
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if moves {
+		result.Moved[t.Name]++
+	} else {
+		result.Rewritten[t.Name]++
+	}
+	return nil
+}
+
+// move writes the row under its new key and deletes the old one in a single transaction, so a failed run never
+// leaves a user with two rows or none.
+func (m *migrator) move(ctx context.Context, t table, item map[string]types.AttributeValue, changes map[string]string) error {
+	moved := make(map[string]types.AttributeValue, len(item))
+	for attribute, value := range item {
+		moved[attribute] = value
+	}
+	for attribute, sub := range changes {
+		moved[attribute] = &types.AttributeValueMemberS{Value: sub}
+	}
+
+	names := map[string]string{"#pk": t.Key[0]}
+	_, err := m.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:                aws.String(t.Name),
+			Item:                     moved,
+			ConditionExpression:      aws.String("attribute_not_exists(#pk)"),
+			ExpressionAttributeNames: names,
+		}},
+		{Delete: &types.Delete{
+			TableName:                aws.String(t.Name),
+			Key:                      keyOf(t, item),
+			ConditionExpression:      aws.String("attribute_exists(#pk)"),
+			ExpressionAttributeNames: names,
+		}},
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to move %s: %w", describeKey(t, item), err)
+	}
+	return nil
+}
+
+// rewrite updates references in place, on the condition that they still hold the scanned emails.
+func (m *migrator) rewrite(ctx context.Context, t table, item map[string]types.AttributeValue, changes map[string]string) error {
+	attributes := make([]string, 0, len(changes))
+	for attribute := range changes {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	names := map[string]string{}
+	values := map[string]types.AttributeValue{}
+	sets := make([]string, 0, len(attributes))
+	conditions := make([]string, 0, len(attributes))
+	for i, attribute := range attributes {
+		names[fmt.Sprintf("#a%d", i)] = attribute
+		values[fmt.Sprintf(":new%d", i)] = &types.AttributeValueMemberS{Value: changes[attribute]}
+		values[fmt.Sprintf(":old%d", i)] = item[attribute]
+		sets = append(sets, fmt.Sprintf("#a%d = :new%d", i, i))
+		conditions = append(conditions, fmt.Sprintf("#a%d = :old%d", i, i))
+	}
+
+	_, err := m.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(t.Name),
+		Key:                       keyOf(t, item),
+		UpdateExpression:          aws.String("SET " + strings.Join(sets, ", ")),
+		ConditionExpression:       aws.String(strings.Join(conditions, " AND ")),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rewrite %s: %w", describeKey(t, item), err)
+	}
+	return nil
+}
+
+// resolve returns the sub of the user signing in with email, or "" if the pool has no such user.
+func (m *migrator) resolve(ctx context.Context, email string) (string, error) {
+	if sub, ok := m.subs[email]; ok {
+		return sub, nil
+	}
+	user, err := m.users.AdminGetUser(ctx, &cognitoidentityprovider.AdminGetUserInput{
+		UserPoolId: aws.String(m.userPoolID),
+		Username:   aws.String(email),
+	})
+	if err != nil && !errorpackage.IsUserNotFoundError(err) {
+		return "", fmt.Errorf("failed to look up %s: %w", email, err)
+	}
+
+	sub := ""
+	if err == nil {
+		for _, attribute := range user.UserAttributes {
+			if aws.ToString(attribute.Name) == "sub" {
+				sub = aws.ToString(attribute.Value)
+			}
+		}
+	}
+	m.subs[email] = sub
+	return sub, nil
+}
+
+// isEmailID reports an ID from before the switch to subs. Cognito subs are UUIDs and never contain an @.
+func isEmailID(id string) bool {
+	return strings.Contains(id, "@")
+}
+
+func isConditionFailure(err error) bool {
+	var conditionErr *types.ConditionalCheckFailedException
+	var canceledErr *types.TransactionCanceledException
+	return errors.As(err, &conditionErr) || errors.As(err, &canceledErr)
+}
+
+func keyOf(t table, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	key := make(map[string]types.AttributeValue, len(t.Key))
+	for _, attribute := range t.Key {
+		key[attribute] = item[attribute]
+	}
+	return key
+}
+
+func describeKey(t table, item map[string]types.AttributeValue) string {
+	parts := make([]string, 0, len(t.Key))
+	for _, attribute := range t.Key {
+		parts = append(parts, attribute+"="+stringValue(item[attribute]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func stringValue(value types.AttributeValue) string {
+	if s, ok := value.(*types.AttributeValueMemberS); ok {
+		return s.Value
+	}
+	return ""
+}
+
+func sortedKeys[V any](maps ...map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
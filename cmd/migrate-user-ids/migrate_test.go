@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// fakeDynamo keeps each table as a map from its key to the item. It only understands the expressions the
+// migration sends, and scans two items per page so pagination is exercised.
+type fakeDynamo struct {
+	tables map[string][]string
+	items  map[string]map[string]map[string]types.AttributeValue
+	writes int
+}
+
+func newFakeDynamo(tables []table) *fakeDynamo {
+	db := &fakeDynamo{tables: map[string][]string{}, items: map[string]map[string]map[string]types.AttributeValue{}}
+	for _, t := range tables {
+		db.tables[t.Name] = t.Key
+		db.items[t.Name] = map[string]map[string]types.AttributeValue{}
+	}
+	return db
+}
+
+func (db *fakeDynamo) put(tableName string, attributes map[string]string) {
+	item := map[string]types.AttributeValue{}
+	for name, value := range attributes {
+		item[name] = &types.AttributeValueMemberS{Value: value}
+	}
+	db.items[tableName][db.key(tableName, item)] = item
+}
+
+func (db *fakeDynamo) get(tableName string, key ...string) (map[string]string, bool) {
+	item, ok := db.items[tableName][strings.Join(key, "|")]
+	if !ok {
+		return nil, false
+	}
+	values := map[string]string{}
+	for name, value := range item {
+		values[name] = stringValue(value)
+	}
+	return values, true
+}
+
+func (db *fakeDynamo) key(tableName string, item map[string]types.AttributeValue) string {
+	parts := make([]string, 0, len(db.tables[tableName]))
+	for _, attribute := range db.tables[tableName] {
+		parts = append(parts, stringValue(item[attribute]))
+	}
+	return strings.Join(parts, "|")
+}
+
+func (db *fakeDynamo) Scan(_ context.Context, params *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	tableName := aws.ToString(params.TableName)
+	keys := make([]string, 0, len(db.items[tableName]))
+	for key := range db.items[tableName] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if params.ExclusiveStartKey != nil {
+		after := db.key(tableName, params.ExclusiveStartKey)
+		start = sort.SearchStrings(keys, after) + 1
+	}
+	output := &dynamodb.ScanOutput{}
+	for i := start; i < len(keys) && i < start+2; i++ {
+		output.Items = append(output.Items, db.items[tableName][keys[i]])
+	}
+	if start+2 < len(keys) {
+		output.LastEvaluatedKey = output.Items[len(output.Items)-1]
+	}
+	return output, nil
+}
+
+func (db *fakeDynamo) TransactWriteItems(_ context.Context, params *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	for _, item := range params.TransactItems {
+		switch {
+		case item.Put != nil:
+			if _, exists := db.items[aws.ToString(item.Put.TableName)][db.key(aws.ToString(item.Put.TableName), item.Put.Item)]; exists {
+				return nil, &types.TransactionCanceledException{Message: aws.String("ConditionalCheckFailed")}
+			}
+		case item.Delete != nil:
+			if _, exists := db.items[aws.ToString(item.Delete.TableName)][db.key(aws.ToString(item.Delete.TableName), item.Delete.Key)]; !exists {
+				return nil, &types.TransactionCanceledException{Message: aws.String("ConditionalCheckFailed")}
+			}
+		}
+	}
+	for _, item := range params.TransactItems {
+		if item.Put != nil {
+			db.items[aws.ToString(item.Put.TableName)][db.key(aws.ToString(item.Put.TableName), item.Put.Item)] = item.Put.Item
+		} else {
+			delete(db.items[aws.ToString(item.Delete.TableName)], db.key(aws.ToString(item.Delete.TableName), item.Delete.Key))
+		}
+	}
+	db.writes++
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+// UpdateItem applies "#a0 = :new0, ..." after checking every "#a0 = :old0" of the condition.
+func (db *fakeDynamo) UpdateItem(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	tableName := aws.ToString(params.TableName)
+	item, ok := db.items[tableName][db.key(tableName, params.Key)]
+	if !ok {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("missing item")}
+	}
+	for _, condition := range strings.Split(aws.ToString(params.ConditionExpression), " AND ") {
+		var name, value string
+		fmt.Sscanf(condition, "%s = %s", &name, &value)
+		if stringValue(item[params.ExpressionAttributeNames[name]]) != stringValue(params.ExpressionAttributeValues[value]) {
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("condition failed")}
+		}
+	}
+	for _, set := range strings.Split(strings.TrimPrefix(aws.ToString(params.UpdateExpression), "SET "), ", ") {
+		var name, value string
+		fmt.Sscanf(set, "%s = %s", &name, &value)
+		item[params.ExpressionAttributeNames[name]] = params.ExpressionAttributeValues[value]
+	}
+	db.writes++
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+type fixture struct {
+	tables   []table
+	db       *fakeDynamo
+	migrator *migrator
+}
+
+// newFixture seeds a mentor and a mentee from before the switch to subs. The mentee's own booking row was
+// already moved by an earlier run, but still references both users by email.
+func newFixture() *fixture {
+	tables := tablesFor(config.Config{
+		UserProfileDDBTableName:        "profiles",
+		AvailabilityDDBTableName:       "availability",
+		BookingsDDBTableName:           "bookings",
+		MentorshipRequestsDDBTableName: "requests",
+	})
+	cognito := awsapi.NewMemoryCognito("client")
+	cognito.AddUser("ada@example.com", "Secret123", nil)
+	cognito.AddUser("grace@example.com", "Secret123", nil)
+
+	db := newFakeDynamo(tables)
+	db.put("profiles", map[string]string{"UserId": "ada@example.com", "ProfileType": "mentor", "Email": "ada@example.com", "Bio": "Compilers"})
+	db.put("profiles", map[string]string{"UserId": "sub-2", "ProfileType": "mentee", "Email": "grace@example.com"})
+	db.put("profiles", map[string]string{"UserId": "ghost@example.com", "ProfileType": "mentee", "Email": "ghost@example.com"})
+	db.put("availability", map[string]string{"MentorId": "ada@example.com", "TimeZone": "UTC"})
+	db.put("bookings", map[string]string{"UserId": "ada@example.com", "StartTime": "2024-06-03T09:00:00Z", "MentorId": "ada@example.com", "MenteeId": "grace@example.com"})
+	db.put("bookings", map[string]string{"UserId": "sub-2", "StartTime": "2024-06-03T09:00:00Z", "MentorId": "ada@example.com", "MenteeId": "grace@example.com"})
+	db.put("requests", map[string]string{"RequestId": "r1", "MentorId": "ada@example.com", "MenteeId": "grace@example.com", "Status": "accepted"})
+
+	return &fixture{
+		tables:   tables,
+		db:       db,
+		migrator: &migrator{db: db, users: cognito, userPoolID: "pool", subs: map[string]string{}},
+	}
+}
+
+func TestMigrateUserIDs(t *testing.T) {
+	f := newFixture()
+
+	result, err := f.migrator.run(context.Background(), f.tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profile, ok := f.db.get("profiles", "sub-1", "mentor")
+	if !ok || profile["Bio"] != "Compilers" || profile["Email"] != "ada@example.com" {
+		t.Fatalf("profile was not moved intact: %v", profile)
+	}
+	if _, ok = f.db.get("profiles", "ada@example.com", "mentor"); ok {
+		t.Fatal("the email-keyed profile is still there")
+	}
+	if _, ok = f.db.get("availability", "sub-1"); !ok {
+		t.Fatal("availability was not moved")
+	}
+	for _, owner := range []string{"sub-1", "sub-2"} {
+		booking, ok := f.db.get("bookings", owner, "2024-06-03T09:00:00Z")
+		if !ok || booking["MentorId"] != "sub-1" || booking["MenteeId"] != "sub-2" {
+			t.Fatalf("booking row of %s was not migrated: %v", owner, booking)
+		}
+	}
+	if request, _ := f.db.get("requests", "r1"); request["MentorId"] != "sub-1" || request["MenteeId"] != "sub-2" || request["Status"] != "accepted" {
+		t.Fatalf("mentorship request was not rewritten: %v", request)
+	}
+
+	if _, ok = f.db.get("profiles", "ghost@example.com", "mentee"); !ok || !result.Unresolved["ghost@example.com"] {
+		t.Fatalf("expected the unknown user to be reported and left in place: %+v", result)
+	}
+	if result.Moved["profiles"] != 1 || result.Moved["bookings"] != 1 || result.Rewritten["bookings"] != 1 || result.Rewritten["requests"] != 1 {
+		t.Fatalf("unexpected report %+v", result)
+	}
+
+	writes := f.db.writes
+	again, err := f.migrator.run(context.Background(), f.tables)
+	if err != nil || f.db.writes != writes || len(again.Moved) != 0 || len(again.Rewritten) != 0 {
+		t.Fatalf("expected a second run to change nothing, got %+v after %d writes: %v", again, f.db.writes-writes, err)
+	}
+}
+
+func TestMigrateUserIDsLeavesConflictsInPlace(t *testing.T) {
+	f := newFixture()
+	f.db.put("profiles", map[string]string{"UserId": "sub-1", "ProfileType": "mentor", "Email": "ada@example.com", "Bio": "Newer"})
+
+	result, err := f.migrator.run(context.Background(), f.tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Conflicts != 1 {
+		t.Fatalf("expected one conflict, got %+v", result)
+	}
+	if profile, _ := f.db.get("profiles", "sub-1", "mentor"); profile["Bio"] != "Newer" {
+		t.Fatalf("the existing sub-keyed profile was overwritten: %v", profile)
+	}
+	if _, ok := f.db.get("profiles", "ada@example.com", "mentor"); !ok {
+		t.Fatal("the conflicting email-keyed profile was deleted")
+	}
+}
+
+func TestMigrateUserIDsDryRun(t *testing.T) {
+	f := newFixture()
+	f.migrator.dryRun = true
+
+	result, err := f.migrator.run(context.Background(), f.tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.db.writes != 0 {
+		t.Fatalf("dry run wrote %d times", f.db.writes)
+	}
+	if result.Moved["profiles"] != 1 || result.Rewritten["requests"] != 1 {
+		t.Fatalf("expected the dry run to report the pending changes, got %+v", result)
+	}
+}
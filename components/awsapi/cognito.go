@@ -0,0 +1,770 @@
+package awsapi
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// ConfirmationCode is the code MemoryCognito "sends" for sign-up confirmation and password resets.
+const ConfirmationCode = "123456"
+
+// TOTPCode is the code every authenticator app enrolled with MemoryCognito shows.
+const TOTPCode = "654321"
+
+type CognitoUser struct {
+	Sub        string
+	Password   string
+	Confirmed  bool
+	Attributes map[string]string
+
+	// TOTPSecret is set by AssociateSoftwareToken, TOTPVerified once VerifySoftwareToken accepted a code and
+	// TOTPEnabled while SetUserMFAPreference has software token MFA switched on.
+	TOTPSecret   string
+	TOTPVerified bool
+	TOTPEnabled  bool
+	// ForceChangePassword makes the next sign-in answer NEW_PASSWORD_REQUIRED, as for an admin-created user.
+	ForceChangePassword bool
+	// PendingEmail is an email change waiting for VerifyUserAttribute. Like a pool that keeps the original value
+	// active while an update is pending, the user keeps signing in with the old email until then.
+	PendingEmail string
+}
+
+// challengeSession is an open sign-in waiting for the user to answer challenge.
+type challengeSession struct {
+	username  string
+	challenge types.ChallengeNameType
+}
+
+// MemoryCognito is an in-memory user pool implementing CognitoAPI. It answers with the same typed exceptions as
+// Cognito, so handlers map its failures exactly as they would in production. ID tokens are unsigned.
+type MemoryCognito struct {
+	Faults
+
+	ClientID string
+
+	mu            sync.Mutex
+	users         map[string]*CognitoUser
+	codes         map[string]string
+	refreshTokens map[string]string
+	// origins holds the origin_jti of the sign-in that issued each refresh token. Tokens refreshed with it keep
+	// that origin_jti, as Cognito's do.
+	origins     map[string]string
+	sessions    map[string]challengeSession
+	nextSub     int
+	nextSession int
+	nextRefresh int
+	now         func() time.Time
+}
+
+func NewMemoryCognito(clientID string) *MemoryCognito {
+	return &MemoryCognito{
+		ClientID:      clientID,
+		users:         map[string]*CognitoUser{},
+		codes:         map[string]string{},
+		refreshTokens: map[string]string{},
+		origins:       map[string]string{},
+		sessions:      map[string]challengeSession{},
+		now:           time.Now,
+	}
+}
+
+// AddUser seeds a confirmed user with a verified email and returns its sub.
+func (c *MemoryCognito) AddUser(username, password string, attributes map[string]string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	user := c.newUser(username, password)
+	user.Confirmed = true
+	user.Attributes["email_verified"] = "true"
+	for name, value := range attributes {
+		user.Attributes[name] = value
+	}
+	return user.Sub
+}
+
+// Update changes the stored user, e.g. to require a password change or switch MFA on without enrolling first.
+func (c *MemoryCognito) Update(username string, update func(user *CognitoUser)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if user, ok := c.users[username]; ok {
+		update(user)
+	}
+}
+
+// User returns a copy of the stored user.
+func (c *MemoryCognito) User(username string) (CognitoUser, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	user, ok := c.users[username]
+	if !ok {
+		return CognitoUser{}, false
+	}
+	copied := *user
+	copied.Attributes = map[string]string{}
+	for name, value := range user.Attributes {
+		copied.Attributes[name] = value
+	}
+	return copied, true
+}
+
+func (c *MemoryCognito) SignUp(_ context.Context, params *cognitoidentityprovider.SignUpInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.SignUpOutput, error) {
+	if err := c.Err("SignUp"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, exists := c.users[username]; exists {
+		return nil, &types.UsernameExistsException{Message: aws.String("User already exists")}
+	}
+	user := c.newUser(username, aws.ToString(params.Password))
+	for _, attribute := range params.UserAttributes {
+		user.Attributes[aws.ToString(attribute.Name)] = aws.ToString(attribute.Value)
+	}
+	c.codes[username] = ConfirmationCode
+	return &cognitoidentityprovider.SignUpOutput{UserSub: aws.String(user.Sub), UserConfirmed: false}, nil
+}
+
+func (c *MemoryCognito) ConfirmSignUp(_ context.Context, params *cognitoidentityprovider.ConfirmSignUpInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ConfirmSignUpOutput, error) {
+	if err := c.Err("ConfirmSignUp"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	if err = c.consumeCode(aws.ToString(params.Username), aws.ToString(params.ConfirmationCode)); err != nil {
+		return nil, err
+	}
+	user.Confirmed = true
+	user.Attributes["email_verified"] = "true"
+	return &cognitoidentityprovider.ConfirmSignUpOutput{}, nil
+}
+
+func (c *MemoryCognito) ResendConfirmationCode(_ context.Context, params *cognitoidentityprovider.ResendConfirmationCodeInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ResendConfirmationCodeOutput, error) {
+	if err := c.Err("ResendConfirmationCode"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	user, err := c.lookup(username)
+	if err != nil {
+		return nil, err
+	}
+	if user.Confirmed {
+		return nil, &types.InvalidParameterException{Message: aws.String("User is already confirmed.")}
+	}
+	c.codes[username] = ConfirmationCode
+	return &cognitoidentityprovider.ResendConfirmationCodeOutput{
+		CodeDeliveryDetails: &types.CodeDeliveryDetailsType{Destination: aws.String(username)},
+	}, nil
+}
+
+func (c *MemoryCognito) InitiateAuth(_ context.Context, params *cognitoidentityprovider.InitiateAuthInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.InitiateAuthOutput, error) {
+	if err := c.Err("InitiateAuth"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch params.AuthFlow {
+	case types.AuthFlowTypeUserPasswordAuth:
+		username := params.AuthParameters["USERNAME"]
+		user, err := c.lookup(username)
+		if err != nil {
+			return nil, err
+		}
+		if user.Password != params.AuthParameters["PASSWORD"] {
+			return nil, &types.NotAuthorizedException{Message: aws.String("Incorrect username or password.")}
+		}
+		if !user.Confirmed {
+			return nil, &types.UserNotConfirmedException{Message: aws.String("User is not confirmed.")}
+		}
+		challenge, session, result, err := c.signIn(username, user)
+		if err != nil {
+			return nil, err
+		}
+		return &cognitoidentityprovider.InitiateAuthOutput{ChallengeName: challenge, Session: session, AuthenticationResult: result}, nil
+
+	case types.AuthFlowTypeRefreshTokenAuth:
+		refreshToken := params.AuthParameters["REFRESH_TOKEN"]
+		username, ok := c.refreshTokens[refreshToken]
+		if !ok {
+			return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Refresh Token")}
+		}
+		user, err := c.lookup(username)
+		if err != nil {
+			return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Refresh Token")}
+		}
+		result, err := c.issueTokens(user, c.origins[refreshToken])
+		if err != nil {
+			return nil, err
+		}
+		return &cognitoidentityprovider.InitiateAuthOutput{AuthenticationResult: result}, nil
+
+	default:
+		return nil, &types.InvalidParameterException{Message: aws.String(fmt.Sprintf("unsupported auth flow %s", params.AuthFlow))}
+	}
+}
+
+func (c *MemoryCognito) ForgotPassword(_ context.Context, params *cognitoidentityprovider.ForgotPasswordInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ForgotPasswordOutput, error) {
+	if err := c.Err("ForgotPassword"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, err := c.lookup(username); err != nil {
+		return nil, err
+	}
+	c.codes[username] = ConfirmationCode
+	return &cognitoidentityprovider.ForgotPasswordOutput{}, nil
+}
+
+func (c *MemoryCognito) ConfirmForgotPassword(_ context.Context, params *cognitoidentityprovider.ConfirmForgotPasswordInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ConfirmForgotPasswordOutput, error) {
+	if err := c.Err("ConfirmForgotPassword"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	user, err := c.lookup(username)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.consumeCode(username, aws.ToString(params.ConfirmationCode)); err != nil {
+		return nil, err
+	}
+	user.Password = aws.ToString(params.Password)
+	return &cognitoidentityprovider.ConfirmForgotPasswordOutput{}, nil
+}
+
+func (c *MemoryCognito) AdminGetUser(_ context.Context, params *cognitoidentityprovider.AdminGetUserInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminGetUserOutput, error) {
+	if err := c.Err("AdminGetUser"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	status := types.UserStatusTypeUnconfirmed
+	if user.Confirmed {
+		status = types.UserStatusTypeConfirmed
+	}
+	return &cognitoidentityprovider.AdminGetUserOutput{
+		Username:       params.Username,
+		UserAttributes: attributeList(user),
+		UserStatus:     status,
+		Enabled:        true,
+	}, nil
+}
+
+func (c *MemoryCognito) AdminUpdateUserAttributes(_ context.Context, params *cognitoidentityprovider.AdminUpdateUserAttributesInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminUpdateUserAttributesOutput, error) {
+	if err := c.Err("AdminUpdateUserAttributes"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range params.UserAttributes {
+		user.Attributes[aws.ToString(attribute.Name)] = aws.ToString(attribute.Value)
+	}
+	return &cognitoidentityprovider.AdminUpdateUserAttributesOutput{}, nil
+}
+
+func (c *MemoryCognito) AdminDeleteUser(_ context.Context, params *cognitoidentityprovider.AdminDeleteUserInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminDeleteUserOutput, error) {
+	if err := c.Err("AdminDeleteUser"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	username := aws.ToString(params.Username)
+	if _, err := c.lookup(username); err != nil {
+		return nil, err
+	}
+	delete(c.users, username)
+	delete(c.codes, username)
+	return &cognitoidentityprovider.AdminDeleteUserOutput{}, nil
+}
+
+// ListUsers supports the filters Cognito does: `attribute = "value"` for an exact match and
+// `attribute ^= "value"` for a prefix, or no filter for every user. Users come back sorted by username, in one
+// page.
+func (c *MemoryCognito) ListUsers(_ context.Context, params *cognitoidentityprovider.ListUsersInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ListUsersOutput, error) {
+	if err := c.Err("ListUsers"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match, err := parseUserFilter(aws.ToString(params.Filter))
+	if err != nil {
+		return nil, err
+	}
+	usernames := make([]string, 0, len(c.users))
+	for username := range c.users {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	output := &cognitoidentityprovider.ListUsersOutput{}
+	for _, username := range usernames {
+		user := c.users[username]
+		if !match(user) {
+			continue
+		}
+		if limit := aws.ToInt32(params.Limit); limit > 0 && len(output.Users) == int(limit) {
+			break
+		}
+		status := types.UserStatusTypeUnconfirmed
+		if user.Confirmed {
+			status = types.UserStatusTypeConfirmed
+		}
+		output.Users = append(output.Users, types.UserType{
+			Username:   aws.String(username),
+			Attributes: attributeList(user),
+			UserStatus: status,
+			Enabled:    true,
+		})
+	}
+	return output, nil
+}
+
+func (c *MemoryCognito) RespondToAuthChallenge(_ context.Context, params *cognitoidentityprovider.RespondToAuthChallengeInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.RespondToAuthChallengeOutput, error) {
+	if err := c.Err("RespondToAuthChallenge"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token := aws.ToString(params.Session)
+	session, ok := c.sessions[token]
+	if !ok || session.challenge != params.ChallengeName || session.username != params.ChallengeResponses["USERNAME"] {
+		return nil, &types.NotAuthorizedException{Message: aws.String("Invalid session for the user, session is expired.")}
+	}
+	user, err := c.lookup(session.username)
+	if err != nil {
+		return nil, err
+	}
+
+	switch session.challenge {
+	case types.ChallengeNameTypeSoftwareTokenMfa:
+		if params.ChallengeResponses["SOFTWARE_TOKEN_MFA_CODE"] != TOTPCode {
+			return nil, &types.CodeMismatchException{Message: aws.String("Invalid code received for user")}
+		}
+		delete(c.sessions, token)
+		result, err := c.issueSignInTokens(session.username, user)
+		if err != nil {
+			return nil, err
+		}
+		return &cognitoidentityprovider.RespondToAuthChallengeOutput{AuthenticationResult: result}, nil
+
+	case types.ChallengeNameTypeNewPasswordRequired:
+		password := params.ChallengeResponses["NEW_PASSWORD"]
+		if password == "" {
+			return nil, &types.InvalidParameterException{Message: aws.String("Missing required parameter NEW_PASSWORD")}
+		}
+		delete(c.sessions, token)
+		user.Password = password
+		user.ForceChangePassword = false
+		challenge, next, result, err := c.signIn(session.username, user)
+		if err != nil {
+			return nil, err
+		}
+		return &cognitoidentityprovider.RespondToAuthChallengeOutput{ChallengeName: challenge, Session: next, AuthenticationResult: result}, nil
+	}
+	return nil, &types.InvalidParameterException{Message: aws.String(fmt.Sprintf("unsupported challenge %s", session.challenge))}
+}
+
+func (c *MemoryCognito) AssociateSoftwareToken(_ context.Context, params *cognitoidentityprovider.AssociateSoftwareTokenInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AssociateSoftwareTokenOutput, error) {
+	if err := c.Err("AssociateSoftwareToken"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	// Associating again replaces the secret, so the previous authenticator has to be verified anew.
+	user.TOTPSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("totp-" + user.Sub))
+	user.TOTPVerified = false
+	return &cognitoidentityprovider.AssociateSoftwareTokenOutput{SecretCode: aws.String(user.TOTPSecret)}, nil
+}
+
+func (c *MemoryCognito) VerifySoftwareToken(_ context.Context, params *cognitoidentityprovider.VerifySoftwareTokenInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.VerifySoftwareTokenOutput, error) {
+	if err := c.Err("VerifySoftwareToken"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPSecret == "" {
+		return nil, &types.InvalidParameterException{Message: aws.String("User has not associated a software token")}
+	}
+	if aws.ToString(params.UserCode) != TOTPCode {
+		return nil, &types.EnableSoftwareTokenMFAException{Message: aws.String("Code mismatch")}
+	}
+	user.TOTPVerified = true
+	return &cognitoidentityprovider.VerifySoftwareTokenOutput{Status: types.VerifySoftwareTokenResponseTypeSuccess}, nil
+}
+
+func (c *MemoryCognito) SetUserMFAPreference(_ context.Context, params *cognitoidentityprovider.SetUserMFAPreferenceInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.SetUserMFAPreferenceOutput, error) {
+	if err := c.Err("SetUserMFAPreference"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	if settings := params.SoftwareTokenMfaSettings; settings != nil {
+		if settings.Enabled && !user.TOTPVerified {
+			return nil, &types.InvalidParameterException{Message: aws.String("User has not verified software token mfa")}
+		}
+		user.TOTPEnabled = settings.Enabled
+	}
+	return &cognitoidentityprovider.SetUserMFAPreferenceOutput{}, nil
+}
+
+func (c *MemoryCognito) RevokeToken(_ context.Context, params *cognitoidentityprovider.RevokeTokenInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.RevokeTokenOutput, error) {
+	if err := c.Err("RevokeToken"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if aws.ToString(params.ClientId) != c.ClientID {
+		return nil, &types.UnauthorizedException{Message: aws.String("Invalid client")}
+	}
+	token := aws.ToString(params.Token)
+	if _, ok := c.refreshTokens[token]; !ok {
+		return nil, &types.UnsupportedTokenTypeException{Message: aws.String("Invalid refresh token")}
+	}
+	delete(c.refreshTokens, token)
+	delete(c.origins, token)
+	return &cognitoidentityprovider.RevokeTokenOutput{}, nil
+}
+
+// GlobalSignOut revokes every refresh token of the access token's user. The fake's access tokens are derived
+// from the sub, so unlike Cognito's they keep working afterwards.
+func (c *MemoryCognito) GlobalSignOut(_ context.Context, params *cognitoidentityprovider.GlobalSignOutInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.GlobalSignOutOutput, error) {
+	if err := c.Err("GlobalSignOut"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	for token, username := range c.refreshTokens {
+		if c.users[username] == user {
+			delete(c.refreshTokens, token)
+		}
+	}
+	return &cognitoidentityprovider.GlobalSignOutOutput{}, nil
+}
+
+func (c *MemoryCognito) ChangePassword(_ context.Context, params *cognitoidentityprovider.ChangePasswordInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.ChangePasswordOutput, error) {
+	if err := c.Err("ChangePassword"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToString(params.PreviousPassword) != user.Password {
+		return nil, &types.NotAuthorizedException{Message: aws.String("Incorrect username or password.")}
+	}
+	user.Password = aws.ToString(params.ProposedPassword)
+	return &cognitoidentityprovider.ChangePasswordOutput{}, nil
+}
+
+func (c *MemoryCognito) GetUser(_ context.Context, params *cognitoidentityprovider.GetUserInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.GetUserOutput, error) {
+	if err := c.Err("GetUser"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	return &cognitoidentityprovider.GetUserOutput{
+		Username:       aws.String(c.usernameOf(user)),
+		UserAttributes: attributeList(user),
+	}, nil
+}
+
+// UpdateUserAttributes only supports changing the email, which it leaves pending with ConfirmationCode sent to
+// the new address.
+func (c *MemoryCognito) UpdateUserAttributes(_ context.Context, params *cognitoidentityprovider.UpdateUserAttributesInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.UpdateUserAttributesOutput, error) {
+	if err := c.Err("UpdateUserAttributes"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range params.UserAttributes {
+		if aws.ToString(attribute.Name) != "email" {
+			return nil, &types.InvalidParameterException{Message: aws.String("Only the email can be updated")}
+		}
+		email := aws.ToString(attribute.Value)
+		if _, taken := c.users[email]; taken {
+			return nil, &types.AliasExistsException{Message: aws.String("An account with the given email already exists.")}
+		}
+		user.PendingEmail = email
+		c.codes[emailCodeKey(user)] = ConfirmationCode
+	}
+	return &cognitoidentityprovider.UpdateUserAttributesOutput{
+		CodeDeliveryDetailsList: []types.CodeDeliveryDetailsType{{
+			AttributeName:  aws.String("email"),
+			DeliveryMedium: types.DeliveryMediumTypeEmail,
+			Destination:    aws.String(user.PendingEmail),
+		}},
+	}, nil
+}
+
+// VerifyUserAttribute makes a pending email the user's sign-in email, re-keying the user the way a pool with
+// email sign-in resolves the new address.
+func (c *MemoryCognito) VerifyUserAttribute(_ context.Context, params *cognitoidentityprovider.VerifyUserAttributeInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.VerifyUserAttributeOutput, error) {
+	if err := c.Err("VerifyUserAttribute"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	user, err := c.userByAccessToken(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	if aws.ToString(params.AttributeName) != "email" || user.PendingEmail == "" {
+		return nil, &types.InvalidParameterException{Message: aws.String("No pending update for the attribute")}
+	}
+	if err = c.consumeCode(emailCodeKey(user), aws.ToString(params.Code)); err != nil {
+		return nil, err
+	}
+
+	previous := user.Attributes["email"]
+	delete(c.users, previous)
+	c.users[user.PendingEmail] = user
+	for token, username := range c.refreshTokens {
+		if username == previous {
+			c.refreshTokens[token] = user.PendingEmail
+		}
+	}
+	for id, session := range c.sessions {
+		if session.username == previous {
+			session.username = user.PendingEmail
+			c.sessions[id] = session
+		}
+	}
+	user.Attributes["email"] = user.PendingEmail
+	user.Attributes["email_verified"] = "true"
+	user.PendingEmail = ""
+	return &cognitoidentityprovider.VerifyUserAttributeOutput{}, nil
+}
+
+func emailCodeKey(user *CognitoUser) string {
+	return "email:" + user.Sub
+}
+
+func (c *MemoryCognito) newUser(username, password string) *CognitoUser {
+	c.nextSub++
+	user := &CognitoUser{
+		Sub:        fmt.Sprintf("sub-%d", c.nextSub),
+		Password:   password,
+		Attributes: map[string]string{"email": username, "email_verified": "false"},
+	}
+	c.users[username] = user
+	return user
+}
+
+func (c *MemoryCognito) lookup(username string) (*CognitoUser, error) {
+	user, ok := c.users[username]
+	if !ok {
+		return nil, &types.UserNotFoundException{Message: aws.String("User does not exist.")}
+	}
+	return user, nil
+}
+
+// usernameOf returns the username user is stored under, which is the email it signed up with.
+func (c *MemoryCognito) usernameOf(user *CognitoUser) string {
+	for username, stored := range c.users {
+		if stored == user {
+			return username
+		}
+	}
+	return ""
+}
+
+// userByAccessToken resolves the access tokens issueTokens hands out, which are not JWTs.
+func (c *MemoryCognito) userByAccessToken(accessToken string) (*CognitoUser, error) {
+	for _, user := range c.users {
+		if accessToken == "access-"+user.Sub {
+			return user, nil
+		}
+	}
+	return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Access Token")}
+}
+
+// signIn completes a sign-in step: it opens a session for the next challenge the user owes, or issues tokens.
+func (c *MemoryCognito) signIn(username string, user *CognitoUser) (types.ChallengeNameType, *string, *types.AuthenticationResultType, error) {
+	var challenge types.ChallengeNameType
+	switch {
+	case user.ForceChangePassword:
+		challenge = types.ChallengeNameTypeNewPasswordRequired
+	case user.TOTPEnabled:
+		challenge = types.ChallengeNameTypeSoftwareTokenMfa
+	default:
+		result, err := c.issueSignInTokens(username, user)
+		return "", nil, result, err
+	}
+	c.nextSession++
+	session := fmt.Sprintf("session-%d", c.nextSession)
+	c.sessions[session] = challengeSession{username: username, challenge: challenge}
+	return challenge, aws.String(session), nil, nil
+}
+
+// issueSignInTokens issues tokens together with a refresh token, as a completed sign-in does. The sign-in's
+// origin_jti is "origin-<sub>-<n>", numbered like its refresh token.
+func (c *MemoryCognito) issueSignInTokens(username string, user *CognitoUser) (*types.AuthenticationResultType, error) {
+	origin := fmt.Sprintf("origin-%s-%d", user.Sub, c.nextRefresh)
+	result, err := c.issueTokens(user, origin)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken := fmt.Sprintf("refresh-%s-%d", user.Sub, c.nextRefresh)
+	c.nextRefresh++
+	c.refreshTokens[refreshToken] = username
+	c.origins[refreshToken] = origin
+	result.RefreshToken = aws.String(refreshToken)
+	return result, nil
+}
+
+func (c *MemoryCognito) consumeCode(username, code string) error {
+	if pending, ok := c.codes[username]; !ok || pending != code {
+		return &types.CodeMismatchException{Message: aws.String("Invalid verification code provided, please try again.")}
+	}
+	delete(c.codes, username)
+	return nil
+}
+
+func (c *MemoryCognito) issueTokens(user *CognitoUser, origin string) (*types.AuthenticationResultType, error) {
+	now := c.now()
+	claims := map[string]interface{}{
+		"sub":            user.Sub,
+		"origin_jti":     origin,
+		"email":          user.Attributes["email"],
+		"email_verified": user.Attributes["email_verified"] == "true",
+		"name":           user.Attributes["name"],
+		"custom:role":    user.Attributes["custom:role"],
+		"aud":            c.ClientID,
+		"token_use":      "id",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	idToken := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + ".unsigned"
+
+	return &types.AuthenticationResultType{
+		AccessToken: aws.String("access-" + user.Sub),
+		IdToken:     aws.String(idToken),
+		ExpiresIn:   3600,
+		TokenType:   aws.String("Bearer"),
+	}, nil
+}
+
+// parseUserFilter turns a ListUsers filter into a predicate over users.
+func parseUserFilter(filter string) (func(user *CognitoUser) bool, error) {
+	if strings.TrimSpace(filter) == "" {
+		return func(*CognitoUser) bool { return true }, nil
+	}
+	invalid := &types.InvalidParameterException{Message: aws.String("Invalid search filter: " + filter)}
+	for _, operator := range []string{"^=", "="} {
+		name, quoted, found := strings.Cut(filter, operator)
+		if !found {
+			continue
+		}
+		name, quoted = strings.TrimSpace(name), strings.TrimSpace(quoted)
+		value, err := strconv.Unquote(quoted)
+		if err != nil || name == "" {
+			return nil, invalid
+		}
+		return func(user *CognitoUser) bool {
+			actual := user.Attributes[name]
+			if name == "sub" {
+				actual = user.Sub
+			}
+			if operator == "^=" {
+				return strings.HasPrefix(actual, value)
+			}
+			return actual == value
+		}, nil
+	}
+	return nil, invalid
+}
+
+func attributeList(user *CognitoUser) []types.AttributeType {
+	names := make([]string, 0, len(user.Attributes)+1)
+	for name := range user.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attributes := []types.AttributeType{{Name: aws.String("sub"), Value: aws.String(user.Sub)}}
+	for _, name := range names {
+		attributes = append(attributes, types.AttributeType{Name: aws.String(name), Value: aws.String(user.Attributes[name])})
+	}
+	return attributes
+}
+
+var _ CognitoAPI = (*MemoryCognito)(nil)
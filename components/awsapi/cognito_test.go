@@ -0,0 +1,267 @@
+package awsapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func login(c *MemoryCognito, username, password string) (*cognitoidentityprovider.InitiateAuthOutput, error) {
+	return c.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		AuthParameters: map[string]string{"USERNAME": username, "PASSWORD": password},
+	})
+}
+
+func TestMemoryCognitoSignUpFlow(t *testing.T) {
+	ctx := context.Background()
+	cognito := NewMemoryCognito("client")
+
+	_, err := cognito.SignUp(ctx, &cognitoidentityprovider.SignUpInput{
+		Username:       aws.String("ada@example.com"),
+		Password:       aws.String("Secret123!"),
+		UserAttributes: []types.AttributeType{{Name: aws.String("custom:role"), Value: aws.String("mentor")}},
+	})
+	if err != nil {
+		t.Fatalf("sign up: %v", err)
+	}
+	if _, err = cognito.SignUp(ctx, &cognitoidentityprovider.SignUpInput{Username: aws.String("ada@example.com")}); !errorpackage.IsUserAlreadyExistsError(err) {
+		t.Fatalf("expected UsernameExistsException, got %v", err)
+	}
+
+	if _, err = login(cognito, "ada@example.com", "Secret123!"); errorpackage.AWSErrorCode(err) != "UserNotConfirmedException" {
+		t.Fatalf("expected an unconfirmed user to be rejected, got %v", err)
+	}
+
+	_, err = cognito.ConfirmSignUp(ctx, &cognitoidentityprovider.ConfirmSignUpInput{Username: aws.String("ada@example.com"), ConfirmationCode: aws.String("000000")})
+	if !errorpackage.IsCodeMismatchError(err) {
+		t.Fatalf("expected CodeMismatchException, got %v", err)
+	}
+	_, err = cognito.ConfirmSignUp(ctx, &cognitoidentityprovider.ConfirmSignUpInput{Username: aws.String("ada@example.com"), ConfirmationCode: aws.String(ConfirmationCode)})
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	if _, err = login(cognito, "ada@example.com", "wrong"); !errorpackage.IsInvalidCredentialsError(err) {
+		t.Fatalf("expected NotAuthorizedException, got %v", err)
+	}
+	output, err := login(cognito, "ada@example.com", "Secret123!")
+	if err != nil || output.AuthenticationResult.RefreshToken == nil {
+		t.Fatalf("login: %+v %v", output, err)
+	}
+
+	refreshed, err := cognito.InitiateAuth(ctx, &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeRefreshTokenAuth,
+		AuthParameters: map[string]string{"REFRESH_TOKEN": *output.AuthenticationResult.RefreshToken},
+	})
+	if err != nil || refreshed.AuthenticationResult.IdToken == nil {
+		t.Fatalf("refresh: %+v %v", refreshed, err)
+	}
+}
+
+func TestMemoryCognitoSoftwareTokenMFA(t *testing.T) {
+	ctx := context.Background()
+	cognito := NewMemoryCognito("client")
+	cognito.AddUser("ada@example.com", "Secret123!", nil)
+
+	output, err := login(cognito, "ada@example.com", "Secret123!")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	accessToken := output.AuthenticationResult.AccessToken
+
+	enable := &cognitoidentityprovider.SetUserMFAPreferenceInput{
+		AccessToken:              accessToken,
+		SoftwareTokenMfaSettings: &types.SoftwareTokenMfaSettingsType{Enabled: true, PreferredMfa: true},
+	}
+	if _, err = cognito.SetUserMFAPreference(ctx, enable); !errorpackage.IsMFANotEnrolledError(err) {
+		t.Fatalf("expected MFA to need a verified token, got %v", err)
+	}
+
+	associated, err := cognito.AssociateSoftwareToken(ctx, &cognitoidentityprovider.AssociateSoftwareTokenInput{AccessToken: accessToken})
+	if err != nil || aws.ToString(associated.SecretCode) == "" {
+		t.Fatalf("associate: %+v %v", associated, err)
+	}
+	_, err = cognito.VerifySoftwareToken(ctx, &cognitoidentityprovider.VerifySoftwareTokenInput{AccessToken: accessToken, UserCode: aws.String("000000")})
+	if !errorpackage.IsCodeMismatchError(err) {
+		t.Fatalf("expected EnableSoftwareTokenMFAException, got %v", err)
+	}
+	if _, err = cognito.VerifySoftwareToken(ctx, &cognitoidentityprovider.VerifySoftwareTokenInput{AccessToken: accessToken, UserCode: aws.String(TOTPCode)}); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if _, err = cognito.SetUserMFAPreference(ctx, enable); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+
+	output, err = login(cognito, "ada@example.com", "Secret123!")
+	if err != nil || output.ChallengeName != types.ChallengeNameTypeSoftwareTokenMfa || output.AuthenticationResult != nil {
+		t.Fatalf("expected a SOFTWARE_TOKEN_MFA challenge, got %+v %v", output, err)
+	}
+	respond := func(code string) (*cognitoidentityprovider.RespondToAuthChallengeOutput, error) {
+		return cognito.RespondToAuthChallenge(ctx, &cognitoidentityprovider.RespondToAuthChallengeInput{
+			ChallengeName:      types.ChallengeNameTypeSoftwareTokenMfa,
+			Session:            output.Session,
+			ChallengeResponses: map[string]string{"USERNAME": "ada@example.com", "SOFTWARE_TOKEN_MFA_CODE": code},
+		})
+	}
+	if _, err = respond("000000"); !errorpackage.IsCodeMismatchError(err) {
+		t.Fatalf("expected CodeMismatchException, got %v", err)
+	}
+	answered, err := respond(TOTPCode)
+	if err != nil || answered.AuthenticationResult == nil || answered.AuthenticationResult.RefreshToken == nil {
+		t.Fatalf("respond: %+v %v", answered, err)
+	}
+	if _, err = respond(TOTPCode); !errorpackage.IsInvalidSessionError(err) {
+		t.Fatalf("expected a used session to be rejected, got %v", err)
+	}
+}
+
+func TestMemoryCognitoNewPasswordRequired(t *testing.T) {
+	cognito := NewMemoryCognito("client")
+	cognito.AddUser("ada@example.com", "Temporary1!", nil)
+	cognito.Update("ada@example.com", func(user *CognitoUser) { user.ForceChangePassword = true })
+
+	output, err := login(cognito, "ada@example.com", "Temporary1!")
+	if err != nil || output.ChallengeName != types.ChallengeNameTypeNewPasswordRequired {
+		t.Fatalf("expected a NEW_PASSWORD_REQUIRED challenge, got %+v %v", output, err)
+	}
+	answered, err := cognito.RespondToAuthChallenge(context.Background(), &cognitoidentityprovider.RespondToAuthChallengeInput{
+		ChallengeName:      types.ChallengeNameTypeNewPasswordRequired,
+		Session:            output.Session,
+		ChallengeResponses: map[string]string{"USERNAME": "ada@example.com", "NEW_PASSWORD": "Permanent1!"},
+	})
+	if err != nil || answered.AuthenticationResult == nil {
+		t.Fatalf("respond: %+v %v", answered, err)
+	}
+	if _, err = login(cognito, "ada@example.com", "Permanent1!"); err != nil {
+		t.Fatalf("expected the new password to work, got %v", err)
+	}
+}
+
+func TestMemoryCognitoInjectedFault(t *testing.T) {
+	cognito := NewMemoryCognito("client")
+	cognito.AddUser("ada@example.com", "Secret123!", nil)
+
+	outage := errors.New("service unavailable")
+	cognito.Fail("InitiateAuth", outage)
+	if _, err := login(cognito, "ada@example.com", "Secret123!"); !errors.Is(err, outage) {
+		t.Fatalf("expected the injected error, got %v", err)
+	}
+
+	cognito.Fail("InitiateAuth", nil)
+	if _, err := login(cognito, "ada@example.com", "Secret123!"); err != nil {
+		t.Fatalf("expected the fault to be cleared, got %v", err)
+	}
+}
+
+func TestMemoryCognitoChangeEmail(t *testing.T) {
+	ctx := context.Background()
+	cognito := NewMemoryCognito("client")
+	sub := cognito.AddUser("ada@example.com", "Secret123!", nil)
+	cognito.AddUser("grace@example.com", "Secret123!", nil)
+	accessToken := aws.String("access-" + sub)
+
+	_, err := cognito.UpdateUserAttributes(ctx, &cognitoidentityprovider.UpdateUserAttributesInput{
+		AccessToken:    accessToken,
+		UserAttributes: []types.AttributeType{{Name: aws.String("email"), Value: aws.String("grace@example.com")}},
+	})
+	if !errorpackage.IsUserAlreadyExistsError(err) {
+		t.Fatalf("expected AliasExistsException, got %v", err)
+	}
+	_, err = cognito.UpdateUserAttributes(ctx, &cognitoidentityprovider.UpdateUserAttributesInput{
+		AccessToken:    accessToken,
+		UserAttributes: []types.AttributeType{{Name: aws.String("email"), Value: aws.String("ada@lovelace.dev")}},
+	})
+	if err != nil {
+		t.Fatalf("update attributes: %v", err)
+	}
+	if _, err = login(cognito, "ada@example.com", "Secret123!"); err != nil {
+		t.Fatalf("expected the old email to sign in until the change is verified, got %v", err)
+	}
+
+	verify := &cognitoidentityprovider.VerifyUserAttributeInput{AccessToken: accessToken, AttributeName: aws.String("email"), Code: aws.String("000000")}
+	if _, err = cognito.VerifyUserAttribute(ctx, verify); !errorpackage.IsCodeMismatchError(err) {
+		t.Fatalf("expected CodeMismatchException, got %v", err)
+	}
+	verify.Code = aws.String(ConfirmationCode)
+	if _, err = cognito.VerifyUserAttribute(ctx, verify); err != nil {
+		t.Fatalf("verify attribute: %v", err)
+	}
+
+	if _, err = login(cognito, "ada@example.com", "Secret123!"); !errorpackage.IsInvalidCredentialsError(err) {
+		t.Fatalf("expected the old email to stop signing in, got %v", err)
+	}
+	if _, err = login(cognito, "ada@lovelace.dev", "Secret123!"); err != nil {
+		t.Fatalf("expected the new email to sign in, got %v", err)
+	}
+	user, _ := cognito.User("ada@lovelace.dev")
+	if user.Sub != sub || user.Attributes["email"] != "ada@lovelace.dev" || user.PendingEmail != "" {
+		t.Fatalf("unexpected user after the change: %+v", user)
+	}
+}
+
+func TestMemoryCognitoChangePassword(t *testing.T) {
+	cognito := NewMemoryCognito("client")
+	sub := cognito.AddUser("ada@example.com", "Secret123!", nil)
+
+	input := &cognitoidentityprovider.ChangePasswordInput{
+		AccessToken:      aws.String("access-" + sub),
+		PreviousPassword: aws.String("wrong"),
+		ProposedPassword: aws.String("Changed123!"),
+	}
+	if _, err := cognito.ChangePassword(context.Background(), input); !errorpackage.IsInvalidCredentialsError(err) {
+		t.Fatalf("expected NotAuthorizedException, got %v", err)
+	}
+	input.PreviousPassword = aws.String("Secret123!")
+	if _, err := cognito.ChangePassword(context.Background(), input); err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+	if _, err := login(cognito, "ada@example.com", "Changed123!"); err != nil {
+		t.Fatalf("expected the new password to work, got %v", err)
+	}
+}
+
+func TestMemoryCognitoListUsers(t *testing.T) {
+	cognito := NewMemoryCognito("client")
+	sub := cognito.AddUser("ada@example.com", "Secret123!", nil)
+	cognito.AddUser("grace@example.com", "Secret123!", nil)
+
+	list := func(filter string) ([]string, error) {
+		output, err := cognito.ListUsers(context.Background(), &cognitoidentityprovider.ListUsersInput{Filter: aws.String(filter)})
+		if err != nil {
+			return nil, err
+		}
+		var usernames []string
+		for _, user := range output.Users {
+			usernames = append(usernames, aws.ToString(user.Username))
+		}
+		return usernames, nil
+	}
+
+	if usernames, err := list(`sub = "` + sub + `"`); err != nil || len(usernames) != 1 || usernames[0] != "ada@example.com" {
+		t.Fatalf("expected ada by sub, got %v %v", usernames, err)
+	}
+	if usernames, err := list(`email ^= "grace"`); err != nil || len(usernames) != 1 || usernames[0] != "grace@example.com" {
+		t.Fatalf("expected grace by email prefix, got %v %v", usernames, err)
+	}
+	if usernames, err := list(`sub = "sub-unknown"`); err != nil || len(usernames) != 0 {
+		t.Fatalf("expected no users, got %v %v", usernames, err)
+	}
+	if usernames, err := list(""); err != nil || len(usernames) != 2 {
+		t.Fatalf("expected every user, got %v %v", usernames, err)
+	}
+	if _, err := list("sub"); err == nil {
+		t.Fatal("expected an invalid filter to be rejected")
+	}
+
+	// Usernames are the emails, so looking a user up by sub finds nobody.
+	if _, err := cognito.AdminGetUser(context.Background(), &cognitoidentityprovider.AdminGetUserInput{Username: aws.String(sub)}); !errorpackage.IsUserNotFoundError(err) {
+		t.Fatalf("expected UserNotFoundException for a sub, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package awsapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// LambdaFunction is a function MemoryLambda runs in-process, called with the invocation's payload.
+type LambdaFunction func(ctx context.Context, payload []byte) error
+
+// MemoryLambda is an in-memory LambdaAPI that runs the functions registered with Register. Invoke returns once
+// the function has run, whatever the invocation type, so tests and the local server see its effects straight
+// away. Unknown functions fail with ResourceNotFoundException, as Lambda does.
+type MemoryLambda struct {
+	Faults
+
+	mu          sync.Mutex
+	functions   map[string]LambdaFunction
+	invocations []lambda.InvokeInput
+}
+
+func NewMemoryLambda() *MemoryLambda {
+	return &MemoryLambda{functions: map[string]LambdaFunction{}}
+}
+
+// Register makes name invokable.
+func (l *MemoryLambda) Register(name string, function LambdaFunction) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.functions[name] = function
+}
+
+// Invocations returns every call to Invoke so far, in order.
+func (l *MemoryLambda) Invocations() []lambda.InvokeInput {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]lambda.InvokeInput{}, l.invocations...)
+}
+
+// Invoke reports a failing function through FunctionError, as Lambda does for synchronous invocations. An Event
+// invocation only fails if the function does not exist.
+func (l *MemoryLambda) Invoke(ctx context.Context, params *lambda.InvokeInput, _ ...func(*lambda.Options)) (*lambda.InvokeOutput, error) {
+	if err := l.Err("Invoke"); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	l.invocations = append(l.invocations, *params)
+	function, ok := l.functions[aws.ToString(params.FunctionName)]
+	l.mu.Unlock()
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Function not found: " + aws.ToString(params.FunctionName))}
+	}
+
+	err := function(ctx, params.Payload)
+	if params.InvocationType == types.InvocationTypeEvent {
+		return &lambda.InvokeOutput{StatusCode: http.StatusAccepted}, nil
+	}
+	output := &lambda.InvokeOutput{StatusCode: http.StatusOK}
+	if err != nil {
+		output.FunctionError = aws.String("Unhandled")
+		output.Payload, _ = json.Marshal(map[string]string{"errorMessage": err.Error()})
+	}
+	return output, nil
+}
+
+var _ LambdaAPI = (*MemoryLambda)(nil)
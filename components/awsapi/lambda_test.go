@@ -0,0 +1,40 @@
+package awsapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestMemoryLambda(t *testing.T) {
+	ctx := context.Background()
+	functions := NewMemoryLambda()
+
+	var received string
+	functions.Register("worker", func(_ context.Context, payload []byte) error {
+		received = string(payload)
+		return errors.New("boom")
+	})
+
+	output, err := functions.Invoke(ctx, &lambda.InvokeInput{FunctionName: aws.String("worker"), InvocationType: types.InvocationTypeEvent, Payload: []byte(`{"a":1}`)})
+	if err != nil || output.StatusCode != 202 || output.FunctionError != nil || received != `{"a":1}` {
+		t.Fatalf("expected the event to be accepted and run, got %+v, %q: %v", output, received, err)
+	}
+
+	output, err = functions.Invoke(ctx, &lambda.InvokeInput{FunctionName: aws.String("worker")})
+	if err != nil || aws.ToString(output.FunctionError) != "Unhandled" || string(output.Payload) != `{"errorMessage":"boom"}` {
+		t.Fatalf("expected a synchronous call to report the function error, got %+v: %v", output, err)
+	}
+
+	var notFound *types.ResourceNotFoundException
+	if _, err = functions.Invoke(ctx, &lambda.InvokeInput{FunctionName: aws.String("missing")}); !errors.As(err, &notFound) {
+		t.Fatalf("expected ResourceNotFoundException, got %v", err)
+	}
+	if len(functions.Invocations()) != 3 {
+		t.Fatalf("expected 3 recorded invocations, got %d", len(functions.Invocations()))
+	}
+}
@@ -0,0 +1,206 @@
+package awsapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+type Object struct {
+	Body        []byte
+	ContentType string
+}
+
+// MemoryS3 is an in-memory object store implementing S3API and PresignAPI. Missing objects fail with the same
+// NoSuchKey and NotFound errors S3 returns; presigned URLs point at a fake host and are never fetched.
+type MemoryS3 struct {
+	Faults
+
+	mu      sync.Mutex
+	buckets map[string]map[string]Object
+}
+
+func NewMemoryS3() *MemoryS3 {
+	return &MemoryS3{buckets: map[string]map[string]Object{}}
+}
+
+// Object returns the stored object.
+func (s *MemoryS3) Object(bucket, key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.buckets[bucket][key]
+	return object, ok
+}
+
+// Put stores an object directly, for seeding tests.
+func (s *MemoryS3) Put(bucket, key string, object Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets[bucket] == nil {
+		s.buckets[bucket] = map[string]Object{}
+	}
+	s.buckets[bucket][key] = object
+}
+
+func (s *MemoryS3) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	if err := s.Err("PutObject"); err != nil {
+		return nil, err
+	}
+	var body []byte
+	if params.Body != nil {
+		var err error
+		if body, err = io.ReadAll(params.Body); err != nil {
+			return nil, fmt.Errorf("failed to read object body: %w", err)
+		}
+	}
+	s.Put(aws.ToString(params.Bucket), aws.ToString(params.Key), Object{Body: body, ContentType: aws.ToString(params.ContentType)})
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (s *MemoryS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if err := s.Err("GetObject"); err != nil {
+		return nil, err
+	}
+	object, ok := s.Object(aws.ToString(params.Bucket), aws.ToString(params.Key))
+	if !ok {
+		return nil, &types.NoSuchKey{Message: aws.String("The specified key does not exist.")}
+	}
+	return &s3.GetObjectOutput{
+		Body:          io.NopCloser(bytes.NewReader(object.Body)),
+		ContentType:   aws.String(object.ContentType),
+		ContentLength: aws.Int64(int64(len(object.Body))),
+	}, nil
+}
+
+func (s *MemoryS3) HeadObject(_ context.Context, params *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	if err := s.Err("HeadObject"); err != nil {
+		return nil, err
+	}
+	object, ok := s.Object(aws.ToString(params.Bucket), aws.ToString(params.Key))
+	if !ok {
+		return nil, &types.NotFound{}
+	}
+	return &s3.HeadObjectOutput{
+		ContentType:   aws.String(object.ContentType),
+		ContentLength: aws.Int64(int64(len(object.Body))),
+	}, nil
+}
+
+// DeleteObject succeeds for missing keys, as S3 does.
+func (s *MemoryS3) DeleteObject(_ context.Context, params *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	if err := s.Err("DeleteObject"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buckets[aws.ToString(params.Bucket)], aws.ToString(params.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+// MaxDeleteObjects is the most keys S3 accepts in one DeleteObjects request.
+const MaxDeleteObjects = 1000
+
+// DeleteObjects rejects more than MaxDeleteObjects keys with MalformedXML, as S3 does. Missing keys count as
+// deleted.
+func (s *MemoryS3) DeleteObjects(_ context.Context, params *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	if err := s.Err("DeleteObjects"); err != nil {
+		return nil, err
+	}
+	if params.Delete == nil || len(params.Delete.Objects) == 0 || len(params.Delete.Objects) > MaxDeleteObjects {
+		return nil, &smithy.GenericAPIError{Code: "MalformedXML", Message: "The XML you provided was not well-formed or did not validate against our published schema"}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output := &s3.DeleteObjectsOutput{}
+	for _, object := range params.Delete.Objects {
+		delete(s.buckets[aws.ToString(params.Bucket)], aws.ToString(object.Key))
+		if !aws.ToBool(params.Delete.Quiet) {
+			output.Deleted = append(output.Deleted, types.DeletedObject{Key: object.Key})
+		}
+	}
+	return output, nil
+}
+
+// ListObjectsV2 returns keys in lexical order. The continuation token is the last key of the previous page.
+func (s *MemoryS3) ListObjectsV2(_ context.Context, params *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	if err := s.Err("ListObjectsV2"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix, after := aws.ToString(params.Prefix), aws.ToString(params.ContinuationToken)
+	var keys []string
+	for key := range s.buckets[aws.ToString(params.Bucket)] {
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	maxKeys := int(aws.ToInt32(params.MaxKeys))
+	if maxKeys <= 0 {
+		maxKeys = 1000
+	}
+	output := &s3.ListObjectsV2Output{Prefix: params.Prefix, IsTruncated: aws.Bool(len(keys) > maxKeys)}
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+		output.NextContinuationToken = aws.String(keys[len(keys)-1])
+	}
+	for _, key := range keys {
+		object := s.buckets[aws.ToString(params.Bucket)][key]
+		output.Contents = append(output.Contents, types.Object{Key: aws.String(key), Size: aws.Int64(int64(len(object.Body)))})
+	}
+	output.KeyCount = aws.Int32(int32(len(output.Contents)))
+	return output, nil
+}
+
+func (s *MemoryS3) PresignGetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if err := s.Err("PresignGetObject"); err != nil {
+		return nil, err
+	}
+	return &v4.PresignedHTTPRequest{
+		URL:          presignedURL(aws.ToString(params.Bucket), aws.ToString(params.Key)),
+		Method:       http.MethodGet,
+		SignedHeader: http.Header{"Host": {aws.ToString(params.Bucket) + ".s3.local"}},
+	}, nil
+}
+
+func (s *MemoryS3) PresignPutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if err := s.Err("PresignPutObject"); err != nil {
+		return nil, err
+	}
+	header := http.Header{"Host": {aws.ToString(params.Bucket) + ".s3.local"}}
+	if params.ContentType != nil {
+		header.Set("Content-Type", aws.ToString(params.ContentType))
+	}
+	if params.ContentLength != nil {
+		header.Set("Content-Length", fmt.Sprint(aws.ToInt64(params.ContentLength)))
+	}
+	return &v4.PresignedHTTPRequest{
+		URL:          presignedURL(aws.ToString(params.Bucket), aws.ToString(params.Key)),
+		Method:       http.MethodPut,
+		SignedHeader: header,
+	}, nil
+}
+
+func presignedURL(bucket, key string) string {
+	return fmt.Sprintf("https://%s.s3.local/%s?X-Amz-Signature=fake", bucket, key)
+}
+
+var (
+	_ S3API      = (*MemoryS3)(nil)
+	_ PresignAPI = (*MemoryS3)(nil)
+)
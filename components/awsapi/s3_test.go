@@ -0,0 +1,77 @@
+package awsapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func TestMemoryS3(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryS3()
+
+	_, err := store.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String("b"), Key: aws.String("users/a/1.txt"), Body: strings.NewReader("one")})
+	if err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	store.Put("b", "users/a/2.txt", Object{Body: []byte("two")})
+	store.Put("b", "users/b/1.txt", Object{Body: []byte("other")})
+
+	paginator := s3.NewListObjectsV2Paginator(store, &s3.ListObjectsV2Input{Bucket: aws.String("b"), Prefix: aws.String("users/a/"), MaxKeys: aws.Int32(1)})
+	var keys []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, aws.ToString(object.Key))
+		}
+	}
+	if strings.Join(keys, ",") != "users/a/1.txt,users/a/2.txt" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	if _, err = store.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("b"), Key: aws.String("missing")}); !errorpackage.IsS3NotFoundError(err) {
+		t.Fatalf("expected NoSuchKey, got %v", err)
+	}
+	if _, err = store.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("b"), Key: aws.String("missing")}); !errorpackage.IsS3NotFoundError(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestMemoryS3DeleteObjects(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryS3()
+	store.Put("b", "users/a/1.txt", Object{Body: []byte("one")})
+	store.Put("b", "users/b/1.txt", Object{Body: []byte("other")})
+
+	_, err := store.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String("b"),
+		Delete: &types.Delete{Objects: []types.ObjectIdentifier{{Key: aws.String("users/a/1.txt")}, {Key: aws.String("missing")}}},
+	})
+	if err != nil {
+		t.Fatalf("delete objects: %v", err)
+	}
+	if _, ok := store.Object("b", "users/a/1.txt"); ok {
+		t.Fatal("object was not deleted")
+	}
+	if _, ok := store.Object("b", "users/b/1.txt"); !ok {
+		t.Fatal("an object that was not listed was deleted")
+	}
+
+	tooMany := make([]types.ObjectIdentifier, MaxDeleteObjects+1)
+	for i := range tooMany {
+		tooMany[i] = types.ObjectIdentifier{Key: aws.String("users/b/1.txt")}
+	}
+	_, err = store.DeleteObjects(ctx, &s3.DeleteObjectsInput{Bucket: aws.String("b"), Delete: &types.Delete{Objects: tooMany}})
+	if errorpackage.AWSErrorCode(err) != "MalformedXML" {
+		t.Fatalf("expected MalformedXML for an oversized batch, got %v", err)
+	}
+}
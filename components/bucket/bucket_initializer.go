@@ -4,23 +4,31 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
 	"github.com/aws/jsii-runtime-go"
-	"mentorship-app-backend/permissions"
+	"mentorship-app-backend/handlers/s3/ownership"
 )
 
 func InitializeBucket(stack awscdk.Stack, bucketName string) awss3.Bucket {
 	bucket := awss3.NewBucket(stack, jsii.String(bucketName), &awss3.BucketProps{
 		BucketName: jsii.String(bucketName),
 		Versioned:  jsii.Bool(false),
-		BlockPublicAccess: awss3.NewBlockPublicAccess(&awss3.BlockPublicAccessOptions{
-			BlockPublicAcls:       jsii.Bool(false),
-			BlockPublicPolicy:     jsii.Bool(false),
-			IgnorePublicAcls:      jsii.Bool(false),
-			RestrictPublicBuckets: jsii.Bool(false),
-		}),
-		PublicReadAccess: jsii.Bool(true),
+		// Every object belongs to a user, so nothing is readable without the handlers' ownership checks.
+		BlockPublicAccess: awss3.BlockPublicAccess_BLOCK_ALL(),
+		// Browsers upload and download through presigned URLs directly against the bucket.
+		Cors: &[]*awss3.CorsRule{
+			{
+				AllowedMethods: &[]awss3.HttpMethods{awss3.HttpMethods_GET, awss3.HttpMethods_PUT},
+				AllowedOrigins: jsii.Strings("*"),
+				AllowedHeaders: jsii.Strings("*"),
+				MaxAge:         jsii.Number(3000),
+			},
+		},
+		LifecycleRules: &[]*awss3.LifecycleRule{
+			{
+				Prefix:     jsii.String(ownership.ExportsPrefix),
+				Expiration: awscdk.Duration_Days(jsii.Number(ownership.ExportRetentionDays)),
+			},
+		},
 	})
 
-	permissions.GrantPublicReadAccess(bucket)
-
 	return bucket
 }
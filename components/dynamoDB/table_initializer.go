@@ -4,14 +4,106 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awsdynamodb"
 	"github.com/aws/jsii-runtime-go"
+	"mentorship-app-backend/repository/accountjob"
+	"mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"mentorship-app-backend/repository/revocation"
 )
 
+type Tables struct {
+	Profile            awsdynamodb.Table
+	MentorshipRequests awsdynamodb.Table
+	Availability       awsdynamodb.Table
+	Bookings           awsdynamodb.Table
+	RevokedTokens      awsdynamodb.Table
+	AccountJobs        awsdynamodb.Table
+}
+
 func InitializeProfileTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
-	return awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+	table := awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
 		TableName:     jsii.String(tableName),
 		PartitionKey:  &awsdynamodb.Attribute{Name: jsii.String("UserId"), Type: awsdynamodb.AttributeType_STRING},
 		SortKey:       &awsdynamodb.Attribute{Name: jsii.String("ProfileType"), Type: awsdynamodb.AttributeType_STRING},
 		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
 		RemovalPolicy: removalPolicy,
 	})
+
+	table.AddGlobalSecondaryIndex(&awsdynamodb.GlobalSecondaryIndexProps{
+		IndexName:      jsii.String(profile.ProfileTypeIndex),
+		PartitionKey:   &awsdynamodb.Attribute{Name: jsii.String("ProfileType"), Type: awsdynamodb.AttributeType_STRING},
+		SortKey:        &awsdynamodb.Attribute{Name: jsii.String("UserId"), Type: awsdynamodb.AttributeType_STRING},
+		ProjectionType: awsdynamodb.ProjectionType_ALL,
+	})
+
+	return table
+}
+
+func InitializeMentorshipRequestTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
+	table := awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+		TableName:     jsii.String(tableName),
+		PartitionKey:  &awsdynamodb.Attribute{Name: jsii.String("RequestId"), Type: awsdynamodb.AttributeType_STRING},
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		RemovalPolicy: removalPolicy,
+	})
+
+	table.AddGlobalSecondaryIndex(&awsdynamodb.GlobalSecondaryIndexProps{
+		IndexName:      jsii.String(mentorship.MentorIndex),
+		PartitionKey:   &awsdynamodb.Attribute{Name: jsii.String("MentorId"), Type: awsdynamodb.AttributeType_STRING},
+		SortKey:        &awsdynamodb.Attribute{Name: jsii.String("CreatedAt"), Type: awsdynamodb.AttributeType_STRING},
+		ProjectionType: awsdynamodb.ProjectionType_ALL,
+	})
+	table.AddGlobalSecondaryIndex(&awsdynamodb.GlobalSecondaryIndexProps{
+		IndexName:      jsii.String(mentorship.MenteeIndex),
+		PartitionKey:   &awsdynamodb.Attribute{Name: jsii.String("MenteeId"), Type: awsdynamodb.AttributeType_STRING},
+		SortKey:        &awsdynamodb.Attribute{Name: jsii.String("CreatedAt"), Type: awsdynamodb.AttributeType_STRING},
+		ProjectionType: awsdynamodb.ProjectionType_ALL,
+	})
+
+	return table
+}
+
+func InitializeAvailabilityTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
+	return awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+		TableName:     jsii.String(tableName),
+		PartitionKey:  &awsdynamodb.Attribute{Name: jsii.String("MentorId"), Type: awsdynamodb.AttributeType_STRING},
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		RemovalPolicy: removalPolicy,
+	})
+}
+
+// InitializeBookingsTable stores one item per participant of a session, keyed by user and UTC start time, so a
+// conditional put on the mentor's item is what makes a slot bookable only once.
+func InitializeBookingsTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
+	return awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+		TableName:     jsii.String(tableName),
+		PartitionKey:  &awsdynamodb.Attribute{Name: jsii.String("UserId"), Type: awsdynamodb.AttributeType_STRING},
+		SortKey:       &awsdynamodb.Attribute{Name: jsii.String("StartTime"), Type: awsdynamodb.AttributeType_STRING},
+		BillingMode:   awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		RemovalPolicy: removalPolicy,
+	})
+}
+
+// InitializeRevokedTokensTable holds the token denylist. Entries only matter until the tokens they cover expire,
+// so the table's time to live removes them and it never grows beyond the tokens revoked in the last day.
+func InitializeRevokedTokensTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
+	return awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+		TableName:           jsii.String(tableName),
+		PartitionKey:        &awsdynamodb.Attribute{Name: jsii.String("TokenId"), Type: awsdynamodb.AttributeType_STRING},
+		TimeToLiveAttribute: jsii.String(revocation.TTLAttribute),
+		BillingMode:         awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		RemovalPolicy:       removalPolicy,
+	})
+}
+
+// InitializeAccountJobsTable tracks account deletions and exports per user. The time to live drops a job once
+// its record is no longer needed.
+func InitializeAccountJobsTable(stack awscdk.Stack, tableName string, removalPolicy awscdk.RemovalPolicy) awsdynamodb.Table {
+	return awsdynamodb.NewTable(stack, jsii.String(tableName), &awsdynamodb.TableProps{
+		TableName:           jsii.String(tableName),
+		PartitionKey:        &awsdynamodb.Attribute{Name: jsii.String("UserId"), Type: awsdynamodb.AttributeType_STRING},
+		SortKey:             &awsdynamodb.Attribute{Name: jsii.String("JobId"), Type: awsdynamodb.AttributeType_STRING},
+		TimeToLiveAttribute: jsii.String(accountjob.TTLAttribute),
+		BillingMode:         awsdynamodb.BillingMode_PAY_PER_REQUEST,
+		RemovalPolicy:       removalPolicy,
+	})
 }
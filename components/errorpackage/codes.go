@@ -0,0 +1,112 @@
+package errorpackage
+
+import "net/http"
+
+// Code is a stable, machine-readable error identifier. Clients branch on codes, never on messages, so a code
+// must not be renamed or reused once it has shipped.
+type Code string
+
+const (
+	CodeValidationFailed      Code = "VALIDATION_FAILED"
+	CodeInvalidRequestBody    Code = "INVALID_REQUEST_BODY"
+	CodeInvalidCursor         Code = "INVALID_CURSOR"
+	CodeUnauthorized          Code = "AUTH_UNAUTHORIZED"
+	CodeInvalidToken          Code = "AUTH_INVALID_TOKEN"
+	CodeInvalidCredentials    Code = "AUTH_INVALID_CREDENTIALS"
+	CodeInvalidRefreshToken   Code = "AUTH_INVALID_REFRESH_TOKEN"
+	CodeInvalidCode           Code = "AUTH_INVALID_CODE"
+	CodeExpiredCode           Code = "AUTH_EXPIRED_CODE"
+	CodePasswordPolicy        Code = "AUTH_PASSWORD_POLICY"
+	CodeUserExists            Code = "AUTH_USER_EXISTS"
+	CodeUserNotFound          Code = "AUTH_USER_NOT_FOUND"
+	CodeUserNotConfirmed      Code = "AUTH_USER_NOT_CONFIRMED"
+	CodeInvalidSession        Code = "AUTH_INVALID_SESSION"
+	CodeMFANotEnrolled        Code = "AUTH_MFA_NOT_ENROLLED"
+	CodeForbidden             Code = "FORBIDDEN"
+	CodeNotFound              Code = "NOT_FOUND"
+	CodeConflict              Code = "CONFLICT"
+	CodeProfileNotFound       Code = "PROFILE_NOT_FOUND"
+	CodeProfileConflict       Code = "PROFILE_VERSION_CONFLICT"
+	CodeMentorNotFound        Code = "MENTOR_NOT_FOUND"
+	CodeRequestNotFound       Code = "MENTORSHIP_REQUEST_NOT_FOUND"
+	CodeRequestExists         Code = "MENTORSHIP_REQUEST_EXISTS"
+	CodeInvalidTransition     Code = "MENTORSHIP_INVALID_TRANSITION"
+	CodeAvailabilityNotFound  Code = "AVAILABILITY_NOT_FOUND"
+	CodeSlotUnavailable       Code = "BOOKING_SLOT_UNAVAILABLE"
+	CodeSlotTaken             Code = "BOOKING_SLOT_TAKEN"
+	CodeBookingNotFound       Code = "BOOKING_NOT_FOUND"
+	CodeFileNotFound          Code = "FILE_NOT_FOUND"
+	CodeFileForbidden         Code = "FILE_FORBIDDEN"
+	CodeRateLimited           Code = "RATE_LIMITED"
+	CodeTimeout               Code = "TIMEOUT"
+	CodeInternal              Code = "INTERNAL_ERROR"
+	CodeDependencyUnavailable Code = "DEPENDENCY_UNAVAILABLE"
+)
+
+var codeStatus = map[Code]int{
+	CodeValidationFailed:      http.StatusBadRequest,
+	CodeInvalidRequestBody:    http.StatusBadRequest,
+	CodeInvalidCursor:         http.StatusBadRequest,
+	CodeUnauthorized:          http.StatusUnauthorized,
+	CodeInvalidToken:          http.StatusUnauthorized,
+	CodeInvalidCredentials:    http.StatusUnauthorized,
+	CodeInvalidRefreshToken:   http.StatusUnauthorized,
+	CodeInvalidCode:           http.StatusBadRequest,
+	CodeExpiredCode:           http.StatusBadRequest,
+	CodePasswordPolicy:        http.StatusBadRequest,
+	CodeUserExists:            http.StatusConflict,
+	CodeUserNotFound:          http.StatusNotFound,
+	CodeUserNotConfirmed:      http.StatusForbidden,
+	CodeInvalidSession:        http.StatusUnauthorized,
+	CodeMFANotEnrolled:        http.StatusConflict,
+	CodeForbidden:             http.StatusForbidden,
+	CodeNotFound:              http.StatusNotFound,
+	CodeConflict:              http.StatusConflict,
+	CodeProfileNotFound:       http.StatusNotFound,
+	CodeProfileConflict:       http.StatusConflict,
+	CodeMentorNotFound:        http.StatusNotFound,
+	CodeRequestNotFound:       http.StatusNotFound,
+	CodeRequestExists:         http.StatusConflict,
+	CodeInvalidTransition:     http.StatusConflict,
+	CodeAvailabilityNotFound:  http.StatusNotFound,
+	CodeSlotUnavailable:       http.StatusBadRequest,
+	CodeSlotTaken:             http.StatusConflict,
+	CodeBookingNotFound:       http.StatusNotFound,
+	CodeFileNotFound:          http.StatusNotFound,
+	CodeFileForbidden:         http.StatusForbidden,
+	CodeRateLimited:           http.StatusTooManyRequests,
+	CodeTimeout:               http.StatusGatewayTimeout,
+	CodeInternal:              http.StatusInternalServerError,
+	CodeDependencyUnavailable: http.StatusServiceUnavailable,
+}
+
+// genericCodes is used by ClientError, whose callers only know the status.
+var genericCodes = map[int]Code{
+	http.StatusBadRequest:          CodeValidationFailed,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusTooManyRequests:     CodeRateLimited,
+	http.StatusGatewayTimeout:      CodeTimeout,
+	http.StatusServiceUnavailable:  CodeDependencyUnavailable,
+	http.StatusInternalServerError: CodeInternal,
+}
+
+// Status is the HTTP status a code is always returned with.
+func (c Code) Status() int {
+	if status, ok := codeStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func codeForStatus(status int) Code {
+	if code, ok := genericCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeValidationFailed
+}
@@ -1,87 +1,153 @@
 package errorpackage
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/aws/aws-lambda-go/events"
-	"log"
-	"net/http"
+	"net"
 	"strings"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 var (
-	ErrNoSuchKey            = errors.New("NoSuchKey")
-	ErrUserAlreadyExists    = errors.New("user already exists")
-	ErrInvalidCredentials   = errors.New("invalid credentials")
-	ErrMissingAuthorization = errors.New("authorization header is missing")
-	ErrMissingToken         = errors.New("ID token is missing")
-	ErrInvalidTokenFormat   = errors.New("invalid ID token format")
-	ErrEmailNotFound        = errors.New("email not found in ID token")
+	ErrNoSuchKey               = errors.New("NoSuchKey")
+	ErrUserAlreadyExists       = errors.New("user already exists")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrMissingAuthorization    = errors.New("authorization header is missing")
+	ErrMissingToken            = errors.New("ID token is missing")
+	ErrInvalidTokenFormat      = errors.New("invalid ID token format")
+	ErrEmailNotFound           = errors.New("email not found in ID token")
+	ErrInvalidTokenSignature   = errors.New("invalid ID token signature")
+	ErrInvalidTokenClaims      = errors.New("invalid ID token claims")
+	ErrTokenExpired            = errors.New("ID token has expired")
+	ErrTokenRevoked            = errors.New("ID token has been revoked")
+	ErrUnknownSigningKey       = errors.New("ID token signed with an unknown key")
+	ErrMissingSubject          = errors.New("subject not found in ID token")
+	ErrMissingRole             = errors.New("custom:role attribute is missing in the token")
+	ErrForbiddenKey            = errors.New("file does not belong to the caller")
+	ErrVersionConflict         = errors.New("item was modified concurrently")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
+	ErrInvalidTransition       = errors.New("mentorship request is no longer in a state that allows this action")
+	ErrRequestExists           = errors.New("a mentorship request to this mentor is already pending or accepted")
+	ErrSlotOutsideAvailability = errors.New("requested session is outside the mentor's availability")
+	ErrSlotAlreadyBooked       = errors.New("requested session slot is already booked")
+	ErrInvalidRefreshToken     = errors.New("refresh token is invalid, expired or revoked")
+	ErrCodeMismatch            = errors.New("verification code does not match")
+	ErrExpiredCode             = errors.New("verification code has expired")
+	ErrPasswordPolicy          = errors.New("password does not satisfy the password policy")
+	ErrInvalidSession          = errors.New("sign-in session is invalid or has expired, please sign in again")
+	ErrMFANotEnrolled          = errors.New("an authenticator app has not been verified for this account")
+	ErrUserNotResolved         = errors.New("no user in the user pool has this subject")
 )
 
-func HandleS3Error(err error) (events.APIGatewayProxyResponse, error) {
-	switch {
-	case errors.Is(err, ErrNoSuchKey):
-		return ClientError(http.StatusNotFound, "File not found")
-	default:
-		log.Printf("S3 error: %v", err)
-		return ServerError("Internal server error")
-	}
-}
-
-func ServerError(message string) (events.APIGatewayProxyResponse, error) {
-	err := fmt.Errorf("server error: %s", message)
-	log.Println(err)
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusInternalServerError,
-		Body:       fmt.Sprintf(`{"error": "%s"}`, message),
-		Headers: map[string]string{
-			"Content-Type":                 "application/json",
-			"Access-Control-Allow-Origin":  "*",
-			"Access-Control-Allow-Methods": "GET, OPTIONS",
-			"Access-Control-Allow-Headers": "Content-Type",
-		},
-	}, err
-}
-
-func ClientError(status int, message string) (events.APIGatewayProxyResponse, error) {
-	err := fmt.Errorf("client error: %s", message)
-	log.Println(err)
-	return events.APIGatewayProxyResponse{
-		StatusCode: status,
-		Body:       fmt.Sprintf(`{"error": "%s"}`, message),
-		Headers: map[string]string{
-			"Content-Type":                 "application/json",
-			"Access-Control-Allow-Origin":  "*",
-			"Access-Control-Allow-Methods": "GET, OPTIONS",
-			"Access-Control-Allow-Headers": "Content-Type",
-		},
-	}, err
-}
-
 func IsInvalidConfirmationCodeError(err error) bool {
-	if err == nil {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
 		return false
 	}
-	return strings.Contains(err.Error(), "InvalidParameterException") &&
-		strings.Contains(err.Error(), "Invalid code")
+	return apiErr.ErrorCode() == "CodeMismatchException" ||
+		(apiErr.ErrorCode() == "InvalidParameterException" && strings.Contains(apiErr.ErrorMessage(), "Invalid code"))
 }
 
 func IsExpiredConfirmationCodeError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "ExpiredCodeException")
+	return errors.Is(err, ErrExpiredCode) || hasAWSErrorCode(err, "ExpiredCodeException")
 }
 
 func IsUserAlreadyExistsError(err error) bool {
-	return errors.Is(err, ErrUserAlreadyExists)
+	return errors.Is(err, ErrUserAlreadyExists) || hasAWSErrorCode(err, "UsernameExistsException", "AliasExistsException")
 }
 
+// IsInvalidCredentialsError deliberately treats an unknown user like a wrong password, so login responses do
+// not reveal which emails are registered.
 func IsInvalidCredentialsError(err error) bool {
-	return errors.Is(err, ErrInvalidCredentials)
+	return errors.Is(err, ErrInvalidCredentials) || hasAWSErrorCode(err, "NotAuthorizedException", "UserNotFoundException")
 }
 
 func IsDynamoDBNotFoundError(err error) bool {
 	return errors.Is(err, ErrNoSuchKey)
 }
+
+// IsInvalidRefreshTokenError also covers the exceptions RevokeToken raises for a token that is malformed or was
+// issued to another app client.
+func IsInvalidRefreshTokenError(err error) bool {
+	return hasAWSErrorCode(err, "NotAuthorizedException", "UnauthorizedException", "UnsupportedTokenTypeException")
+}
+
+// IsCodeMismatchError also covers EnableSoftwareTokenMFAException, which VerifySoftwareToken raises for a wrong
+// authenticator code.
+func IsCodeMismatchError(err error) bool {
+	return errors.Is(err, ErrCodeMismatch) || hasAWSErrorCode(err, "CodeMismatchException", "EnableSoftwareTokenMFAException")
+}
+
+// IsInvalidSessionError reports a challenge session that Cognito no longer accepts, typically because its
+// three-minute lifetime has passed.
+func IsInvalidSessionError(err error) bool {
+	if errors.Is(err, ErrInvalidSession) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotAuthorizedException" &&
+		strings.Contains(strings.ToLower(apiErr.ErrorMessage()), "session")
+}
+
+// IsMFANotEnrolledError reports an attempt to enable TOTP before an authenticator has been verified.
+func IsMFANotEnrolledError(err error) bool {
+	if errors.Is(err, ErrMFANotEnrolled) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidParameterException" &&
+		strings.Contains(strings.ToLower(apiErr.ErrorMessage()), "software token")
+}
+
+func IsInvalidPasswordError(err error) bool {
+	return errors.Is(err, ErrPasswordPolicy) || hasAWSErrorCode(err, "InvalidPasswordException")
+}
+
+func IsUserNotFoundError(err error) bool {
+	return hasAWSErrorCode(err, "UserNotFoundException")
+}
+
+func IsLimitExceededError(err error) bool {
+	return hasAWSErrorCode(err, "LimitExceededException", "TooManyRequestsException", "TooManyFailedAttemptsException")
+}
+
+// IsS3NotFoundError covers both shapes S3 uses for a missing object: NoSuchKey from GetObject and a bare
+// NotFound from HeadObject, which has no response body to carry an error code.
+func IsS3NotFoundError(err error) bool {
+	var noSuchKey *s3types.NoSuchKey
+	var notFound *s3types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
+
+// IsTimeoutError reports whether err comes from an expired context or a timed-out network call.
+func IsTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// AWSErrorCode returns the service error code, such as UsernameExistsException, carried by an AWS SDK error.
+func AWSErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+func hasAWSErrorCode(err error, codes ...string) bool {
+	code := AWSErrorCode(err)
+	if code == "" {
+		return false
+	}
+	for _, candidate := range codes {
+		if code == candidate {
+			return true
+		}
+	}
+	return false
+}
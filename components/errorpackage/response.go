@@ -0,0 +1,247 @@
+package errorpackage
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	internalErrorMessage    = "Internal server error"
+	timeoutMessage          = "The request timed out, please try again"
+	verificationUnavailable = "The token could not be verified right now, please try again"
+)
+
+// fallbackBody is sent if an envelope cannot be marshalled, which only happens for unsupported Details values.
+const fallbackBody = `{"code":"INTERNAL_ERROR","message":"Internal server error"}`
+
+// Envelope is the body of every error response.
+type Envelope struct {
+	Code      Code        `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// Error responses report a nil Go error: a Lambda proxy integration that returns an error makes API Gateway answer
+// 502 and drop the body, so the envelope would never reach the client.
+
+// CodedError responds with the status registered for code and a message that is safe to show to the client.
+func CodedError(code Code, message string) (events.APIGatewayProxyResponse, error) {
+	return CodedErrorWithDetails(code, message, nil)
+}
+
+// CodedErrorWithDetails is CodedError with structured details, such as the fields that failed validation.
+func CodedErrorWithDetails(code Code, message string, details interface{}) (events.APIGatewayProxyResponse, error) {
+	if code.Status() >= http.StatusInternalServerError {
+		log.Printf("server error: %s: %s", code, message)
+	} else {
+		log.Printf("client error: %s: %s", code, message)
+	}
+	return respond(code.Status(), Envelope{Code: code, Message: message, Details: details}), nil
+}
+
+// ClientError is kept for call sites that only know the status; the code is the generic one for that status.
+func ClientError(status int, message string) (events.APIGatewayProxyResponse, error) {
+	code := codeForStatus(status)
+	log.Printf("client error: %s: %s", code, message)
+	return respond(status, Envelope{Code: code, Message: message}), nil
+}
+
+// ServerError logs message and answers with a generic body, so internal details never reach the client.
+func ServerError(message string) (events.APIGatewayProxyResponse, error) {
+	log.Printf("server error: %s", message)
+	return respond(http.StatusInternalServerError, Envelope{Code: CodeInternal, Message: internalErrorMessage}), nil
+}
+
+// GatewayTimeout is returned when a handler runs out of time.
+func GatewayTimeout() (events.APIGatewayProxyResponse, error) {
+	log.Println("gateway timeout: handler deadline exceeded")
+	return respond(http.StatusGatewayTimeout, Envelope{Code: CodeTimeout, Message: timeoutMessage}), nil
+}
+
+// FromError maps an error returned by a repository or an AWS SDK call to its catalogue code. Errors without a
+// mapping are logged and reported as INTERNAL_ERROR, so raw SDK messages never reach the client.
+func FromError(err error) (events.APIGatewayProxyResponse, error) {
+	code, message := Classify(err)
+	if code == CodeInternal {
+		return ServerError(errorText(err))
+	}
+	log.Printf("client error: %s: %v", code, err)
+	return respond(code.Status(), Envelope{Code: code, Message: message}), nil
+}
+
+// Classify returns the catalogue code and client-facing message for err. Handlers that know more about the
+// context, such as login treating an unknown user as bad credentials, check for that before falling back here.
+func Classify(err error) (Code, string) {
+	switch {
+	case err == nil:
+		return CodeInternal, internalErrorMessage
+	case IsTimeoutError(err):
+		return CodeTimeout, timeoutMessage
+	case errors.Is(err, ErrForbiddenKey):
+		return CodeFileForbidden, "You do not have access to this file"
+	case errors.Is(err, ErrMissingAuthorization), errors.Is(err, ErrMissingToken),
+		errors.Is(err, ErrInvalidTokenFormat), errors.Is(err, ErrEmailNotFound),
+		errors.Is(err, ErrInvalidTokenSignature), errors.Is(err, ErrInvalidTokenClaims),
+		errors.Is(err, ErrTokenExpired), errors.Is(err, ErrTokenRevoked), errors.Is(err, ErrUnknownSigningKey),
+		errors.Is(err, ErrMissingSubject), errors.Is(err, ErrMissingRole):
+		return CodeInvalidToken, err.Error()
+	case errors.Is(err, ErrInvalidCredentials):
+		return CodeInvalidCredentials, "Incorrect email or password"
+	case errors.Is(err, ErrInvalidRefreshToken):
+		return CodeInvalidRefreshToken, ErrInvalidRefreshToken.Error()
+	case errors.Is(err, ErrUserAlreadyExists):
+		return CodeUserExists, "An account with this email already exists"
+	case errors.Is(err, ErrVersionConflict):
+		return CodeProfileConflict, "The profile was modified by another request, reload it and try again"
+	case errors.Is(err, ErrInvalidCursor):
+		return CodeInvalidCursor, ErrInvalidCursor.Error()
+	case errors.Is(err, ErrInvalidTransition):
+		return CodeInvalidTransition, ErrInvalidTransition.Error()
+	case errors.Is(err, ErrRequestExists):
+		return CodeRequestExists, ErrRequestExists.Error()
+	case errors.Is(err, ErrSlotOutsideAvailability):
+		return CodeSlotUnavailable, ErrSlotOutsideAvailability.Error()
+	case errors.Is(err, ErrSlotAlreadyBooked):
+		return CodeSlotTaken, ErrSlotAlreadyBooked.Error()
+	case errors.Is(err, ErrCodeMismatch):
+		return CodeInvalidCode, ErrCodeMismatch.Error()
+	case errors.Is(err, ErrExpiredCode):
+		return CodeExpiredCode, ErrExpiredCode.Error()
+	case errors.Is(err, ErrPasswordPolicy):
+		return CodePasswordPolicy, ErrPasswordPolicy.Error()
+	case IsInvalidSessionError(err):
+		return CodeInvalidSession, ErrInvalidSession.Error()
+	case IsMFANotEnrolledError(err):
+		return CodeMFANotEnrolled, ErrMFANotEnrolled.Error()
+	case errors.Is(err, ErrNoSuchKey):
+		return CodeNotFound, "Resource not found"
+	case IsS3NotFoundError(err):
+		return CodeFileNotFound, "File not found"
+	}
+
+	switch AWSErrorCode(err) {
+	case "NotAuthorizedException":
+		return CodeUnauthorized, "The request is not authorized"
+	case "UsernameExistsException", "AliasExistsException":
+		return CodeUserExists, "An account with this email already exists"
+	case "UserNotFoundException":
+		return CodeUserNotFound, "User not found"
+	case "UserNotConfirmedException":
+		return CodeUserNotConfirmed, "The account has not been confirmed yet"
+	case "CodeMismatchException", "EnableSoftwareTokenMFAException":
+		return CodeInvalidCode, ErrCodeMismatch.Error()
+	case "ExpiredCodeException":
+		return CodeExpiredCode, ErrExpiredCode.Error()
+	case "InvalidPasswordException":
+		return CodePasswordPolicy, ErrPasswordPolicy.Error()
+	case "LimitExceededException", "TooManyRequestsException", "TooManyFailedAttemptsException",
+		"ThrottlingException", "ProvisionedThroughputExceededException", "RequestLimitExceeded", "SlowDown":
+		return CodeRateLimited, "Too many requests, please try again later"
+	case "InvalidParameterException":
+		if IsInvalidConfirmationCodeError(err) {
+			return CodeInvalidCode, ErrCodeMismatch.Error()
+		}
+		return CodeValidationFailed, "The request contains invalid parameters"
+	case "ConditionalCheckFailedException", "TransactionCanceledException":
+		return CodeConflict, "The resource was modified by another request"
+	case "NoSuchKey", "NotFound":
+		return CodeFileNotFound, "File not found"
+	}
+	return CodeInternal, internalErrorMessage
+}
+
+// WithRequestID stamps the API Gateway request ID into an error envelope so clients can quote it when reporting
+// a problem. Successful responses and bodies that are not envelopes are returned unchanged.
+func WithRequestID(response events.APIGatewayProxyResponse, requestID string) events.APIGatewayProxyResponse {
+	if requestID == "" || response.StatusCode < http.StatusBadRequest || response.Body == "" {
+		return response
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(response.Body), &envelope); err != nil || envelope.Code == "" {
+		return response
+	}
+	envelope.RequestID = requestID
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return response
+	}
+	response.Body = string(body)
+	return response
+}
+
+// HandleS3Error maps an S3 failure to a response.
+func HandleS3Error(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, ErrNoSuchKey), IsS3NotFoundError(err):
+		return CodedError(CodeFileNotFound, "File not found")
+	default:
+		return FromError(err)
+	}
+}
+
+// HandleKeyError maps a rejected object key to a response: someone else's key is forbidden, a token without a
+// subject is unauthorized, and anything else is a malformed key.
+func HandleKeyError(err error) (events.APIGatewayProxyResponse, error) {
+	switch {
+	case errors.Is(err, ErrForbiddenKey):
+		return CodedError(CodeFileForbidden, "You do not have access to this file")
+	case errors.Is(err, ErrMissingSubject):
+		return CodedError(CodeInvalidToken, err.Error())
+	default:
+		return CodedError(CodeValidationFailed, err.Error())
+	}
+}
+
+// tokenErrors are the reasons a verifier rejects a token. Each is answered 401 with the sentinel's own text, so
+// wrapped detail such as which claim failed stays in the logs.
+var tokenErrors = []error{
+	ErrMissingAuthorization, ErrMissingToken, ErrInvalidTokenFormat, ErrInvalidTokenSignature, ErrInvalidTokenClaims,
+	ErrUnknownSigningKey, ErrTokenExpired, ErrTokenRevoked, ErrEmailNotFound, ErrMissingSubject, ErrMissingRole,
+}
+
+// HandleTokenError maps a failed token verification to a response. Any other error means the check itself could
+// not run, for instance because the JWKS endpoint or the revocation table was unreachable: the cause is logged
+// and the client gets a 503 it may retry, never a 401 that would sign it out.
+func HandleTokenError(err error) (events.APIGatewayProxyResponse, error) {
+	for _, tokenErr := range tokenErrors {
+		if errors.Is(err, tokenErr) {
+			log.Printf("client error: %s: %v", CodeInvalidToken, err)
+			return respond(CodeInvalidToken.Status(), Envelope{Code: CodeInvalidToken, Message: tokenErr.Error()}), nil
+		}
+	}
+	if IsTimeoutError(err) {
+		log.Printf("token verification timed out: %v", err)
+		return GatewayTimeout()
+	}
+
+	log.Printf("server error: token verification failed: %s", errorText(err))
+	return respond(CodeDependencyUnavailable.Status(), Envelope{Code: CodeDependencyUnavailable, Message: verificationUnavailable}), nil
+}
+
+func respond(status int, envelope Envelope) events.APIGatewayProxyResponse {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("failed to marshal error envelope: %v", err)
+		body = []byte(fallbackBody)
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Body:       string(body),
+		// The CORS middleware adds the route's CORS headers, which an error does not know.
+		Headers: map[string]string{"Content-Type": "application/json"},
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return "unknown error"
+	}
+	return err.Error()
+}
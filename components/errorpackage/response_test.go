@@ -0,0 +1,97 @@
+package errorpackage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func decode(t *testing.T, body string) Envelope {
+	t.Helper()
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		t.Fatalf("body is not valid JSON: %v\n%s", err, body)
+	}
+	return envelope
+}
+
+func TestClientErrorEscapesMessage(t *testing.T) {
+	response, err := ClientError(http.StatusBadRequest, `field "name" is required`)
+	if err != nil {
+		t.Fatalf("expected a nil error, got %v", err)
+	}
+
+	envelope := decode(t, response.Body)
+	if envelope.Code != CodeValidationFailed || envelope.Message != `field "name" is required` {
+		t.Fatalf("unexpected envelope %+v", envelope)
+	}
+}
+
+func TestServerErrorHidesDetail(t *testing.T) {
+	response, _ := ServerError("dynamodb: table mentorship-prod not found")
+
+	envelope := decode(t, response.Body)
+	if response.StatusCode != http.StatusInternalServerError || envelope.Code != CodeInternal || envelope.Message != internalErrorMessage {
+		t.Fatalf("unexpected response %d %+v", response.StatusCode, envelope)
+	}
+}
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   Code
+		status int
+	}{
+		{"code mismatch", &smithy.GenericAPIError{Code: "CodeMismatchException", Message: "Invalid verification code provided"}, CodeInvalidCode, http.StatusBadRequest},
+		{"invalid code parameter", &smithy.GenericAPIError{Code: "InvalidParameterException", Message: "Invalid code provided"}, CodeInvalidCode, http.StatusBadRequest},
+		{"wrong authenticator code", &smithy.GenericAPIError{Code: "EnableSoftwareTokenMFAException", Message: "Code mismatch"}, CodeInvalidCode, http.StatusBadRequest},
+		{"expired session", &smithy.GenericAPIError{Code: "NotAuthorizedException", Message: "Invalid session for the user, session is expired."}, CodeInvalidSession, http.StatusUnauthorized},
+		{"other not authorized", &smithy.GenericAPIError{Code: "NotAuthorizedException", Message: "Access Token has been revoked"}, CodeUnauthorized, http.StatusUnauthorized},
+		{"mfa not enrolled", &smithy.GenericAPIError{Code: "InvalidParameterException", Message: "User has not verified software token mfa"}, CodeMFANotEnrolled, http.StatusConflict},
+		{"username exists", fmt.Errorf("sign up: %w", &smithy.GenericAPIError{Code: "UsernameExistsException"}), CodeUserExists, http.StatusConflict},
+		{"throttled", &smithy.GenericAPIError{Code: "TooManyRequestsException"}, CodeRateLimited, http.StatusTooManyRequests},
+		{"wrapped sentinel", fmt.Errorf("update: %w", ErrVersionConflict), CodeProfileConflict, http.StatusConflict},
+		{"deadline", fmt.Errorf("get item: %w", context.DeadlineExceeded), CodeTimeout, http.StatusGatewayTimeout},
+		{"unknown service error", &smithy.GenericAPIError{Code: "InternalErrorException", Message: "secret detail"}, CodeInternal, http.StatusInternalServerError},
+		{"code text in a plain error", fmt.Errorf("CodeMismatchException"), CodeInternal, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := FromError(tt.err)
+			envelope := decode(t, response.Body)
+			if response.StatusCode != tt.status || envelope.Code != tt.code {
+				t.Fatalf("expected %d %s, got %d %+v", tt.status, tt.code, response.StatusCode, envelope)
+			}
+			if tt.code == CodeInternal && envelope.Message != internalErrorMessage {
+				t.Fatalf("internal error leaked its message: %q", envelope.Message)
+			}
+		})
+	}
+}
+
+func TestInvalidCredentialsCoversUnknownUser(t *testing.T) {
+	for _, code := range []string{"NotAuthorizedException", "UserNotFoundException"} {
+		if !IsInvalidCredentialsError(&smithy.GenericAPIError{Code: code}) {
+			t.Fatalf("%s should count as invalid credentials", code)
+		}
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	response, _ := CodedErrorWithDetails(CodeValidationFailed, "Invalid request", map[string]string{"name": "required"})
+	envelope := decode(t, WithRequestID(response, "req-1").Body)
+	if envelope.RequestID != "req-1" || envelope.Details == nil {
+		t.Fatalf("unexpected envelope %+v", envelope)
+	}
+
+	plain := WithRequestID(response, "")
+	if plain.Body != response.Body {
+		t.Fatalf("body changed without a request ID: %s", plain.Body)
+	}
+}
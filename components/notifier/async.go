@@ -0,0 +1,126 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultQueueSize bounds the notifications waiting to be sent by one Lambda container.
+	DefaultQueueSize = 64
+	// defaultSendTimeout applies when the request context carries no deadline, e.g. outside the Lambda runtime.
+	defaultSendTimeout = 5 * time.Second
+)
+
+var ErrQueueFull = errors.New("notification queue is full")
+
+type job struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	notification Notification
+}
+
+// Async sends notifications from a background goroutine so the handler's response is not held up by the
+// backend. Each send keeps the request's deadline but not its cancellation. The Lambda runtime freezes the
+// container once the handler has returned, so callers Flush before returning; a notification whose deadline
+// has passed by the time it is picked up is dropped.
+//
+// One Async serves concurrent requests on the local server, and a Flush that timed out leaves jobs behind for
+// the next invocation, so notifications are enqueued while others are being flushed. Pending jobs are therefore
+// counted under mu rather than with a sync.WaitGroup, which must not be added to while a Wait is in progress.
+type Async struct {
+	next  Notifier
+	queue chan job
+
+	mu      sync.Mutex
+	pending int
+	// idle is closed whenever pending drops to zero and replaced when the next job is enqueued.
+	idle chan struct{}
+}
+
+func NewAsync(next Notifier, queueSize int) *Async {
+	a := &Async{next: next, queue: make(chan job, queueSize)}
+	go a.run()
+	return a
+}
+
+// Notify enqueues notification without blocking. It only fails when the queue is full.
+func (a *Async) Notify(ctx context.Context, notification Notification) error {
+	base := context.WithoutCancel(ctx)
+	var sendCtx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); ok {
+		sendCtx, cancel = context.WithDeadline(base, deadline)
+	} else {
+		sendCtx, cancel = context.WithTimeout(base, defaultSendTimeout)
+	}
+
+	a.started()
+	select {
+	case a.queue <- job{ctx: sendCtx, cancel: cancel, notification: notification}:
+		return nil
+	default:
+		a.finished()
+		cancel()
+		return ErrQueueFull
+	}
+}
+
+// Flush waits until every queued notification has been handled or ctx is done. Jobs enqueued while it waits are
+// waited for too.
+func (a *Async) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	if a.pending == 0 {
+		a.mu.Unlock()
+		return nil
+	}
+	idle := a.idle
+	a.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Async) started() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.pending++
+}
+
+func (a *Async) finished() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending--
+	if a.pending == 0 {
+		close(a.idle)
+	}
+}
+
+func (a *Async) run() {
+	for j := range a.queue {
+		a.send(j)
+	}
+}
+
+func (a *Async) send(j job) {
+	defer a.finished()
+	defer j.cancel()
+
+	if err := j.ctx.Err(); err != nil {
+		log.Printf("Dropping %s notification %q: %v", j.notification.Level, j.notification.Message, err)
+		return
+	}
+	if err := a.next.Notify(j.ctx, j.notification); err != nil {
+		log.Printf("Failed to send notification %q: %v", j.notification.Message, err)
+	}
+}
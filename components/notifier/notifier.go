@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"mentorship-app-backend/components/secrets"
+	"mentorship-app-backend/config"
+)
+
+type Level string
+
+const (
+	LevelInfo    Level = "info"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// Backends selectable through config.Config.NotifierBackend.
+const (
+	BackendSlack   = "slack"
+	BackendWebhook = "webhook"
+	BackendSNS     = "sns"
+	BackendLog     = "log"
+	BackendNone    = "none"
+)
+
+type Field struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// Notification is one handler outcome. Channel is a routing hint that only the Slack backend interprets.
+type Notification struct {
+	Channel string  `json:"channel"`
+	Message string  `json:"message"`
+	Level   Level   `json:"level"`
+	Fields  []Field `json:"fields,omitempty"`
+}
+
+// Notifier delivers notifications to an operator-facing destination. Callers treat errors as informational:
+// a failed notification is logged and never changes the response a user receives.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Flusher is a Notifier that may still be delivering notifications after Notify returned.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Flush waits until n has delivered what it was given or ctx is done. Notifiers that deliver within Notify have
+// nothing to wait for.
+func Flush(ctx context.Context, n Notifier) error {
+	if flusher, ok := n.(Flusher); ok {
+		return flusher.Flush(ctx)
+	}
+	return nil
+}
+
+// New builds the backend selected in cfg. An empty backend keeps the historical Slack behaviour. The publisher
+// is only used by the SNS backend.
+func New(cfg config.Config, secretCache *secrets.Cache, publisher SNSPublisher) (Notifier, error) {
+	switch cfg.NotifierBackend {
+	case "", BackendSlack:
+		if cfg.SlackWebhookSecretARN == "" {
+			return nil, fmt.Errorf("slack notifier requires slack_webhook_secret_arn")
+		}
+		return NewSlackNotifier(func(ctx context.Context) (string, error) {
+			var secret secrets.SlackSecret
+			if err := secretCache.Decode(ctx, cfg.SlackWebhookSecretARN, &secret); err != nil {
+				return "", err
+			}
+			return secret.Token, nil
+		}), nil
+	case BackendWebhook:
+		if cfg.NotificationWebhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier requires notification_webhook_url")
+		}
+		return NewWebhookNotifier(cfg.NotificationWebhookURL, nil), nil
+	case BackendSNS:
+		if cfg.NotificationTopicARN == "" {
+			return nil, fmt.Errorf("sns notifier requires notification_topic_arn")
+		}
+		return NewSNSNotifier(publisher, cfg.NotificationTopicARN), nil
+	case BackendLog:
+		return LogNotifier{}, nil
+	case BackendNone:
+		return NopNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier backend %q", cfg.NotifierBackend)
+	}
+}
+
+// LogNotifier writes notifications to the Lambda log, which is useful locally and as a fallback.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(_ context.Context, notification Notification) error {
+	log.Printf("[%s] %s: %s %v", notification.Level, notification.Channel, notification.Message, notification.Fields)
+	return nil
+}
+
+// NopNotifier discards every notification.
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(context.Context, Notification) error {
+	return nil
+}
@@ -0,0 +1,199 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/secrets"
+	"mentorship-app-backend/config"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+type fakePublisher struct {
+	input *sns.PublishInput
+}
+
+func (f *fakePublisher) Publish(_ context.Context, input *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	f.input = input
+	return &sns.PublishOutput{}, nil
+}
+
+var sample = Notification{
+	Channel: "auth-alerts",
+	Message: "Login execution failed",
+	Level:   LevelError,
+	Fields:  []Field{{Title: "Status", Value: "Failure", Short: true}},
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("webhook body is not JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, server.Client()).Notify(context.Background(), sample); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Message != sample.Message || received.Level != LevelError || len(received.Fields) != 1 {
+		t.Fatalf("unexpected payload %+v", received)
+	}
+}
+
+func TestWebhookNotifierReportsFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, server.Client()).Notify(context.Background(), sample); err == nil {
+		t.Fatal("expected an error for a 502 answer")
+	}
+}
+
+func TestSNSNotifier(t *testing.T) {
+	publisher := &fakePublisher{}
+	if err := NewSNSNotifier(publisher, "arn:aws:sns:us-east-1:123456789012:alerts").Notify(context.Background(), sample); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *publisher.input.TopicArn != "arn:aws:sns:us-east-1:123456789012:alerts" || *publisher.input.Subject != sample.Message {
+		t.Fatalf("unexpected publish input %+v", publisher.input)
+	}
+	if *publisher.input.MessageAttributes["level"].StringValue != "error" {
+		t.Fatalf("missing level attribute: %v", publisher.input.MessageAttributes)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		cfg     config.Config
+		want    Notifier
+		wantErr bool
+	}{
+		{cfg: config.Config{NotifierBackend: BackendLog}, want: LogNotifier{}},
+		{cfg: config.Config{NotifierBackend: BackendNone}, want: NopNotifier{}},
+		{cfg: config.Config{NotifierBackend: BackendWebhook}, wantErr: true},
+		{cfg: config.Config{NotifierBackend: "pager"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := New(tt.cfg, nil, nil)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%q: unexpected error %v", tt.cfg.NotifierBackend, err)
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Fatalf("%q: got %T", tt.cfg.NotifierBackend, got)
+		}
+	}
+
+	store := secrets.NewMemoryStore(map[string]string{"arn": `{"slack_token":"xoxb-test"}`})
+	got, err := New(config.Config{SlackWebhookSecretARN: "arn"}, secrets.NewCache(store, 0, 0), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slackNotifier, ok := got.(*SlackNotifier)
+	if !ok {
+		t.Fatalf("expected Slack by default, got %T", got)
+	}
+	if token, err := slackNotifier.token(context.Background()); err != nil || token != "xoxb-test" {
+		t.Fatalf("unexpected token %q %v", token, err)
+	}
+}
+
+type blockingNotifier struct {
+	release chan struct{}
+	Recorder
+}
+
+func (b *blockingNotifier) Notify(ctx context.Context, notification Notification) error {
+	<-b.release
+	return b.Recorder.Notify(ctx, notification)
+}
+
+func TestAsyncDoesNotBlockTheCaller(t *testing.T) {
+	backend := &blockingNotifier{release: make(chan struct{})}
+	async := NewAsync(backend, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := async.Notify(ctx, sample); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The request context is cancelled when the handler returns; the queued send must survive that.
+	cancel()
+	close(backend.release)
+
+	flushCtx, stop := context.WithTimeout(context.Background(), time.Second)
+	defer stop()
+	if err := async.Flush(flushCtx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(backend.Sent()) != 1 {
+		t.Fatalf("expected the notification to be delivered, got %+v", backend.Sent())
+	}
+}
+
+func TestAsyncDropsExpiredNotifications(t *testing.T) {
+	recorder := &Recorder{}
+	async := NewAsync(recorder, 1)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_ = async.Notify(ctx, sample)
+
+	if err := async.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(recorder.Sent()) != 0 {
+		t.Fatalf("expected the expired notification to be dropped, got %+v", recorder.Sent())
+	}
+}
+
+// TestAsyncNotifyWhileFlushing enqueues from many goroutines while others flush with budgets too short to wait the
+// backend out, as concurrent requests on the local server and timed-out flushes in Lambda do.
+func TestAsyncNotifyWhileFlushing(t *testing.T) {
+	backend := &blockingNotifier{release: make(chan struct{})}
+	async := NewAsync(backend, DefaultQueueSize)
+
+	var requests sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		requests.Add(1)
+		go func() {
+			defer requests.Done()
+			if err := async.Notify(context.Background(), sample); err != nil {
+				t.Errorf("notify: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+			if err := async.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected the flush to time out behind the blocked backend, got %v", err)
+			}
+		}()
+	}
+	requests.Wait()
+	close(backend.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := async.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(backend.Sent()) != 32 {
+		t.Fatalf("expected every notification to be delivered, got %d", len(backend.Sent()))
+	}
+	if err := async.Flush(ctx); err != nil {
+		t.Fatalf("flush with nothing pending: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"mentorship-app-backend/config"
+)
+
+var levelRank = map[Level]int{
+	LevelInfo:    0,
+	LevelWarning: 1,
+	LevelError:   2,
+}
+
+func ParseLevel(value string) (Level, error) {
+	if value == "" {
+		return LevelInfo, nil
+	}
+	level := Level(value)
+	if _, ok := levelRank[level]; !ok {
+		return "", fmt.Errorf("unknown notification level %q", value)
+	}
+	return level, nil
+}
+
+// Policy decides which notifications a handler sends. Notifications below MinLevel are dropped, and
+// successful calls (LevelInfo) are additionally sampled at SuccessSampleRate. Warnings and errors are never
+// sampled.
+type Policy struct {
+	MinLevel          Level
+	SuccessSampleRate float64
+}
+
+// PolicyFromConfig resolves a configured policy, defaulting to sending everything.
+func PolicyFromConfig(policy config.NotificationPolicy) (Policy, error) {
+	level, err := ParseLevel(policy.MinLevel)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	rate := 1.0
+	if policy.SuccessSampleRate != nil {
+		rate = *policy.SuccessSampleRate
+	}
+	if rate < 0 || rate > 1 {
+		return Policy{}, fmt.Errorf("success_sample_rate must be between 0 and 1, got %v", rate)
+	}
+	return Policy{MinLevel: level, SuccessSampleRate: rate}, nil
+}
+
+type Filter struct {
+	next   Notifier
+	policy Policy
+	sample func() float64
+}
+
+// NewFilter drops the notifications policy does not allow before they reach next.
+func NewFilter(next Notifier, policy Policy) *Filter {
+	return &Filter{next: next, policy: policy, sample: rand.Float64}
+}
+
+func (f *Filter) Notify(ctx context.Context, notification Notification) error {
+	if !f.allows(notification.Level) {
+		return nil
+	}
+	return f.next.Notify(ctx, notification)
+}
+
+// Flush waits for the notifier the filter passes notifications to.
+func (f *Filter) Flush(ctx context.Context) error {
+	return Flush(ctx, f.next)
+}
+
+func (f *Filter) allows(level Level) bool {
+	if levelRank[level] < levelRank[f.policy.MinLevel] {
+		return false
+	}
+	if level == LevelInfo {
+		return f.sample() < f.policy.SuccessSampleRate
+	}
+	return true
+}
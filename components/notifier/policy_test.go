@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"mentorship-app-backend/config"
+)
+
+func TestFilter(t *testing.T) {
+	recorder := &Recorder{}
+	filter := NewFilter(recorder, Policy{MinLevel: LevelWarning, SuccessSampleRate: 1})
+
+	for _, level := range []Level{LevelInfo, LevelWarning, LevelError} {
+		_ = filter.Notify(context.Background(), Notification{Level: level})
+	}
+	if sent := recorder.Sent(); len(sent) != 2 || sent[0].Level != LevelWarning {
+		t.Fatalf("expected only warning and error, got %+v", sent)
+	}
+}
+
+func TestFilterSamplesSuccessesOnly(t *testing.T) {
+	recorder := &Recorder{}
+	filter := NewFilter(recorder, Policy{MinLevel: LevelInfo, SuccessSampleRate: 0.25})
+	filter.sample = func() float64 { return 0.5 }
+
+	_ = filter.Notify(context.Background(), Notification{Level: LevelInfo})
+	_ = filter.Notify(context.Background(), Notification{Level: LevelError})
+
+	if sent := recorder.Sent(); len(sent) != 1 || sent[0].Level != LevelError {
+		t.Fatalf("expected the success to be sampled out, got %+v", sent)
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	half, never := 0.5, 0.0
+	notifications := config.NotificationsConfig{
+		Default:  config.NotificationPolicy{MinLevel: "warning", SuccessSampleRate: &half},
+		Handlers: map[string]config.NotificationPolicy{"LoginHandler": {SuccessSampleRate: &never}},
+	}
+
+	policy, err := PolicyFromConfig(notifications.PolicyFor("LoginHandler"))
+	if err != nil || policy.MinLevel != LevelWarning || policy.SuccessSampleRate != 0 {
+		t.Fatalf("unexpected policy %+v %v", policy, err)
+	}
+
+	if policy, err = PolicyFromConfig(config.NotificationPolicy{}); err != nil || policy.SuccessSampleRate != 1 {
+		t.Fatalf("expected everything to be sent by default, got %+v %v", policy, err)
+	}
+	if _, err = PolicyFromConfig(config.NotificationPolicy{MinLevel: "loud"}); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+}
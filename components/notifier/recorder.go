@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+)
+
+// Recorder is an in-memory Notifier for tests. It records every notification and returns Err, if set, after
+// recording it.
+type Recorder struct {
+	Err error
+
+	mu   sync.Mutex
+	sent []Notification
+}
+
+func (r *Recorder) Notify(_ context.Context, notification Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, notification)
+	return r.Err
+}
+
+// Sent returns a copy of the notifications recorded so far.
+func (r *Recorder) Sent() []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Notification(nil), r.sent...)
+}
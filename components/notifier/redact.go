@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+	redactedEmail = "[REDACTED_EMAIL]"
+	redactedToken = "[REDACTED_TOKEN]"
+)
+
+// sensitiveKeyParts marks JSON keys whose values are removed wholesale, e.g. id_token, new_password, email.
+var sensitiveKeyParts = []string{"token", "password", "secret", "authorization", "email", "session"}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+\S+`)
+)
+
+type Redactor struct {
+	next Notifier
+}
+
+// NewRedactor strips credentials and email addresses from every notification before it reaches next, so no
+// backend ever receives them.
+func NewRedactor(next Notifier) *Redactor {
+	return &Redactor{next: next}
+}
+
+func (r *Redactor) Notify(ctx context.Context, notification Notification) error {
+	return r.next.Notify(ctx, Redact(notification))
+}
+
+// Redact returns a copy of notification with sensitive values replaced. JSON field values are redacted by
+// key; any remaining text is scrubbed of email addresses, JWTs and bearer tokens.
+func Redact(notification Notification) Notification {
+	redacted := notification
+	redacted.Message = redactText(notification.Message)
+	redacted.Fields = make([]Field, len(notification.Fields))
+	for i, field := range notification.Fields {
+		redacted.Fields[i] = Field{Title: field.Title, Value: redactValue(field.Value), Short: field.Short}
+	}
+	return redacted
+}
+
+func redactValue(value string) string {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		switch decoded.(type) {
+		case map[string]interface{}, []interface{}:
+			if encoded, err := json.Marshal(redactJSON(decoded)); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+	return redactText(value)
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if isSensitiveKey(key) {
+				typed[key] = redactedValue
+			} else {
+				typed[key] = redactJSON(nested)
+			}
+		}
+		return typed
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = redactJSON(nested)
+		}
+		return typed
+	case string:
+		return redactText(typed)
+	default:
+		return typed
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactText(text string) string {
+	text = bearerPattern.ReplaceAllString(text, redactedToken)
+	text = jwtPattern.ReplaceAllString(text, redactedToken)
+	return emailPattern.ReplaceAllString(text, redactedEmail)
+}
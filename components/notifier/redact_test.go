@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	loginResponse := `{"id_token":"eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl","refresh_token":"opaque-refresh","user":{"email":"alice@example.com","name":"Alice"}}`
+	notification := Notification{
+		Message: "LoginHandler failed for bob@example.com",
+		Fields: []Field{
+			{Title: "Response", Value: loginResponse},
+			{Title: "Error", Value: "verify: Bearer eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl rejected"},
+			{Title: "Handler", Value: "LoginHandler"},
+		},
+	}
+
+	redacted := Redact(notification)
+
+	for _, leaked := range []string{"eyJhbGciOi", "opaque-refresh", "alice@example.com", "bob@example.com"} {
+		if strings.Contains(redacted.Message, leaked) {
+			t.Fatalf("message leaked %q: %s", leaked, redacted.Message)
+		}
+		for _, field := range redacted.Fields {
+			if strings.Contains(field.Value, leaked) {
+				t.Fatalf("field %s leaked %q: %s", field.Title, leaked, field.Value)
+			}
+		}
+	}
+	if !strings.Contains(redacted.Fields[0].Value, `"name":"Alice"`) || redacted.Fields[2].Value != "LoginHandler" {
+		t.Fatalf("non-sensitive values were changed: %+v", redacted.Fields)
+	}
+	if notification.Fields[0].Value != loginResponse {
+		t.Fatal("Redact modified its input")
+	}
+}
@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/slack-go/slack"
+)
+
+// TokenSource returns the Slack bot token. It is called per notification so that a secret that cannot be read
+// only fails that notification.
+type TokenSource func(ctx context.Context) (string, error)
+
+type SlackNotifier struct {
+	token TokenSource
+}
+
+func NewSlackNotifier(token TokenSource) *SlackNotifier {
+	return &SlackNotifier{token: token}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, notification Notification) error {
+	token, err := n.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve Slack token: %w", err)
+	}
+
+	api := slack.New(token)
+
+	if _, _, _, err = api.JoinConversationContext(ctx, notification.Channel); err != nil {
+		log.Printf("Unable to join conversation %s: %v", notification.Channel, err)
+	}
+
+	attachment := slack.Attachment{
+		Color:  slackColor(notification.Level),
+		Text:   notification.Message,
+		Fields: slackFields(notification.Fields),
+	}
+
+	if _, _, err = api.PostMessageContext(ctx, notification.Channel, slack.MsgOptionAttachments(attachment)); err != nil {
+		return fmt.Errorf("failed to send message to Slack channel %s: %w", notification.Channel, err)
+	}
+	return nil
+}
+
+func slackColor(level Level) string {
+	switch level {
+	case LevelWarning:
+		return "#FFA500"
+	case LevelError:
+		return "#FF0000"
+	default:
+		return "#36a64f"
+	}
+}
+
+func slackFields(fields []Field) []slack.AttachmentField {
+	converted := make([]slack.AttachmentField, 0, len(fields))
+	for _, field := range fields {
+		converted = append(converted, slack.AttachmentField{Title: field.Title, Value: field.Value, Short: field.Short})
+	}
+	return converted
+}
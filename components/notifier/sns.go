@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// snsSubjectLimit is the maximum length SNS accepts for an email subject.
+const snsSubjectLimit = 100
+
+type SNSPublisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSNotifier publishes notifications to a topic as JSON. Level and channel are also sent as message
+// attributes so subscribers can filter on them.
+type SNSNotifier struct {
+	client   SNSPublisher
+	topicARN string
+}
+
+func NewSNSNotifier(client SNSPublisher, topicARN string) *SNSNotifier {
+	return &SNSNotifier{client: client, topicARN: topicARN}
+}
+
+func (n *SNSNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	subject := notification.Message
+	if len(subject) > snsSubjectLimit {
+		subject = subject[:snsSubjectLimit]
+	}
+
+	// SNS rejects attributes with empty values, so only the ones that are set are sent.
+	attributes := map[string]types.MessageAttributeValue{}
+	for name, value := range map[string]string{"level": string(notification.Level), "channel": notification.Channel} {
+		if value != "" {
+			attributes[name] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+		}
+	}
+
+	_, err = n.client.Publish(ctx, &sns.PublishInput{
+		TopicArn:          aws.String(n.topicARN),
+		Subject:           aws.String(subject),
+		Message:           aws.String(string(body)),
+		MessageAttributes: attributes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish notification to SNS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTTL          = 5 * time.Minute
+	DefaultRefreshAhead = time.Minute
+	// refreshTimeout bounds a background refresh, which runs after the triggering request may have finished.
+	refreshTimeout = 5 * time.Second
+)
+
+type entry struct {
+	value      string
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// Cache keeps secret values in memory for the life of a Lambda container. A value younger than the TTL is
+// served from memory; once it is within refreshAhead of expiring, the next read still returns it but starts a
+// background refresh, so warm containers rarely wait on Secrets Manager.
+type Cache struct {
+	store        Store
+	ttl          time.Duration
+	refreshAhead time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewCache uses DefaultTTL and DefaultRefreshAhead for zero durations. refreshAhead is capped at the TTL.
+func NewCache(store Store, ttl, refreshAhead time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if refreshAhead <= 0 {
+		refreshAhead = DefaultRefreshAhead
+	}
+	if refreshAhead > ttl {
+		refreshAhead = ttl
+	}
+	return &Cache{store: store, ttl: ttl, refreshAhead: refreshAhead, now: time.Now, entries: map[string]*entry{}}
+}
+
+// Get returns the raw string value of a secret.
+func (c *Cache) Get(ctx context.Context, secretID string) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.entries[secretID]
+	if ok {
+		age := c.now().Sub(cached.fetchedAt)
+		if age < c.ttl {
+			if age >= c.ttl-c.refreshAhead && !cached.refreshing {
+				cached.refreshing = true
+				go c.refresh(context.WithoutCancel(ctx), secretID)
+			}
+			value := cached.value
+			c.mu.Unlock()
+			return value, nil
+		}
+	}
+	c.mu.Unlock()
+
+	value, err := c.store.GetSecretString(ctx, secretID)
+	if err != nil {
+		return "", err
+	}
+	c.put(secretID, value)
+	return value, nil
+}
+
+// Validator is implemented by secret schemas that have required keys.
+type Validator interface {
+	Validate() error
+}
+
+// Decode unmarshals a JSON secret into target, which must be a pointer to a struct describing its shape. A
+// target that implements Validator is validated after decoding.
+func (c *Cache) Decode(ctx context.Context, secretID string, target interface{}) error {
+	value, err := c.Get(ctx, secretID)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal([]byte(value), target); err != nil {
+		return fmt.Errorf("failed to parse secret JSON: %w", err)
+	}
+	if validator, ok := target.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+func (c *Cache) refresh(ctx context.Context, secretID string) {
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+
+	value, err := c.store.GetSecretString(ctx, secretID)
+	if err != nil {
+		// Keep serving the cached value until it expires; the next read after that fetches synchronously.
+		log.Printf("Failed to refresh secret %s: %v", secretID, err)
+		c.mu.Lock()
+		if cached, ok := c.entries[secretID]; ok {
+			cached.refreshing = false
+		}
+		c.mu.Unlock()
+		return
+	}
+	c.put(secretID, value)
+}
+
+func (c *Cache) put(secretID, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[secretID] = &entry{value: value, fetchedAt: c.now()}
+}
@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestCache(store Store, ttl, refreshAhead time.Duration) (*Cache, *clock) {
+	clk := &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	cache := NewCache(store, ttl, refreshAhead)
+	cache.now = clk.Now
+	return cache, clk
+}
+
+func waitForReads(t *testing.T, store *MemoryStore, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for store.Reads() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d reads, got %d", want, store.Reads())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCacheServesFromMemoryWithinTTL(t *testing.T) {
+	store := NewMemoryStore(map[string]string{"api": "v1"})
+	cache, clk := newTestCache(store, time.Minute, 10*time.Second)
+
+	for i := 0; i < 3; i++ {
+		if value, err := cache.Get(context.Background(), "api"); err != nil || value != "v1" {
+			t.Fatalf("unexpected value %q %v", value, err)
+		}
+		clk.now = clk.now.Add(10 * time.Second)
+	}
+	if store.Reads() != 1 {
+		t.Fatalf("expected a single store read, got %d", store.Reads())
+	}
+}
+
+func TestCacheRefreshesAhead(t *testing.T) {
+	store := NewMemoryStore(map[string]string{"api": "v1"})
+	cache, clk := newTestCache(store, time.Minute, 10*time.Second)
+
+	_, _ = cache.Get(context.Background(), "api")
+	store.Set("api", "v2")
+
+	clk.now = clk.now.Add(55 * time.Second)
+	if value, _ := cache.Get(context.Background(), "api"); value != "v1" {
+		t.Fatalf("expected the cached value while refreshing, got %q", value)
+	}
+	waitForReads(t, store, 2)
+
+	// The refresh reset the entry's age, so the new value is served without another read.
+	deadline := time.Now().Add(time.Second)
+	for {
+		value, _ := cache.Get(context.Background(), "api")
+		if value == "v2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("refreshed value was never served")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if store.Reads() != 2 {
+		t.Fatalf("expected exactly one background refresh, got %d reads", store.Reads())
+	}
+}
+
+func TestCacheFetchesAgainAfterExpiry(t *testing.T) {
+	store := NewMemoryStore(map[string]string{"api": "v1"})
+	cache, clk := newTestCache(store, time.Minute, time.Second)
+
+	_, _ = cache.Get(context.Background(), "api")
+	store.Set("api", "v2")
+	clk.now = clk.now.Add(2 * time.Minute)
+
+	if value, err := cache.Get(context.Background(), "api"); err != nil || value != "v2" {
+		t.Fatalf("expected a fresh value after expiry, got %q %v", value, err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	store := NewMemoryStore(map[string]string{
+		"slack":   `{"slack_token":"xoxb-1"}`,
+		"broken":  `not json`,
+		"missing": `{"other":"value"}`,
+	})
+	cache := NewCache(store, 0, 0)
+
+	var slack SlackSecret
+	if err := cache.Decode(context.Background(), "slack", &slack); err != nil || slack.Token != "xoxb-1" {
+		t.Fatalf("unexpected secret %+v %v", slack, err)
+	}
+
+	var database struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	store.Set("db", `{"host":"db.internal","port":5432}`)
+	if err := cache.Decode(context.Background(), "db", &database); err != nil || database.Port != 5432 {
+		t.Fatalf("unexpected secret %+v %v", database, err)
+	}
+
+	if err := cache.Decode(context.Background(), "broken", &slack); err == nil {
+		t.Fatal("expected an error for a non-JSON secret")
+	}
+	if err := cache.Decode(context.Background(), "missing", &SlackSecret{}); err == nil {
+		t.Fatal("expected a validation error for a missing key")
+	}
+	if err := cache.Decode(context.Background(), "unknown", &slack); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+}
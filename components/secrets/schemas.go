@@ -0,0 +1,15 @@
+package secrets
+
+import "fmt"
+
+// SlackSecret is the shape of the Slack notifier secret.
+type SlackSecret struct {
+	Token string `json:"slack_token"`
+}
+
+func (s SlackSecret) Validate() error {
+	if s.Token == "" {
+		return fmt.Errorf("slack_token key not found in secret")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Store fetches the raw string value of a secret. Cache sits in front of a Store; nothing else should call
+// one directly.
+type Store interface {
+	GetSecretString(ctx context.Context, secretID string) (string, error)
+}
+
+type SecretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type SecretsManagerStore struct {
+	client SecretsManagerAPI
+}
+
+func NewSecretsManagerStore(client SecretsManagerAPI) *SecretsManagerStore {
+	return &SecretsManagerStore{client: client}
+}
+
+func (s *SecretsManagerStore) GetSecretString(ctx context.Context, secretID string) (string, error) {
+	result, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve secret: %w", err)
+	}
+	if result.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+	return *result.SecretString, nil
+}
+
+// MemoryStore is an in-memory Store for tests and local runs.
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+	reads  int
+}
+
+func NewMemoryStore(values map[string]string) *MemoryStore {
+	store := &MemoryStore{values: map[string]string{}}
+	for id, value := range values {
+		store.values[id] = value
+	}
+	return store
+}
+
+func (s *MemoryStore) GetSecretString(_ context.Context, secretID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reads++
+	value, ok := s.values[secretID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, secretID)
+	}
+	return value, nil
+}
+
+func (s *MemoryStore) Set(secretID, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[secretID] = value
+}
+
+// Reads returns how many times the store has been asked for a value.
+func (s *MemoryStore) Reads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reads
+}
@@ -3,38 +3,95 @@ package config
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Environment             string `yaml:"environment"`
-	Account                 string `yaml:"account"`
-	AppName                 string `yaml:"app_name"`
-	Region                  string `yaml:"region"`
-	CognitoAuthorizer       string `yaml:"cognito_authorizer"`
-	CognitoPoolArn          string `yaml:"cognito_pool_arn"`
-	CognitoClientID         string `yaml:"cognito_client_id"`
-	UserProfileDDBTableName string `yaml:"user_profile_ddb_table_name"`
-	UserPoolName            string `yaml:"user_pool_name"`
-	BucketName              string `yaml:"bucket_name"`
-	SlackWebhookSecretARN   string `yaml:"slack_webhook_secret_arn"`
-	EndpointBaseURL         string `yaml:"endpoint_base_url"`
-	AllowUnconfirmedLogin   bool   `yaml:"allow_unconfirmed_login"`
+	Environment                    string              `yaml:"environment"`
+	Account                        string              `yaml:"account"`
+	AppName                        string              `yaml:"app_name"`
+	Region                         string              `yaml:"region"`
+	CognitoAuthorizer              string              `yaml:"cognito_authorizer"`
+	CognitoPoolArn                 string              `yaml:"cognito_pool_arn"`
+	CognitoClientID                string              `yaml:"cognito_client_id"`
+	UserProfileDDBTableName        string              `yaml:"user_profile_ddb_table_name"`
+	MentorshipRequestsDDBTableName string              `yaml:"mentorship_requests_ddb_table_name"`
+	AvailabilityDDBTableName       string              `yaml:"availability_ddb_table_name"`
+	BookingsDDBTableName           string              `yaml:"bookings_ddb_table_name"`
+	RevokedTokensDDBTableName      string              `yaml:"revoked_tokens_ddb_table_name"`
+	AccountJobsDDBTableName        string              `yaml:"account_jobs_ddb_table_name"`
+	UserPoolName                   string              `yaml:"user_pool_name"`
+	BucketName                     string              `yaml:"bucket_name"`
+	SlackWebhookSecretARN          string              `yaml:"slack_webhook_secret_arn"`
+	NotifierBackend                string              `yaml:"notifier_backend"`
+	NotificationWebhookURL         string              `yaml:"notification_webhook_url"`
+	NotificationTopicARN           string              `yaml:"notification_topic_arn"`
+	Notifications                  NotificationsConfig `yaml:"notifications"`
+	SecretsCacheTTL                time.Duration       `yaml:"secrets_cache_ttl"`
+	SecretsRefreshAhead            time.Duration       `yaml:"secrets_refresh_ahead"`
+	EndpointBaseURL                string              `yaml:"endpoint_base_url"`
+	AllowUnconfirmedLogin          bool                `yaml:"allow_unconfirmed_login"`
+	Endpoints                      EndpointsConfig     `yaml:"endpoints"`
 }
 
-var (
-	AppConfig     Config
-	awsConfig     aws.Config
-	cognitoClient *cognitoidentityprovider.Client
-	dynamoClient  *dynamodb.Client
-)
+// UserPoolID is the last segment of CognitoPoolArn, which the Admin* Cognito APIs take.
+func (c Config) UserPoolID() string {
+	return c.CognitoPoolArn[strings.LastIndex(c.CognitoPoolArn, "/")+1:]
+}
+
+// EndpointsConfig points the AWS clients at local stand-ins such as DynamoDB Local, MinIO or a fake Cognito.
+// An empty field keeps the service's real AWS endpoint.
+type EndpointsConfig struct {
+	DynamoDB       string `yaml:"dynamodb"`
+	S3             string `yaml:"s3"`
+	Cognito        string `yaml:"cognito"`
+	SecretsManager string `yaml:"secrets_manager"`
+	SNS            string `yaml:"sns"`
+	Lambda         string `yaml:"lambda"`
+}
+
+// NotificationPolicy limits what a handler reports: notifications below MinLevel are dropped and successful
+// calls are sampled at SuccessSampleRate. Unset fields inherit from the default policy.
+type NotificationPolicy struct {
+	MinLevel          string   `yaml:"min_level"`
+	SuccessSampleRate *float64 `yaml:"success_sample_rate"`
+}
+
+type NotificationsConfig struct {
+	Default  NotificationPolicy            `yaml:"default"`
+	Handlers map[string]NotificationPolicy `yaml:"handlers"`
+}
+
+// PolicyFor returns the policy of the named handler merged over the default policy.
+func (c NotificationsConfig) PolicyFor(handlerName string) NotificationPolicy {
+	policy := c.Default
+	override, ok := c.Handlers[handlerName]
+	if !ok {
+		return policy
+	}
+	if override.MinLevel != "" {
+		policy.MinLevel = override.MinLevel
+	}
+	if override.SuccessSampleRate != nil {
+		policy.SuccessSampleRate = override.SuccessSampleRate
+	}
+	return policy
+}
+
+var AppConfig Config
 
 func LoadConfig(environment string, filePath ...string) (Config, error) {
 	if environment == "" {
@@ -65,33 +122,59 @@ func LoadConfig(environment string, filePath ...string) (Config, error) {
 	return envConfig, nil
 }
 
-func InitAWSConfig(cfg Config) error {
-	var err error
-	awsConfig, err = config.LoadDefaultConfig(context.TODO(), config.WithRegion(cfg.Region))
-	if err != nil {
-		return fmt.Errorf("failed to load AWS configuration: %w", err)
-	}
-
-	cognitoClient = cognitoidentityprovider.NewFromConfig(awsConfig)
-	dynamoClient = dynamodb.NewFromConfig(awsConfig)
-
-	return nil
-}
-
-func AWSConfig() aws.Config {
-	return awsConfig
+// AWSCallTimeout bounds a single attempt of an AWS SDK call, so one slow dependency cannot use up the whole
+// handler deadline before the SDK gets a chance to retry or the handler to respond.
+const AWSCallTimeout = 5 * time.Second
+
+// Clients holds the AWS service clients a container shares between its handlers. Handlers depend on the narrow
+// interfaces in components/awsapi instead, so tests can substitute in-memory fakes.
+type Clients struct {
+	Cognito        *cognitoidentityprovider.Client
+	DynamoDB       *dynamodb.Client
+	S3             *s3.Client
+	SecretsManager *secretsmanager.Client
+	SNS            *sns.Client
+	Lambda         *lambda.Client
 }
 
-func CognitoClient() *cognitoidentityprovider.Client {
-	if cognitoClient == nil {
-		log.Fatal("Cognito client not initialized. Call InitAWSConfig first.")
+// NewClients builds every client from the default credential chain, honouring the endpoint overrides in cfg.
+func NewClients(cfg Config) (Clients, error) {
+	awsConfig, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithRegion(cfg.Region),
+		config.WithHTTPClient(awshttp.NewBuildableClient().WithTimeout(AWSCallTimeout)),
+	)
+	if err != nil {
+		return Clients{}, fmt.Errorf("failed to load AWS configuration: %w", err)
 	}
-	return cognitoClient
+
+	endpoints := cfg.Endpoints
+	return Clients{
+		Cognito: cognitoidentityprovider.NewFromConfig(awsConfig, func(o *cognitoidentityprovider.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.Cognito)
+		}),
+		DynamoDB: dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.DynamoDB)
+		}),
+		S3: s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.S3)
+			// MinIO and similar stand-ins serve buckets by path rather than by virtual host.
+			o.UsePathStyle = endpoints.S3 != ""
+		}),
+		SecretsManager: secretsmanager.NewFromConfig(awsConfig, func(o *secretsmanager.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.SecretsManager)
+		}),
+		SNS: sns.NewFromConfig(awsConfig, func(o *sns.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.SNS)
+		}),
+		Lambda: lambda.NewFromConfig(awsConfig, func(o *lambda.Options) {
+			o.BaseEndpoint = baseEndpoint(endpoints.Lambda)
+		}),
+	}, nil
 }
 
-func DynamoDBClient() *dynamodb.Client {
-	if dynamoClient == nil {
-		log.Fatal("DynamoDB client not initialized. Call InitAWSConfig first.")
+func baseEndpoint(url string) *string {
+	if url == "" {
+		return nil
 	}
-	return dynamoClient
+	return aws.String(url)
 }
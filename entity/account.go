@@ -0,0 +1,37 @@
+package entity
+
+type AccountJobStatus string
+
+const (
+	AccountJobRunning   AccountJobStatus = "running"
+	AccountJobCompleted AccountJobStatus = "completed"
+	AccountJobFailed    AccountJobStatus = "failed"
+)
+
+// AccountJob records the progress of a long-running operation on a user's account, so a failed run can resume
+// from the first step it did not complete. DynamoDB drops the item at ExpiresAt.
+type AccountJob struct {
+	UserID         string           `json:"-" dynamodbav:"UserId"`
+	JobID          string           `json:"job_id" dynamodbav:"JobId"`
+	Status         AccountJobStatus `json:"status" dynamodbav:"Status"`
+	CompletedSteps []string         `json:"completed_steps,omitempty" dynamodbav:"CompletedSteps"`
+	LastError      string           `json:"last_error,omitempty" dynamodbav:"LastError,omitempty"`
+	// ResultKey is the S3 key of what the job produced, such as an export archive.
+	ResultKey string `json:"-" dynamodbav:"ResultKey,omitempty"`
+	CreatedAt string `json:"created_at" dynamodbav:"CreatedAt"`
+	UpdatedAt string `json:"updated_at" dynamodbav:"UpdatedAt"`
+	ExpiresAt int64  `json:"-" dynamodbav:"ExpiresAt"`
+	// CognitoUserFound records that a deletion found the Cognito user, so a retry that no longer finds it knows
+	// the user was deleted rather than never looked up correctly.
+	CognitoUserFound bool `json:"-" dynamodbav:"CognitoUserFound,omitempty"`
+}
+
+// Completed reports whether step already ran to completion.
+func (j *AccountJob) Completed(step string) bool {
+	for _, completed := range j.CompletedSteps {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
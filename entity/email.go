@@ -0,0 +1,11 @@
+package entity
+
+type ChangeEmailRequest struct {
+	AccessToken string `json:"access_token"`
+	NewEmail    string `json:"new_email"`
+}
+
+type VerifyEmailRequest struct {
+	AccessToken string `json:"access_token"`
+	Code        string `json:"code"`
+}
@@ -10,6 +10,24 @@ type UploadRequest struct {
 	FileContent string `json:"file_content"`
 }
 
+// UploadResponse names the stored object. The bucket is private, so clients read it through the download
+// endpoints rather than from a bucket URL.
 type UploadResponse struct {
-	FileURL string `json:"FileURL"`
+	Key string `json:"key"`
+}
+
+type PresignUploadRequest struct {
+	Filename      string `json:"file_name"`
+	ContentType   string `json:"content_type"`
+	ContentLength int64  `json:"content_length"`
+}
+
+// PresignedURLResponse tells the client where to send the file and which headers must accompany the request,
+// since they are part of the signature.
+type PresignedURLResponse struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Key       string            `json:"key"`
+	ExpiresAt string            `json:"expires_at"`
 }
@@ -0,0 +1,18 @@
+package entity
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutAllRequest struct {
+	AccessToken string `json:"access_token"`
+}
+
+// RevokedToken is one entry of the token denylist. TokenID is either a token's jti or origin_jti, or a per-user
+// key for an entry that revokes everything the user was issued up to RevokedAt. DynamoDB drops the item at
+// ExpiresAt, once nothing it covers can still be valid.
+type RevokedToken struct {
+	TokenID   string `dynamodbav:"TokenId"`
+	RevokedAt int64  `dynamodbav:"RevokedAt"`
+	ExpiresAt int64  `dynamodbav:"ExpiresAt"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+type MentorshipStatus string
+
+const (
+	MentorshipStatusPending   MentorshipStatus = "pending"
+	MentorshipStatusAccepted  MentorshipStatus = "accepted"
+	MentorshipStatusDeclined  MentorshipStatus = "declined"
+	MentorshipStatusWithdrawn MentorshipStatus = "withdrawn"
+	MentorshipStatusExpired   MentorshipStatus = "expired"
+)
+
+type MentorshipRequest struct {
+	RequestID string           `json:"request_id" dynamodbav:"RequestId"`
+	MentorID  string           `json:"mentor_id" dynamodbav:"MentorId"`
+	MenteeID  string           `json:"mentee_id" dynamodbav:"MenteeId"`
+	Message   string           `json:"message,omitempty" dynamodbav:"Message,omitempty"`
+	Status    MentorshipStatus `json:"status" dynamodbav:"Status"`
+	CreatedAt string           `json:"created_at" dynamodbav:"CreatedAt"`
+	UpdatedAt string           `json:"updated_at" dynamodbav:"UpdatedAt"`
+	ExpiresAt string           `json:"expires_at" dynamodbav:"ExpiresAt"`
+}
+
+type CreateMentorshipRequest struct {
+	MentorID string `json:"mentor_id"`
+	Message  string `json:"message"`
+}
+
+type UpdateMentorshipRequest struct {
+	RequestID string `json:"request_id"`
+	Action    string `json:"action"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+// AuthChallenge is returned by login instead of tokens when Cognito needs another step to finish the sign-in,
+// such as an authenticator code. The client answers it through respond-challenge, passing Session back.
+type AuthChallenge struct {
+	ChallengeName string `json:"challenge_name"`
+	Session       string `json:"session"`
+	Email         string `json:"email"`
+}
+
+type RespondChallengeRequest struct {
+	Email         string `json:"email"`
+	ChallengeName string `json:"challenge_name"`
+	Session       string `json:"session"`
+	Code          string `json:"code"`
+	NewPassword   string `json:"new_password"`
+}
+
+// The MFA enrollment calls act on the signed-in user through Cognito's access token, which the client sends in
+// the body next to the ID token the authorizer checks.
+
+type MFAAssociateRequest struct {
+	AccessToken string `json:"access_token"`
+}
+
+type MFAAssociateResponse struct {
+	SecretCode string `json:"secret_code"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFAVerifyRequest struct {
+	AccessToken string `json:"access_token"`
+	Code        string `json:"code"`
+	DeviceName  string `json:"device_name"`
+}
+
+type MFAPreferenceRequest struct {
+	AccessToken string `json:"access_token"`
+	Enabled     bool   `json:"enabled"`
+	Preferred   bool   `json:"preferred"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Email       string `json:"email"`
+	Code        string `json:"code"`
+	NewPassword string `json:"new_password"`
+}
+
+type ChangePasswordRequest struct {
+	AccessToken      string `json:"access_token"`
+	PreviousPassword string `json:"previous_password"`
+	ProposedPassword string `json:"proposed_password"`
+}
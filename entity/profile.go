@@ -0,0 +1,35 @@
+package entity
+
+type Profile struct {
+	UserID string `json:"user_id" dynamodbav:"UserId"`
+	User
+	Bio               string            `json:"bio,omitempty" dynamodbav:"Bio,omitempty"`
+	Languages         []string          `json:"languages,omitempty" dynamodbav:"Languages,omitempty"`
+	SocialLinks       map[string]string `json:"social_links,omitempty" dynamodbav:"SocialLinks,omitempty"`
+	Skills            []string          `json:"skills,omitempty" dynamodbav:"Skills,omitempty"`
+	Industry          string            `json:"industry,omitempty" dynamodbav:"Industry,omitempty"`
+	Seniority         string            `json:"seniority,omitempty" dynamodbav:"Seniority,omitempty"`
+	YearsOfExperience int               `json:"years_of_experience,omitempty" dynamodbav:"YearsOfExperience,omitempty"`
+	Available         bool              `json:"available" dynamodbav:"Available"`
+	Goals             string            `json:"goals,omitempty" dynamodbav:"Goals,omitempty"`
+	Interests         []string          `json:"interests,omitempty" dynamodbav:"Interests,omitempty"`
+	Version           int               `json:"version" dynamodbav:"Version"`
+	CreatedAt         string            `json:"created_at,omitempty" dynamodbav:"CreatedAt,omitempty"`
+	UpdatedAt         string            `json:"updated_at,omitempty" dynamodbav:"UpdatedAt,omitempty"`
+}
+
+type ProfileUpdateRequest struct {
+	Version           *int               `json:"version"`
+	Name              *string            `json:"name"`
+	Bio               *string            `json:"bio"`
+	ProfilePicture    *string            `json:"profile_picture"`
+	Languages         *[]string          `json:"languages"`
+	SocialLinks       *map[string]string `json:"social_links"`
+	Skills            *[]string          `json:"skills"`
+	Industry          *string            `json:"industry"`
+	Seniority         *string            `json:"seniority"`
+	YearsOfExperience *int               `json:"years_of_experience"`
+	Available         *bool              `json:"available"`
+	Goals             *string            `json:"goals"`
+	Interests         *[]string          `json:"interests"`
+}
@@ -0,0 +1,5 @@
+package entity
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
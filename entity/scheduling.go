@@ -0,0 +1,54 @@
+package entity
+
+type WeeklyWindow struct {
+	Weekday string `json:"weekday" dynamodbav:"Weekday"`
+	Start   string `json:"start" dynamodbav:"Start"`
+	End     string `json:"end" dynamodbav:"End"`
+}
+
+// AvailabilityException overrides the weekly schedule on one date: Available=false blocks the given range
+// (the whole day when Start and End are empty), Available=true opens an extra window.
+type AvailabilityException struct {
+	Date      string `json:"date" dynamodbav:"Date"`
+	Start     string `json:"start,omitempty" dynamodbav:"Start,omitempty"`
+	End       string `json:"end,omitempty" dynamodbav:"End,omitempty"`
+	Available bool   `json:"available" dynamodbav:"Available"`
+}
+
+type Availability struct {
+	MentorID       string                  `json:"mentor_id" dynamodbav:"MentorId"`
+	TimeZone       string                  `json:"time_zone" dynamodbav:"TimeZone"`
+	SessionMinutes int                     `json:"session_minutes" dynamodbav:"SessionMinutes"`
+	Weekly         []WeeklyWindow          `json:"weekly" dynamodbav:"Weekly"`
+	Exceptions     []AvailabilityException `json:"exceptions,omitempty" dynamodbav:"Exceptions,omitempty"`
+	UpdatedAt      string                  `json:"updated_at,omitempty" dynamodbav:"UpdatedAt,omitempty"`
+}
+
+type Booking struct {
+	UserID    string `json:"-" dynamodbav:"UserId"`
+	StartTime string `json:"start_time" dynamodbav:"StartTime"`
+	EndTime   string `json:"end_time" dynamodbav:"EndTime"`
+	BookingID string `json:"booking_id" dynamodbav:"BookingId"`
+	MentorID  string `json:"mentor_id" dynamodbav:"MentorId"`
+	MenteeID  string `json:"mentee_id" dynamodbav:"MenteeId"`
+	Note      string `json:"note,omitempty" dynamodbav:"Note,omitempty"`
+	CreatedAt string `json:"created_at" dynamodbav:"CreatedAt"`
+}
+
+type SetAvailabilityRequest struct {
+	TimeZone       string                  `json:"time_zone"`
+	SessionMinutes int                     `json:"session_minutes"`
+	Weekly         []WeeklyWindow          `json:"weekly"`
+	Exceptions     []AvailabilityException `json:"exceptions"`
+}
+
+type BookSessionRequest struct {
+	MentorID  string `json:"mentor_id"`
+	StartTime string `json:"start_time"`
+	Note      string `json:"note"`
+}
+
+type AvailabilityResponse struct {
+	Availability *Availability `json:"availability"`
+	OpenSlots    []string      `json:"open_slots"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+type MentorSearchFilter struct {
+	Skill     string
+	Industry  string
+	Language  string
+	Seniority string
+	Available *bool
+}
+
+// MentorSummary is the public part of a mentor profile that search returns to any signed-in user. It leaves out
+// the email and the profile's bookkeeping fields. ProfilePicture is an object key any signed-in user may read
+// through download-url.
+type MentorSummary struct {
+	UserID            string            `json:"user_id"`
+	Name              string            `json:"name"`
+	ProfilePicture    string            `json:"profile_picture,omitempty"`
+	Bio               string            `json:"bio,omitempty"`
+	Languages         []string          `json:"languages,omitempty"`
+	SocialLinks       map[string]string `json:"social_links,omitempty"`
+	Skills            []string          `json:"skills,omitempty"`
+	Industry          string            `json:"industry,omitempty"`
+	Seniority         string            `json:"seniority,omitempty"`
+	YearsOfExperience int               `json:"years_of_experience,omitempty"`
+	Available         bool              `json:"available"`
+}
+
+type MentorSearchResponse struct {
+	Mentors    []MentorSummary `json:"mentors"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
@@ -1,9 +1,22 @@
 package entity
 
 type IDTokenPayload struct {
-	Email         string `json:"email"`
-	CustomRole    string `json:"custom:role"`
-	Name          string `json:"name"`
-	EmailVerified bool   `json:"email_verified"`
-	Sub           string `json:"sub"`
+	Email         string   `json:"email"`
+	CustomRole    string   `json:"custom:role"`
+	Name          string   `json:"name"`
+	EmailVerified bool     `json:"email_verified"`
+	Sub           string   `json:"sub"`
+	Issuer        string   `json:"iss"`
+	Audience      string   `json:"aud"`
+	TokenUse      string   `json:"token_use"`
+	ExpiresAt     int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	TokenID       string   `json:"jti"`
+	OriginTokenID string   `json:"origin_jti"`
+	Groups        []string `json:"cognito:groups"`
+}
+
+type TokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
 }
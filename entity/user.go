@@ -1,8 +1,8 @@
 package entity
 
 type User struct {
-	Email          string `json:"email"`
-	Name           string `json:"name"`
-	ProfilePicture string `json:"profile_picture"`
-	Role           string `json:"role"`
+	Email          string `json:"email" dynamodbav:"Email"`
+	Name           string `json:"name" dynamodbav:"Name"`
+	ProfilePicture string `json:"profile_picture" dynamodbav:"ProfilePicURL"`
+	Role           string `json:"role" dynamodbav:"ProfileType"`
 }
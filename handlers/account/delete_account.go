@@ -0,0 +1,274 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// DeletionJobID is the job ID of a user's account deletion. A user has one, so retrying resumes it.
+	DeletionJobID = "deletion"
+	// deletionRecordRetention keeps the record of a deletion, which holds nothing but the sub and the steps
+	// that ran, as evidence that the erasure happened.
+	deletionRecordRetention = 90 * 24 * time.Hour
+	// deletionStepHeadroom is the least time left before the deadline in which another step is started. With
+	// less, the request answers 202 and the client's next call carries on.
+	deletionStepHeadroom = 5 * time.Second
+
+	// Bookings are keyed by RFC 3339 start time, so these bounds cover every booking a user can have.
+	allBookingsFrom = "0000"
+	allBookingsTo   = "9999"
+)
+
+type deletionStep struct {
+	name string
+	run  func(h *Handlers, ctx context.Context, job *entity.AccountJob) error
+}
+
+// forUser adapts a step that only needs the user's sub.
+func forUser(run func(h *Handlers, ctx context.Context, sub string) error) func(*Handlers, context.Context, *entity.AccountJob) error {
+	return func(h *Handlers, ctx context.Context, job *entity.AccountJob) error {
+		return run(h, ctx, job.UserID)
+	}
+}
+
+// deletionSteps run in order, each safe to repeat. The Cognito user goes after the data, so a failed deletion
+// can be retried with the caller's still valid ID token, and the sessions go last, which ends that token too.
+var deletionSteps = []deletionStep{
+	{name: "files", run: forUser((*Handlers).deleteFiles)},
+	{name: "bookings", run: forUser((*Handlers).deleteBookings)},
+	{name: "availability", run: forUser((*Handlers).deleteAvailability)},
+	{name: "mentorship_requests", run: forUser((*Handlers).deleteMentorshipRequests)},
+	{name: "profiles", run: forUser((*Handlers).deleteProfiles)},
+	{name: "cognito_user", run: (*Handlers).deleteCognitoUser},
+	{name: "sessions", run: forUser((*Handlers).revokeSessions)},
+}
+
+// DeleteAccount erases the caller's account and everything stored about them. Progress is saved after each step
+// in the caller's deletion job, so after a failure the client calls DeleteAccount again and it carries on from
+// the step that failed. A deletion that does not fit in one API Gateway request stops between steps, or when a
+// step runs out of time, and answers 202 with the running job; the client repeats the call until it gets 200.
+func (h *Handlers) DeleteAccount(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if caller.Sub == "" {
+		return errorpackage.CodedError(errorpackage.CodeInvalidToken, errorpackage.ErrMissingSubject.Error())
+	}
+
+	now := h.Now().UTC()
+	job, err := h.Jobs.Get(ctx, caller.Sub, DeletionJobID)
+	switch {
+	case errorpackage.IsDynamoDBNotFoundError(err):
+		job = &entity.AccountJob{UserID: caller.Sub, JobID: DeletionJobID, CompletedSteps: []string{}, CreatedAt: now.Format(time.RFC3339)}
+	case err != nil:
+		return errorpackage.FromError(fmt.Errorf("failed to load deletion job: %w", err))
+	}
+	job.Status = entity.AccountJobRunning
+	job.LastError = ""
+	job.ExpiresAt = now.Add(deletionRecordRetention).Unix()
+	if err = h.Jobs.Put(ctx, job); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to save deletion job: %w", err))
+	}
+
+	for _, step := range deletionSteps {
+		if job.Completed(step.name) {
+			continue
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < deletionStepHeadroom {
+			return deletionResponse(job, http.StatusAccepted)
+		}
+		if err = step.run(h, ctx, job); err != nil {
+			// The job was saved after the last completed step, and this one is safe to repeat.
+			if errorpackage.IsTimeoutError(err) {
+				log.Printf("account deletion of %s ran out of time at %s: %v", caller.Sub, step.name, err)
+				return deletionResponse(job, http.StatusAccepted)
+			}
+			log.Printf("account deletion of %s failed at %s: %v", caller.Sub, step.name, err)
+			job.Status = entity.AccountJobFailed
+			job.LastError = "deletion stopped at step " + step.name + ", retry to resume"
+			if putErr := h.Jobs.Put(ctx, job); putErr != nil {
+				log.Printf("failed to record the failed deletion of %s: %v", caller.Sub, putErr)
+			}
+			return errorpackage.FromError(fmt.Errorf("failed to delete %s: %w", step.name, err))
+		}
+		job.CompletedSteps = append(job.CompletedSteps, step.name)
+		if err = h.Jobs.Put(ctx, job); err != nil {
+			return errorpackage.FromError(fmt.Errorf("failed to save deletion job: %w", err))
+		}
+	}
+
+	job.Status = entity.AccountJobCompleted
+	if err = h.Jobs.Put(ctx, job); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to save deletion job: %w", err))
+	}
+
+	return deletionResponse(job, http.StatusOK)
+}
+
+func deletionResponse(job *entity.AccountJob, status int) (events.APIGatewayProxyResponse, error) {
+	responseBody, err := json.Marshal(job)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal deletion job")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    wrapper.SetHeadersDelete(),
+		Body:       string(responseBody),
+	}, nil
+}
+
+// deleteFiles removes the user's uploads and data exports.
+func (h *Handlers) deleteFiles(ctx context.Context, sub string) error {
+	for _, prefix := range []string{ownership.UserPrefix(sub), ownership.ExportPrefix(sub)} {
+		if err := h.deletePrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deletePrefix removes everything under prefix, one DeleteObjects call per listed page. Pages are capped at the
+// DeleteObjects limit, so each page fits in one request.
+func (h *Handlers) deletePrefix(ctx context.Context, prefix string) error {
+	paginator := s3.NewListObjectsV2Paginator(h.S3, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(h.BucketName),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(awsapi.MaxDeleteObjects),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		if len(page.Contents) == 0 {
+			continue
+		}
+
+		objects := make([]s3types.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, s3types.ObjectIdentifier{Key: object.Key})
+		}
+		result, err := h.S3.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(h.BucketName),
+			Delete: &s3types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete files: %w", err)
+		}
+		// DeleteObjects succeeds as a whole and reports the keys it could not delete.
+		if len(result.Errors) > 0 {
+			first := result.Errors[0]
+			return fmt.Errorf("failed to delete %d files, first %s: %s", len(result.Errors), aws.ToString(first.Key), aws.ToString(first.Code))
+		}
+	}
+	return nil
+}
+
+// deleteBookings removes both copies of each of the user's sessions, since a session with a deleted account
+// cannot take place.
+func (h *Handlers) deleteBookings(ctx context.Context, sub string) error {
+	bookings, err := h.Schedules.ListBookings(ctx, sub, allBookingsFrom, allBookingsTo)
+	if err != nil {
+		return err
+	}
+	for _, booking := range bookings {
+		// The user's own copy goes last, so a retry still finds the booking and the other copy.
+		for _, owner := range []string{counterpart(booking, sub), sub} {
+			if err = h.Schedules.DeleteBooking(ctx, owner, &booking); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Handlers) deleteAvailability(ctx context.Context, sub string) error {
+	return h.Schedules.DeleteAvailability(ctx, sub)
+}
+
+func (h *Handlers) deleteMentorshipRequests(ctx context.Context, sub string) error {
+	asMentor, err := h.Requests.ListByMentor(ctx, sub)
+	if err != nil {
+		return err
+	}
+	asMentee, err := h.Requests.ListByMentee(ctx, sub)
+	if err != nil {
+		return err
+	}
+	for _, request := range append(asMentor, asMentee...) {
+		if err = h.Requests.Delete(ctx, &request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handlers) deleteProfiles(ctx context.Context, sub string) error {
+	profiles, err := h.Profiles.Query(ctx, sub)
+	if err != nil {
+		return err
+	}
+	for _, stored := range profiles {
+		if err = h.Profiles.Delete(ctx, sub, stored.Role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCognitoUser deletes the user, looked up by sub since their username is their email. A user that cannot
+// be found fails the step, unless an earlier attempt found and deleted them but failed to record the step.
+func (h *Handlers) deleteCognitoUser(ctx context.Context, job *entity.AccountJob) error {
+	username, err := h.cognitoUsername(ctx, job.UserID)
+	if errors.Is(err, errorpackage.ErrUserNotResolved) && job.CognitoUserFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !job.CognitoUserFound {
+		job.CognitoUserFound = true
+		if err = h.Jobs.Put(ctx, job); err != nil {
+			return fmt.Errorf("failed to save deletion job: %w", err)
+		}
+	}
+	_, err = h.Cognito.AdminDeleteUser(ctx, &cognitoidentityprovider.AdminDeleteUserInput{
+		UserPoolId: aws.String(h.Config.UserPoolID()),
+		Username:   aws.String(username),
+	})
+	return err
+}
+
+// revokeSessions denylists the ID tokens still in circulation. Deleting the Cognito user already invalidated its
+// refresh and access tokens.
+func (h *Handlers) revokeSessions(ctx context.Context, sub string) error {
+	return h.Revocations.RevokeUser(ctx, sub)
+}
+
+func counterpart(booking entity.Booking, sub string) string {
+	if booking.MentorID == sub {
+		return booking.MenteeID
+	}
+	return booking.MentorID
+}
@@ -0,0 +1,236 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+const sessionStart = "2024-06-03T09:00:00Z"
+
+// seed gives Ada, the caller, a mentor profile, availability, files, a mentorship and a session with Grace, and
+// Grace a profile and a file of her own that must survive the deletion.
+func seed(files int) func(env *testEnv) {
+	return func(env *testEnv) {
+		ctx := context.Background()
+		_ = env.profiles.Create(ctx, &entity.Profile{UserID: env.ada, User: entity.User{Email: adaEmail, Role: "mentor"}})
+		_ = env.profiles.Create(ctx, &entity.Profile{UserID: env.grace, User: entity.User{Email: graceEmail, Role: "mentee"}})
+		_ = env.schedules.PutAvailability(ctx, &entity.Availability{MentorID: env.ada, TimeZone: "UTC", SessionMinutes: 60})
+		_ = env.schedules.Book(ctx, &entity.Booking{StartTime: sessionStart, EndTime: "2024-06-03T10:00:00Z", MentorID: env.ada, MenteeID: env.grace})
+		_, _ = env.requests.Create(ctx, env.ada, env.grace, "Hello")
+		_, _ = env.requests.Create(ctx, "sub-linus", env.grace, "Hi")
+		for i := 0; i < files; i++ {
+			env.s3.Put(testBucket, fmt.Sprintf("%sfile-%04d.pdf", ownership.UserPrefix(env.ada), i), awsapi.Object{Body: []byte("x")})
+		}
+		env.s3.Put(testBucket, ownership.UserPrefix(env.grace)+"cv.pdf", awsapi.Object{Body: []byte("x")})
+		env.s3.Put(testBucket, ownership.ExportPrefix(env.ada)+"old.zip", awsapi.Object{Body: []byte("x")})
+	}
+}
+
+func expectErased(t *testing.T, env *testEnv, body string) {
+	t.Helper()
+	ctx := context.Background()
+
+	var job entity.AccountJob
+	if err := json.Unmarshal([]byte(body), &job); err != nil || job.Status != entity.AccountJobCompleted || len(job.CompletedSteps) != len(deletionSteps) {
+		t.Fatalf("expected a completed job, got %s", body)
+	}
+	if _, ok := env.cognito.User(adaEmail); ok {
+		t.Fatal("the Cognito user is still there")
+	}
+	if profiles, _ := env.profiles.Query(ctx, env.ada); len(profiles) != 0 {
+		t.Fatalf("profiles were not deleted: %v", profiles)
+	}
+	if _, err := env.schedules.GetAvailability(ctx, env.ada); !errors.Is(err, errorpackage.ErrNoSuchKey) {
+		t.Fatalf("availability was not deleted: %v", err)
+	}
+	for _, owner := range []string{env.ada, env.grace} {
+		if bookings, _ := env.schedules.ListBookings(ctx, owner, allBookingsFrom, allBookingsTo); len(bookings) != 0 {
+			t.Fatalf("bookings of %s were not deleted: %v", owner, bookings)
+		}
+	}
+	if requests, _ := env.requests.ListByMentor(ctx, env.ada); len(requests) != 0 {
+		t.Fatalf("mentorship requests were not deleted: %v", requests)
+	}
+	for _, key := range []string{ownership.UserPrefix(env.ada) + "file-0000.pdf", ownership.ExportPrefix(env.ada) + "old.zip"} {
+		if _, ok := env.s3.Object(testBucket, key); ok {
+			t.Fatalf("%s was not deleted", key)
+		}
+	}
+	if revoked, _ := env.revocations.Revoked(ctx, caller(env.ada, adaEmail)); !revoked {
+		t.Fatal("the caller's ID tokens were not revoked")
+	}
+
+	if _, ok := env.cognito.User(graceEmail); !ok {
+		t.Fatal("another user's account was deleted")
+	}
+	if profiles, _ := env.profiles.Query(ctx, env.grace); len(profiles) != 1 {
+		t.Fatal("another user's profile was deleted")
+	}
+	if requests, _ := env.requests.ListByMentee(ctx, env.grace); len(requests) != 1 {
+		t.Fatalf("expected another user's other mentorship to survive, got %v", requests)
+	}
+	if _, ok := env.s3.Object(testBucket, ownership.UserPrefix(env.grace)+"cv.pdf"); !ok {
+		t.Fatal("another user's file was deleted")
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	runCases(t, (*Handlers).DeleteAccount, []testCase{
+		{Name: "erases the caller", Caller: ada, Setup: seed(3), Status: http.StatusOK, Check: expectErased},
+		{Name: "deletes files in batches", Caller: ada, Setup: seed(awsapi.MaxDeleteObjects + 1), Status: http.StatusOK, Check: expectErased},
+		{Name: "nothing stored", Caller: ada, Status: http.StatusOK, Check: func(t *testing.T, env *testEnv, body string) {
+			if _, ok := env.cognito.User(adaEmail); ok {
+				t.Fatal("the Cognito user is still there")
+			}
+		}},
+		{
+			Name:   "Cognito user cannot be found",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				_, _ = env.cognito.AdminDeleteUser(context.Background(), &cognitoidentityprovider.AdminDeleteUserInput{Username: aws.String(adaEmail)})
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				job, err := env.jobs.Get(context.Background(), env.ada, DeletionJobID)
+				if err != nil || job.Status != entity.AccountJobFailed || job.Completed("cognito_user") || job.CognitoUserFound {
+					t.Fatalf("expected the deletion to stop at the Cognito user, got %+v: %v", job, err)
+				}
+			},
+		},
+		{
+			Name:   "Cognito user deleted by an attempt that failed to record it",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				_ = env.jobs.Put(context.Background(), &entity.AccountJob{
+					UserID:           env.ada,
+					JobID:            DeletionJobID,
+					Status:           entity.AccountJobFailed,
+					CompletedSteps:   []string{"files", "bookings", "availability", "mentorship_requests", "profiles"},
+					CognitoUserFound: true,
+				})
+				_, _ = env.cognito.AdminDeleteUser(context.Background(), &cognitoidentityprovider.AdminDeleteUserInput{Username: aws.String(adaEmail)})
+			},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				var job entity.AccountJob
+				if err := json.Unmarshal([]byte(body), &job); err != nil || job.Status != entity.AccountJobCompleted || !job.Completed("sessions") {
+					t.Fatalf("expected the deletion to complete, got %s", body)
+				}
+			},
+		},
+		{
+			Name:   "looking up the Cognito user fails",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				env.cognito.Fail("ListUsers", errors.New("throttled"))
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if _, ok := env.cognito.User(adaEmail); !ok {
+					t.Fatal("the Cognito user was deleted")
+				}
+			},
+		},
+		{
+			Name:   "step runs out of time",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				env.requests.Fail("ListByMentor", context.DeadlineExceeded)
+			},
+			Status: http.StatusAccepted,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				job, err := env.jobs.Get(context.Background(), env.ada, DeletionJobID)
+				if err != nil || job.Status != entity.AccountJobRunning || len(job.CompletedSteps) != 3 || job.LastError != "" {
+					t.Fatalf("expected a running job after three steps, got %+v: %v", job, err)
+				}
+			},
+		},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{
+			Name:   "step fails",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				env.requests.Fail("Delete", errors.New("throughput exceeded"))
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				job, err := env.jobs.Get(context.Background(), env.ada, DeletionJobID)
+				if err != nil || job.Status != entity.AccountJobFailed || len(job.CompletedSteps) != 3 {
+					t.Fatalf("expected the failure to be recorded after three steps, got %+v: %v", job, err)
+				}
+				if _, ok := env.cognito.User(adaEmail); !ok {
+					t.Fatal("the Cognito user was deleted before the data")
+				}
+			},
+		},
+	})
+}
+
+func TestDeleteAccountResumes(t *testing.T) {
+	env := newTestEnv()
+	seed(2)(env)
+	ctx := wrapper.WithCaller(context.Background(), caller(env.ada, adaEmail))
+
+	env.profiles.Fail("Query", errors.New("throughput exceeded"))
+	if response, _ := env.handlers.DeleteAccount(ctx, events.APIGatewayProxyRequest{}); response.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the first attempt to fail, got %d", response.StatusCode)
+	}
+	// Steps that completed must not run again.
+	env.profiles.Fail("Query", nil)
+	env.s3.Fail("ListObjectsV2", errors.New("should not be called"))
+
+	response, err := env.handlers.DeleteAccount(ctx, events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the retry to succeed, got %d: %s", response.StatusCode, response.Body)
+	}
+	var job entity.AccountJob
+	if err = json.Unmarshal([]byte(response.Body), &job); err != nil || job.Status != entity.AccountJobCompleted || job.LastError != "" {
+		t.Fatalf("expected a completed job, got %s", response.Body)
+	}
+	if profiles, _ := env.profiles.Query(context.Background(), env.ada); len(profiles) != 0 {
+		t.Fatalf("profiles were not deleted on retry: %v", profiles)
+	}
+}
+
+func TestDeleteAccountContinuesOverSeveralCalls(t *testing.T) {
+	env := newTestEnv()
+	seed(2)(env)
+	ctx := wrapper.WithCaller(context.Background(), caller(env.ada, adaEmail))
+
+	short, cancel := context.WithTimeout(ctx, deletionStepHeadroom/2)
+	defer cancel()
+	response, err := env.handlers.DeleteAccount(short, events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected the deletion to be accepted without time for a step, got %d: %s", response.StatusCode, response.Body)
+	}
+	var job entity.AccountJob
+	if err = json.Unmarshal([]byte(response.Body), &job); err != nil || job.Status != entity.AccountJobRunning || len(job.CompletedSteps) != 0 {
+		t.Fatalf("expected a running job without steps, got %s", response.Body)
+	}
+
+	if response, err = env.handlers.DeleteAccount(ctx, events.APIGatewayProxyRequest{}); err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the next call to finish the deletion, got %d: %s", response.StatusCode, response.Body)
+	}
+	expectErased(t, env, response.Body)
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/account"
+	"mentorship-app-backend/handlers/bootstrap"
+)
+
+func main() {
+	handlers := account.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.ExportStatus, routes.ExportStatusLambdaName)
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"mentorship-app-backend/handlers/account"
+	"mentorship-app-backend/handlers/bootstrap"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// The export worker is invoked by the export Lambda rather than through the API, so it runs without the API
+// middleware chain.
+func main() {
+	handlers := account.New(bootstrap.Init(), bootstrap.Clients())
+
+	lambda.Start(handlers.RunExport)
+}
@@ -0,0 +1,537 @@
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// ExportJobID is the job ID of a user's data export. Requesting a new export replaces the previous one.
+	ExportJobID = "export"
+	// ExportURLExpiry bounds each download link. Polling the export signs a fresh one every time.
+	ExportURLExpiry = time.Hour
+
+	// The job record expires with the archive it points at.
+	exportRecordRetention = ownership.ExportRetentionDays * 24 * time.Hour
+	// An export still running after exportStaleAfter has outlived the worker's timeout, so a new request starts
+	// over instead of waiting for it.
+	exportStaleAfter = 20 * time.Minute
+
+	// Exports of at most this many files and bytes of files are built within the request. Anything larger goes
+	// to the export worker.
+	inlineExportMaxFiles = 100
+	inlineExportMaxBytes = 10 << 20
+
+	exportFormatVersion = 1
+	exportFileName      = "mentorship-data-export.zip"
+	failedExportMessage = "export failed, request a new one"
+)
+
+// ExportEvent is the payload the export worker is invoked with.
+type ExportEvent struct {
+	UserID string `json:"user_id"`
+}
+
+// exportResponse is the export job as the client polls it. DownloadURL is only set once the archive is ready.
+type exportResponse struct {
+	*entity.AccountJob
+	DownloadURL       string `json:"download_url,omitempty"`
+	DownloadExpiresAt string `json:"download_expires_at,omitempty"`
+}
+
+// StartExport starts an export of everything stored about the caller. Small exports are built right away and
+// answered with 200 and the download link; larger ones are handed to the export worker and answered with 202,
+// after which the client polls ExportStatus. A request while an export is running returns that export.
+func (h *Handlers) StartExport(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if caller.Sub == "" {
+		return errorpackage.CodedError(errorpackage.CodeInvalidToken, errorpackage.ErrMissingSubject.Error())
+	}
+
+	now := h.Now().UTC()
+	previous, err := h.Jobs.Get(ctx, caller.Sub, ExportJobID)
+	switch {
+	case errorpackage.IsDynamoDBNotFoundError(err):
+		previous = nil
+	case err != nil:
+		return errorpackage.FromError(fmt.Errorf("failed to load export job: %w", err))
+	case previous.Status == entity.AccountJobRunning && !exportIsStale(previous, now):
+		return h.exportResponse(ctx, previous, http.StatusAccepted, wrapper.SetHeadersPost())
+	}
+
+	files, err := h.listFiles(ctx, ownership.UserPrefix(caller.Sub))
+	if err != nil {
+		return errorpackage.FromError(err)
+	}
+
+	job := &entity.AccountJob{
+		UserID:    caller.Sub,
+		JobID:     ExportJobID,
+		Status:    entity.AccountJobRunning,
+		CreatedAt: now.Format(time.RFC3339),
+		ExpiresAt: now.Add(exportRecordRetention).Unix(),
+	}
+	if previous != nil {
+		// Kept so the previous archive is deleted once this one is stored.
+		job.ResultKey = previous.ResultKey
+	}
+	if err = h.Jobs.Put(ctx, job); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to save export job: %w", err))
+	}
+
+	if len(files) <= inlineExportMaxFiles && totalSize(files) <= inlineExportMaxBytes {
+		if err = h.export(ctx, job, files); err != nil {
+			return errorpackage.FromError(fmt.Errorf("failed to export: %w", err))
+		}
+		return h.exportResponse(ctx, job, http.StatusOK, wrapper.SetHeadersPost())
+	}
+
+	payload, err := json.Marshal(ExportEvent{UserID: caller.Sub})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal export event")
+	}
+	_, err = h.Functions.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(h.ExportWorker),
+		InvocationType: lambdatypes.InvocationTypeEvent,
+		Payload:        payload,
+	})
+	if err != nil {
+		h.failExport(ctx, job, err)
+		return errorpackage.FromError(fmt.Errorf("failed to start export worker: %w", err))
+	}
+	return h.exportResponse(ctx, job, http.StatusAccepted, wrapper.SetHeadersPost())
+}
+
+// ExportStatus returns the caller's latest export, with a download link once it has completed.
+func (h *Handlers) ExportStatus(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if caller.Sub == "" {
+		return errorpackage.CodedError(errorpackage.CodeInvalidToken, errorpackage.ErrMissingSubject.Error())
+	}
+
+	job, err := h.Jobs.Get(ctx, caller.Sub, ExportJobID)
+	if errorpackage.IsDynamoDBNotFoundError(err) {
+		return errorpackage.CodedError(errorpackage.CodeNotFound, "No export has been requested")
+	}
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to load export job: %w", err))
+	}
+
+	return h.exportResponse(ctx, job, http.StatusOK, wrapper.SetHeadersGet(""))
+}
+
+// RunExport is the export worker. Failures are recorded on the job for the client to see rather than returned,
+// since Lambda's retries of an asynchronous invocation would only repeat them; only a job that cannot be loaded
+// is left to those retries.
+func (h *Handlers) RunExport(ctx context.Context, event ExportEvent) error {
+	job, err := h.Jobs.Get(ctx, event.UserID, ExportJobID)
+	if err != nil {
+		return fmt.Errorf("failed to load export job of %s: %w", event.UserID, err)
+	}
+	if job.Status != entity.AccountJobRunning {
+		log.Printf("export of %s is %s, nothing to do", event.UserID, job.Status)
+		return nil
+	}
+
+	files, err := h.listFiles(ctx, ownership.UserPrefix(event.UserID))
+	if err != nil {
+		h.failExport(ctx, job, err)
+		return nil
+	}
+	if err = h.export(ctx, job, files); err != nil {
+		log.Printf("export of %s did not complete: %v", event.UserID, err)
+	}
+	return nil
+}
+
+// ExportWorkerFunction runs RunExport on a raw invocation payload, for running the worker in-process through
+// awsapi.MemoryLambda.
+func (h *Handlers) ExportWorkerFunction() awsapi.LambdaFunction {
+	return func(ctx context.Context, payload []byte) error {
+		var event ExportEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("invalid export event: %w", err)
+		}
+		return h.RunExport(ctx, event)
+	}
+}
+
+// export builds and stores the archive, then completes job and deletes the archive it replaces. A failure to
+// build or store the archive is recorded on the job.
+func (h *Handlers) export(ctx context.Context, job *entity.AccountJob, files []s3types.Object) error {
+	key, err := h.storeArchive(ctx, job.UserID, files)
+	if err != nil {
+		h.failExport(ctx, job, err)
+		return err
+	}
+
+	replaced := job.ResultKey
+	job.ResultKey = key
+	job.Status = entity.AccountJobCompleted
+	job.LastError = ""
+	if err = h.Jobs.Put(ctx, job); err != nil {
+		return fmt.Errorf("failed to save export job: %w", err)
+	}
+
+	if replaced != "" && replaced != key {
+		// The lifecycle rule removes it eventually if this fails.
+		if _, err = h.S3.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(h.BucketName), Key: aws.String(replaced)}); err != nil {
+			log.Printf("failed to delete the previous export %s: %v", replaced, err)
+		}
+	}
+	return nil
+}
+
+// failExport records a failed export. The error itself is only logged, since it can name internal resources.
+func (h *Handlers) failExport(ctx context.Context, job *entity.AccountJob, cause error) {
+	log.Printf("export of %s failed: %v", job.UserID, cause)
+	job.Status = entity.AccountJobFailed
+	job.LastError = failedExportMessage
+	if err := h.Jobs.Put(ctx, job); err != nil {
+		log.Printf("failed to record the failed export of %s: %v", job.UserID, err)
+	}
+}
+
+func (h *Handlers) exportResponse(ctx context.Context, job *entity.AccountJob, status int, headers map[string]string) (events.APIGatewayProxyResponse, error) {
+	response := exportResponse{AccountJob: job}
+	if job.Status == entity.AccountJobCompleted && job.ResultKey != "" {
+		presigned, err := h.Presign.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket:                     aws.String(h.BucketName),
+			Key:                        aws.String(job.ResultKey),
+			ResponseContentDisposition: aws.String(`attachment; filename="` + exportFileName + `"`),
+		})
+		if err != nil {
+			return errorpackage.FromError(fmt.Errorf("failed to presign export: %w", err))
+		}
+		response.DownloadURL = presigned.URL
+		response.DownloadExpiresAt = h.Now().UTC().Add(ExportURLExpiry).Format(time.RFC3339)
+	}
+
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal export job")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    headers,
+		Body:       string(responseBody),
+	}, nil
+}
+
+// storeArchive writes the archive to a temporary file, since S3 needs the length of the body up front, and
+// uploads it under the user's export prefix with an unguessable name.
+func (h *Handlers) storeArchive(ctx context.Context, sub string, files []s3types.Object) (string, error) {
+	archive, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+
+	if err = h.writeArchive(ctx, archive, sub, files); err != nil {
+		return "", err
+	}
+	size, err := archive.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to measure archive: %w", err)
+	}
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	exportID, err := newExportID()
+	if err != nil {
+		return "", err
+	}
+	key := ownership.ExportPrefix(sub) + exportID + ".zip"
+	_, err = h.S3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(h.BucketName),
+		Key:           aws.String(key),
+		Body:          archive,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String("application/zip"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload archive: %w", err)
+	}
+	return key, nil
+}
+
+type exportManifest struct {
+	FormatVersion int             `json:"format_version"`
+	UserID        string          `json:"user_id"`
+	GeneratedAt   string          `json:"generated_at"`
+	Entries       []manifestEntry `json:"entries"`
+}
+
+type manifestEntry struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Records     *int   `json:"records,omitempty"`
+	Size        *int64 `json:"size,omitempty"`
+}
+
+// exportDocument is one JSON file of the archive.
+type exportDocument struct {
+	path        string
+	description string
+	records     int
+	value       interface{}
+}
+
+// writeArchive writes manifest.json first, then one JSON document per store and the uploaded files under
+// files/, streamed from S3 one at a time.
+func (h *Handlers) writeArchive(ctx context.Context, w io.Writer, sub string, files []s3types.Object) error {
+	documents, err := h.collect(ctx, sub)
+	if err != nil {
+		return err
+	}
+
+	manifest := exportManifest{
+		FormatVersion: exportFormatVersion,
+		UserID:        sub,
+		GeneratedAt:   h.Now().UTC().Format(time.RFC3339),
+		Entries:       []manifestEntry{},
+	}
+	for _, document := range documents {
+		records := document.records
+		manifest.Entries = append(manifest.Entries, manifestEntry{Path: document.path, Description: document.description, Records: &records})
+	}
+	prefix := ownership.UserPrefix(sub)
+	for _, file := range files {
+		size := aws.ToInt64(file.Size)
+		manifest.Entries = append(manifest.Entries, manifestEntry{Path: archivePath(prefix, file), Description: "Uploaded file", Size: &size})
+	}
+
+	archive := zip.NewWriter(w)
+	if err = writeJSON(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, document := range documents {
+		if err = writeJSON(archive, document.path, document.value); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		if err = h.copyFile(ctx, archive, archivePath(prefix, file), aws.ToString(file.Key)); err != nil {
+			return err
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+type exportedAccount struct {
+	Username     string            `json:"username"`
+	Status       string            `json:"status"`
+	Enabled      bool              `json:"enabled"`
+	CreatedAt    string            `json:"created_at,omitempty"`
+	UpdatedAt    string            `json:"updated_at,omitempty"`
+	Attributes   map[string]string `json:"attributes"`
+	MFAMethods   []string          `json:"mfa_methods,omitempty"`
+	PreferredMFA string            `json:"preferred_mfa,omitempty"`
+}
+
+// exportedMessage is the message a mentee sent with a mentorship request, the only messages users exchange.
+type exportedMessage struct {
+	RequestID string `json:"request_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Body      string `json:"body"`
+	SentAt    string `json:"sent_at"`
+}
+
+// collect reads everything stored about sub outside S3.
+func (h *Handlers) collect(ctx context.Context, sub string) ([]exportDocument, error) {
+	username, err := h.cognitoUsername(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.Cognito.AdminGetUser(ctx, &cognitoidentityprovider.AdminGetUserInput{
+		UserPoolId: aws.String(h.Config.UserPoolID()),
+		Username:   aws.String(username),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account: %w", err)
+	}
+	account := exportedAccount{
+		Username:     aws.ToString(user.Username),
+		Status:       string(user.UserStatus),
+		Enabled:      user.Enabled,
+		Attributes:   map[string]string{},
+		MFAMethods:   user.UserMFASettingList,
+		PreferredMFA: aws.ToString(user.PreferredMfaSetting),
+	}
+	if user.UserCreateDate != nil {
+		account.CreatedAt = user.UserCreateDate.UTC().Format(time.RFC3339)
+	}
+	if user.UserLastModifiedDate != nil {
+		account.UpdatedAt = user.UserLastModifiedDate.UTC().Format(time.RFC3339)
+	}
+	for _, attribute := range user.UserAttributes {
+		account.Attributes[aws.ToString(attribute.Name)] = aws.ToString(attribute.Value)
+	}
+
+	profiles, err := h.Profiles.Query(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	asMentor, err := h.Requests.ListByMentor(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mentorship requests: %w", err)
+	}
+	asMentee, err := h.Requests.ListByMentee(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mentorship requests: %w", err)
+	}
+	requests := append(asMentor, asMentee...)
+	bookings, err := h.Schedules.ListBookings(ctx, sub, allBookingsFrom, allBookingsTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sessions: %w", err)
+	}
+	availability, err := h.Schedules.GetAvailability(ctx, sub)
+	if err != nil && !errorpackage.IsDynamoDBNotFoundError(err) {
+		return nil, fmt.Errorf("failed to read availability: %w", err)
+	}
+
+	messages := []exportedMessage{}
+	for _, request := range requests {
+		if request.Message != "" {
+			messages = append(messages, exportedMessage{
+				RequestID: request.RequestID,
+				From:      request.MenteeID,
+				To:        request.MentorID,
+				Body:      request.Message,
+				SentAt:    request.CreatedAt,
+			})
+		}
+	}
+
+	documents := []exportDocument{
+		{path: "account.json", description: "Sign-in account and its attributes", records: 1, value: account},
+		{path: "profiles.json", description: "Profiles, one per role", records: len(profiles), value: orEmpty(profiles)},
+		{path: "mentorship_requests.json", description: "Mentorship requests sent and received", records: len(requests), value: orEmpty(requests)},
+		{path: "messages.json", description: "Messages sent with mentorship requests", records: len(messages), value: messages},
+		{path: "sessions.json", description: "Booked mentoring sessions", records: len(bookings), value: orEmpty(bookings)},
+	}
+	if availability != nil {
+		documents = append(documents, exportDocument{path: "availability.json", description: "Weekly availability offered as a mentor", records: 1, value: availability})
+	}
+	return documents, nil
+}
+
+func (h *Handlers) copyFile(ctx context.Context, archive *zip.Writer, path, key string) error {
+	object, err := h.S3.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(h.BucketName), Key: aws.String(key)})
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", key, err)
+	}
+	defer object.Body.Close()
+
+	entry, err := archive.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", path, err)
+	}
+	if _, err = io.Copy(entry, object.Body); err != nil {
+		return fmt.Errorf("failed to copy file %s: %w", key, err)
+	}
+	return nil
+}
+
+// listFiles returns every object under prefix.
+func (h *Handlers) listFiles(ctx context.Context, prefix string) ([]s3types.Object, error) {
+	var files []s3types.Object
+	paginator := s3.NewListObjectsV2Paginator(h.S3, &s3.ListObjectsV2Input{
+		Bucket: aws.String(h.BucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+		files = append(files, page.Contents...)
+	}
+	return files, nil
+}
+
+func writeJSON(archive *zip.Writer, path string, value interface{}) error {
+	raw, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	entry, err := archive.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", path, err)
+	}
+	_, err = entry.Write(raw)
+	return err
+}
+
+func archivePath(prefix string, file s3types.Object) string {
+	return "files/" + strings.TrimPrefix(aws.ToString(file.Key), prefix)
+}
+
+func totalSize(files []s3types.Object) int64 {
+	var total int64
+	for _, file := range files {
+		total += aws.ToInt64(file.Size)
+	}
+	return total
+}
+
+func exportIsStale(job *entity.AccountJob, now time.Time) bool {
+	updated, err := time.Parse(time.RFC3339, job.UpdatedAt)
+	return err != nil || now.Sub(updated) > exportStaleAfter
+}
+
+// orEmpty keeps empty lists as [] rather than null in the archive.
+func orEmpty[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
+func newExportID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate export ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
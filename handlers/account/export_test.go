@@ -0,0 +1,287 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// decodeExport returns the export job of a response body and its download URL.
+func decodeExport(t *testing.T, body string) (entity.AccountJob, string) {
+	t.Helper()
+	var response struct {
+		entity.AccountJob
+		DownloadURL string `json:"download_url"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("body is not valid JSON: %v\n%s", err, body)
+	}
+	return response.AccountJob, response.DownloadURL
+}
+
+// readArchive returns the contents of the caller's stored export by path.
+func readArchive(t *testing.T, env *testEnv) map[string][]byte {
+	t.Helper()
+	job, err := env.jobs.Get(context.Background(), env.ada, ExportJobID)
+	if err != nil || job.Status != entity.AccountJobCompleted {
+		t.Fatalf("expected a completed export, got %+v: %v", job, err)
+	}
+	if !strings.HasPrefix(job.ResultKey, ownership.ExportPrefix(env.ada)) {
+		t.Fatalf("export stored outside the export prefix: %s", job.ResultKey)
+	}
+	object, ok := env.s3.Object(testBucket, job.ResultKey)
+	if !ok {
+		t.Fatalf("no archive at %s", job.ResultKey)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(object.Body), int64(len(object.Body)))
+	if err != nil {
+		t.Fatalf("archive is not a ZIP: %v", err)
+	}
+	if len(archive.File) == 0 || archive.File[0].Name != "manifest.json" {
+		t.Fatal("the manifest is not the first entry")
+	}
+	contents := map[string][]byte{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		contents[file.Name], _ = io.ReadAll(reader)
+		_ = reader.Close()
+	}
+	return contents
+}
+
+func expectArchive(files int) func(t *testing.T, env *testEnv, body string) {
+	return func(t *testing.T, env *testEnv, _ string) {
+		t.Helper()
+		contents := readArchive(t, env)
+
+		var manifest exportManifest
+		if err := json.Unmarshal(contents["manifest.json"], &manifest); err != nil || manifest.UserID != env.ada {
+			t.Fatalf("invalid manifest: %s", contents["manifest.json"])
+		}
+		if len(manifest.Entries) != len(contents)-1 {
+			t.Fatalf("the manifest lists %d entries, the archive holds %d", len(manifest.Entries), len(contents)-1)
+		}
+		for _, entry := range manifest.Entries {
+			if _, ok := contents[entry.Path]; !ok {
+				t.Errorf("the manifest lists %s, which is not in the archive", entry.Path)
+			}
+		}
+
+		if !bytes.Contains(contents["account.json"], []byte(adaEmail)) {
+			t.Errorf("account.json lacks the email: %s", contents["account.json"])
+		}
+		if !bytes.Contains(contents["profiles.json"], []byte(`"role": "mentor"`)) {
+			t.Errorf("profiles.json lacks the profile: %s", contents["profiles.json"])
+		}
+		if !bytes.Contains(contents["messages.json"], []byte(`"body": "Hello"`)) || bytes.Contains(contents["messages.json"], []byte("Hi")) {
+			t.Errorf("messages.json should hold exactly the caller's messages: %s", contents["messages.json"])
+		}
+		if !bytes.Contains(contents["sessions.json"], []byte(sessionStart)) {
+			t.Errorf("sessions.json lacks the session: %s", contents["sessions.json"])
+		}
+		if _, ok := contents["availability.json"]; !ok {
+			t.Error("availability.json is missing")
+		}
+
+		stored := 0
+		for path := range contents {
+			if strings.HasPrefix(path, "files/") {
+				stored++
+			}
+		}
+		if stored != files || string(contents["files/file-0000.pdf"]) != "x" {
+			t.Errorf("expected %d files, the archive holds %d", files, stored)
+		}
+	}
+}
+
+func TestStartExport(t *testing.T) {
+	runCases(t, (*Handlers).StartExport, []testCase{
+		{
+			Name:   "small export is built right away",
+			Caller: ada,
+			Setup:  seed(3),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				job, url := decodeExport(t, body)
+				if job.Status != entity.AccountJobCompleted || url == "" {
+					t.Fatalf("expected a completed export with a link, got %s", body)
+				}
+				if len(env.functions.Invocations()) != 0 {
+					t.Fatal("a small export invoked the worker")
+				}
+				expectArchive(3)(t, env, body)
+			},
+		},
+		{
+			Name:   "large export goes to the worker",
+			Caller: ada,
+			Setup:  seed(inlineExportMaxFiles + 1),
+			Status: http.StatusAccepted,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if job, url := decodeExport(t, body); job.Status != entity.AccountJobRunning || url != "" {
+					t.Fatalf("expected a running export without a link, got %s", body)
+				}
+				invocations := env.functions.Invocations()
+				if len(invocations) != 1 || invocations[0].InvocationType != lambdatypes.InvocationTypeEvent || string(invocations[0].Payload) != `{"user_id":"`+env.ada+`"}` {
+					t.Fatalf("expected one asynchronous invocation of the worker, got %+v", invocations)
+				}
+				// MemoryLambda has already run the worker.
+				expectArchive(inlineExportMaxFiles+1)(t, env, body)
+			},
+		},
+		{
+			Name:   "worker cannot be invoked",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(inlineExportMaxFiles + 1)(env)
+				env.functions.Fail("Invoke", errors.New("throttled"))
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectFailedExport,
+		},
+		{
+			Name:   "export already running",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				_ = env.jobs.Put(context.Background(), &entity.AccountJob{UserID: env.ada, JobID: ExportJobID, Status: entity.AccountJobRunning})
+			},
+			Status: http.StatusAccepted,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if job, _ := decodeExport(t, body); job.Status != entity.AccountJobRunning {
+					t.Fatalf("expected the running export, got %s", body)
+				}
+				if len(env.functions.Invocations()) != 0 {
+					t.Fatal("a second export was started")
+				}
+			},
+		},
+		{
+			Name:   "replaces the previous export",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				previous := ownership.ExportPrefix(env.ada) + "previous.zip"
+				env.s3.Put(testBucket, previous, awsapi.Object{Body: []byte("zip")})
+				_ = env.jobs.Put(context.Background(), &entity.AccountJob{UserID: env.ada, JobID: ExportJobID, Status: entity.AccountJobCompleted, ResultKey: previous})
+			},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if _, ok := env.s3.Object(testBucket, ownership.ExportPrefix(env.ada)+"previous.zip"); ok {
+					t.Fatal("the previous export was kept")
+				}
+				expectArchive(1)(t, env, body)
+			},
+		},
+		{
+			Name:   "reading the account fails",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				env.cognito.Fail("AdminGetUser", errors.New("throttled"))
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectFailedExport,
+		},
+		{
+			Name:   "account cannot be found",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				seed(1)(env)
+				_, _ = env.cognito.AdminDeleteUser(context.Background(), &cognitoidentityprovider.AdminDeleteUserInput{Username: aws.String(adaEmail)})
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectFailedExport,
+		},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+	})
+}
+
+func expectFailedExport(t *testing.T, env *testEnv, _ string) {
+	t.Helper()
+	job, err := env.jobs.Get(context.Background(), env.ada, ExportJobID)
+	if err != nil || job.Status != entity.AccountJobFailed || job.LastError != failedExportMessage {
+		t.Fatalf("expected the failure to be recorded, got %+v: %v", job, err)
+	}
+}
+
+func TestExportStatus(t *testing.T) {
+	runCases(t, (*Handlers).ExportStatus, []testCase{
+		{Name: "nothing requested", Caller: ada, Status: http.StatusNotFound, Code: errorpackage.CodeNotFound},
+		{
+			Name:   "running",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				_ = env.jobs.Put(context.Background(), &entity.AccountJob{UserID: env.ada, JobID: ExportJobID, Status: entity.AccountJobRunning})
+			},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if job, url := decodeExport(t, body); job.Status != entity.AccountJobRunning || url != "" {
+					t.Fatalf("expected a running export without a link, got %s", body)
+				}
+			},
+		},
+		{
+			Name:   "completed",
+			Caller: ada,
+			Setup: func(env *testEnv) {
+				_ = env.jobs.Put(context.Background(), &entity.AccountJob{UserID: env.ada, JobID: ExportJobID, Status: entity.AccountJobCompleted, ResultKey: ownership.ExportPrefix(env.ada) + "a.zip"})
+			},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if _, url := decodeExport(t, body); !strings.Contains(url, ownership.ExportPrefix(env.ada)+"a.zip") {
+					t.Fatalf("expected a link to the archive, got %s", body)
+				}
+			},
+		},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+	})
+}
+
+func TestRunExportIgnoresFinishedJobs(t *testing.T) {
+	env := newTestEnv()
+	job := &entity.AccountJob{UserID: env.ada, JobID: ExportJobID, Status: entity.AccountJobCompleted, ResultKey: "exports/kept.zip"}
+	_ = env.jobs.Put(context.Background(), job)
+
+	if err := env.handlers.RunExport(context.Background(), ExportEvent{UserID: env.ada}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := env.jobs.Get(context.Background(), env.ada, ExportJobID); stored.ResultKey != "exports/kept.zip" {
+		t.Fatalf("a finished export was redone: %+v", stored)
+	}
+	if err := env.handlers.RunExport(context.Background(), ExportEvent{UserID: "sub-unknown"}); err == nil {
+		t.Fatal("expected an error for a missing job, so Lambda retries the invocation")
+	}
+}
+
+func TestExportIsStale(t *testing.T) {
+	now := time.Now().UTC()
+	fresh := &entity.AccountJob{UpdatedAt: now.Add(-time.Minute).Format(time.RFC3339)}
+	stale := &entity.AccountJob{UpdatedAt: now.Add(-exportStaleAfter - time.Minute).Format(time.RFC3339)}
+	if exportIsStale(fresh, now) || !exportIsStale(stale, now) || !exportIsStale(&entity.AccountJob{}, now) {
+		t.Fatal("unexpected staleness")
+	}
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/repository/accountjob"
+	"mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"mentorship-app-backend/repository/revocation"
+	"mentorship-app-backend/repository/scheduling"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Handlers serves the endpoints that act on everything stored about the caller at once, across Cognito, S3
+// and every table.
+type Handlers struct {
+	Config      config.Config
+	Cognito     awsapi.CognitoAPI
+	Profiles    profile.Repository
+	Requests    mentorship.Repository
+	Schedules   scheduling.Repository
+	Revocations revocation.Repository
+	Jobs        accountjob.Repository
+	S3          awsapi.S3API
+	Presign     awsapi.PresignAPI
+	BucketName  string
+	// Functions invokes ExportWorker, the deployed name of the export worker, for exports too large to build
+	// within a request.
+	Functions    awsapi.LambdaAPI
+	ExportWorker string
+	Now          func() time.Time
+}
+
+// New opens every table an account operation touches and addresses the export worker by its deployed name.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		Config:      cfg,
+		Cognito:     clients.Cognito,
+		Profiles:    profile.NewDynamoRepository(clients.DynamoDB, cfg.UserProfileDDBTableName),
+		Requests:    mentorship.NewDynamoRepository(clients.DynamoDB, cfg.MentorshipRequestsDDBTableName),
+		Schedules:   scheduling.NewDynamoRepository(clients.DynamoDB, cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName),
+		Revocations: revocation.NewDynamoRepository(clients.DynamoDB, cfg.RevokedTokensDDBTableName),
+		Jobs:        accountjob.NewDynamoRepository(clients.DynamoDB, cfg.AccountJobsDDBTableName),
+		S3:          clients.S3,
+		Presign: awss3.NewPresignClient(clients.S3, func(options *awss3.PresignOptions) {
+			options.Expires = ExportURLExpiry
+		}),
+		BucketName:   cfg.BucketName,
+		Functions:    clients.Lambda,
+		ExportWorker: routes.FunctionName(routes.ExportWorkerLambdaName, cfg.Environment),
+		Now:          time.Now,
+	}
+}
+
+// cognitoUsername finds the username of the user whose sub is sub. Users sign up with their email as the
+// username, so the sub cannot stand in for it in the Admin* calls.
+func (h *Handlers) cognitoUsername(ctx context.Context, sub string) (string, error) {
+	output, err := h.Cognito.ListUsers(ctx, &cognitoidentityprovider.ListUsersInput{
+		UserPoolId: aws.String(h.Config.UserPoolID()),
+		Filter:     aws.String(fmt.Sprintf("sub = %q", sub)),
+		Limit:      aws.Int32(1),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up user: %w", err)
+	}
+	if len(output.Users) == 0 {
+		return "", fmt.Errorf("%w: %s", errorpackage.ErrUserNotResolved, sub)
+	}
+	return aws.ToString(output.Users[0].Username), nil
+}
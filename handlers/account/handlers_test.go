@@ -0,0 +1,88 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+	"mentorship-app-backend/repository/accountjob"
+	"mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"mentorship-app-backend/repository/revocation"
+	"mentorship-app-backend/repository/scheduling"
+)
+
+const (
+	testPoolArn  = "arn:aws:cognito-idp:us-east-1:123456789012:userpool/us-east-1_TestPool"
+	testClientID = "test-client-id"
+	testBucket   = "test-bucket"
+	testPassword = "Secret123"
+	testWorker   = "export-worker-test"
+	adaEmail     = "ada@example.com"
+	graceEmail   = "grace@example.com"
+)
+
+type testEnv struct {
+	handlers    *Handlers
+	cognito     *awsapi.MemoryCognito
+	profiles    *profile.MemoryRepository
+	requests    *mentorship.MemoryRepository
+	schedules   *scheduling.MemoryRepository
+	revocations *revocation.MemoryRepository
+	jobs        *accountjob.MemoryRepository
+	s3          *awsapi.MemoryS3
+	functions   *awsapi.MemoryLambda
+	// ada and grace are the subs of the two seeded users. Ada is the caller, a mentor to Grace.
+	ada, grace string
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{
+		cognito:     awsapi.NewMemoryCognito(testClientID),
+		profiles:    profile.NewMemoryRepository(),
+		requests:    mentorship.NewMemoryRepository(),
+		schedules:   scheduling.NewMemoryRepository(),
+		revocations: revocation.NewMemoryRepository(),
+		jobs:        accountjob.NewMemoryRepository(),
+		s3:          awsapi.NewMemoryS3(),
+		functions:   awsapi.NewMemoryLambda(),
+	}
+	env.ada = env.cognito.AddUser(adaEmail, testPassword, map[string]string{"custom:role": "mentor"})
+	env.grace = env.cognito.AddUser(graceEmail, testPassword, map[string]string{"custom:role": "mentee"})
+	env.handlers = &Handlers{
+		Config:       config.Config{CognitoClientID: testClientID, CognitoPoolArn: testPoolArn},
+		Cognito:      env.cognito,
+		Profiles:     env.profiles,
+		Requests:     env.requests,
+		Schedules:    env.schedules,
+		Revocations:  env.revocations,
+		Jobs:         env.jobs,
+		S3:           env.s3,
+		Presign:      env.s3,
+		BucketName:   testBucket,
+		Functions:    env.functions,
+		ExportWorker: testWorker,
+		Now:          time.Now,
+	}
+	env.functions.Register(testWorker, env.handlers.ExportWorkerFunction())
+	return env
+}
+
+// caller returns the ID token payload of the user with sub, as the authorizer would pass it on.
+func caller(sub, email string) *entity.IDTokenPayload {
+	return &entity.IDTokenPayload{Sub: sub, Email: email}
+}
+
+// ada is the ID token of the caller in most cases. MemoryCognito numbers subs from 1, so Ada, seeded first,
+// is always sub-1.
+var ada = caller("sub-1", adaEmail)
+
+type testCase = handlertest.Case[*testEnv]
+
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], tests []testCase) {
+	t.Helper()
+	handlertest.Run(t, newTestEnv, func(env *testEnv) *Handlers { return env.handlers }, handler, tests)
+}
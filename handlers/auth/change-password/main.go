@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/auth"
+	"mentorship-app-backend/handlers/bootstrap"
+)
+
+func main() {
+	handlers := auth.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.ChangePassword, routes.ChangePasswordLambdaName)
+}
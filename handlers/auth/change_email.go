@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// maxEmailSyncAttempts bounds the retries when a concurrent profile update bumps the version between our read
+// and write.
+const maxEmailSyncAttempts = 3
+
+// ChangeEmail asks Cognito to move the caller to a new email, which receives a verification code. What happens
+// to sign-in until VerifyEmail confirms the code is up to the user pool, which the stack imports rather than
+// defines: with AttributesRequireVerificationBeforeUpdate set on email, Cognito keeps the old email in place
+// while the change is pending. Without it the email is replaced at once, unverified, and Login and the
+// AdminGetUser lookups must be given the new one.
+func (h *Handlers) ChangeEmail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := wrapper.Caller(ctx); err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.ChangeEmailRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	if err := validator.ValidateEmail(req.NewEmail); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	_, err := h.Cognito.UpdateUserAttributes(ctx, &cognitoidentityprovider.UpdateUserAttributesInput{
+		AccessToken:    &req.AccessToken,
+		UserAttributes: []types.AttributeType{{Name: aws.String("email"), Value: &req.NewEmail}},
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsUserAlreadyExistsError(err):
+			return errorpackage.CodedError(errorpackage.CodeUserExists, "An account with this email already exists")
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many email change attempts, please try again later")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to update email: %w", err))
+		}
+	}
+
+	return accountResponse("Verification code sent to the new email")
+}
+
+// VerifyEmail confirms a pending email change and copies the new email onto the caller's profiles. The ID token
+// keeps the old email claim until the client refreshes its tokens.
+func (h *Handlers) VerifyEmail(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := wrapper.Caller(ctx); err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.VerifyEmailRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	if req.Code == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Verification code is required")
+	}
+
+	_, err := h.Cognito.VerifyUserAttribute(ctx, &cognitoidentityprovider.VerifyUserAttributeInput{
+		AccessToken:   &req.AccessToken,
+		AttributeName: aws.String("email"),
+		Code:          &req.Code,
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsCodeMismatchError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidCode, errorpackage.ErrCodeMismatch.Error())
+		case errorpackage.IsExpiredConfirmationCodeError(err):
+			return errorpackage.CodedError(errorpackage.CodeExpiredCode, errorpackage.ErrExpiredCode.Error())
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many verification attempts, please try again later")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to verify email: %w", err))
+		}
+	}
+
+	// The profiles to update are looked up by the access token's owner rather than the ID token's, so a caller
+	// cannot rewrite somebody else's profile by pairing their own ID token with another user's access token.
+	user, err := h.Cognito.GetUser(ctx, &cognitoidentityprovider.GetUserInput{AccessToken: &req.AccessToken})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to read verified user: %w", err))
+	}
+	sub, email := userAttribute(user.UserAttributes, "sub"), userAttribute(user.UserAttributes, "email")
+	if err = h.syncProfileEmail(ctx, sub, email); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to update profile email: %w", err))
+	}
+
+	return accountResponse("Email changed, refresh your tokens to pick up the new email")
+}
+
+// syncProfileEmail rewrites the denormalised Email on each of the user's profiles. Profiles are keyed by sub, so
+// no row has to move.
+func (h *Handlers) syncProfileEmail(ctx context.Context, userID, email string) error {
+	profiles, err := h.Profiles.Query(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range profiles {
+		for attempt := 1; existing.Email != email; attempt++ {
+			_, err = h.Profiles.Update(ctx, userID, existing.Role, existing.Version, profile.Changes{"Email": email})
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, errorpackage.ErrVersionConflict) || attempt == maxEmailSyncAttempts {
+				return err
+			}
+			log.Printf("profile %s changed while updating its email, retrying", userID)
+			current, err := h.Profiles.Get(ctx, userID, existing.Role)
+			if err != nil {
+				return err
+			}
+			existing = *current
+		}
+	}
+	return nil
+}
+
+func userAttribute(attributes []types.AttributeType, name string) string {
+	for _, attribute := range attributes {
+		if aws.ToString(attribute.Name) == name {
+			return aws.ToString(attribute.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+const newTestEmail = "ada@lovelace.dev"
+
+func seedProfiledUser(env *testEnv) {
+	env.cognito.AddUser(testEmail, testPassword, map[string]string{"custom:role": "mentor"})
+	_ = env.profiles.Create(context.Background(), &entity.Profile{
+		UserID: "sub-1",
+		User:   entity.User{Email: testEmail, Name: "Ada", Role: "mentor"},
+	})
+}
+
+func TestChangeEmail(t *testing.T) {
+	caller := &entity.IDTokenPayload{Sub: "sub-1", Email: testEmail}
+	body := func(email string) string {
+		return jsonBody(t, entity.ChangeEmailRequest{AccessToken: testAccessToken, NewEmail: email})
+	}
+
+	runCases(t, (*Handlers).ChangeEmail, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedProfiledUser,
+			Caller: caller,
+			Body:   body(newTestEmail),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				user, _ := env.cognito.User(testEmail)
+				if user.PendingEmail != newTestEmail || user.Attributes["email"] != testEmail {
+					t.Fatalf("expected the change to wait for verification: %+v", user)
+				}
+			},
+		},
+		{
+			Name: "email taken",
+			Setup: func(env *testEnv) {
+				seedProfiledUser(env)
+				env.cognito.AddUser(newTestEmail, testPassword, nil)
+			},
+			Caller: caller,
+			Body:   body(newTestEmail),
+			Status: http.StatusConflict,
+			Code:   errorpackage.CodeUserExists,
+		},
+		{Name: "invalid email", Setup: seedProfiledUser, Caller: caller, Body: body("not-an-email"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing access token", Caller: caller, Body: `{"new_email":"ada@lovelace.dev"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seedProfiledUser, Body: body(newTestEmail), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: caller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "rate limited",
+			Setup: func(env *testEnv) {
+				seedProfiledUser(env)
+				env.cognito.Fail("UpdateUserAttributes", &types.LimitExceededException{Message: aws.String("Attempt limit exceeded")})
+			},
+			Caller: caller,
+			Body:   body(newTestEmail),
+			Status: http.StatusTooManyRequests,
+			Code:   errorpackage.CodeRateLimited,
+		},
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	caller := &entity.IDTokenPayload{Sub: "sub-1", Email: testEmail}
+	pending := func(env *testEnv) {
+		seedProfiledUser(env)
+		_, _ = env.cognito.UpdateUserAttributes(context.Background(), &cognitoidentityprovider.UpdateUserAttributesInput{
+			AccessToken:    aws.String(testAccessToken),
+			UserAttributes: []types.AttributeType{{Name: aws.String("email"), Value: aws.String(newTestEmail)}},
+		})
+	}
+	body := func(code string) string {
+		return jsonBody(t, entity.VerifyEmailRequest{AccessToken: testAccessToken, Code: code})
+	}
+
+	runCases(t, (*Handlers).VerifyEmail, []testCase{
+		{
+			Name:   "success",
+			Setup:  pending,
+			Caller: caller,
+			Body:   body(awsapi.ConfirmationCode),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if _, ok := env.cognito.User(newTestEmail); !ok {
+					t.Fatal("the user does not sign in with the new email")
+				}
+				stored, err := env.profiles.Get(context.Background(), "sub-1", "mentor")
+				if err != nil || stored.Email != newTestEmail || stored.Version != 2 {
+					t.Fatalf("profile email was not synced: %+v %v", stored, err)
+				}
+			},
+		},
+		{Name: "wrong code", Setup: pending, Caller: caller, Body: body("000000"), Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCode},
+		{
+			Name: "expired code",
+			Setup: func(env *testEnv) {
+				pending(env)
+				env.cognito.Fail("VerifyUserAttribute", &types.ExpiredCodeException{Message: aws.String("Invalid code provided, please request a code again.")})
+			},
+			Caller: caller,
+			Body:   body(awsapi.ConfirmationCode),
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeExpiredCode,
+		},
+		{Name: "missing code", Setup: pending, Caller: caller, Body: body(""), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: pending, Body: body(awsapi.ConfirmationCode), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{
+			Name: "profile sync failure",
+			Setup: func(env *testEnv) {
+				pending(env)
+				env.profiles.Fail("Update", errors.New("connection reset"))
+			},
+			Caller: caller,
+			Body:   body(awsapi.ConfirmationCode),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// ChangePassword replaces the signed-in caller's password. Unlike ResetPassword it needs the current password
+// rather than an emailed code, and existing sessions stay signed in.
+func (h *Handlers) ChangePassword(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := wrapper.Caller(ctx); err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.ChangePasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	if req.PreviousPassword == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Current password is required")
+	}
+
+	if err := validator.ValidatePassword(req.ProposedPassword); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	_, err := h.Cognito.ChangePassword(ctx, &cognitoidentityprovider.ChangePasswordInput{
+		AccessToken:      &req.AccessToken,
+		PreviousPassword: &req.PreviousPassword,
+		ProposedPassword: &req.ProposedPassword,
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsInvalidPasswordError(err):
+			return errorpackage.CodedError(errorpackage.CodePasswordPolicy, errorpackage.ErrPasswordPolicy.Error())
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many password change attempts, please try again later")
+		case errorpackage.IsInvalidCredentialsError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidCredentials, "Current password is incorrect")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to change password: %w", err))
+		}
+	}
+
+	return accountResponse("Password changed successfully")
+}
+
+func accountResponse(message string) (events.APIGatewayProxyResponse, error) {
+	responseBody, err := json.Marshal(map[string]string{"message": message})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal account response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
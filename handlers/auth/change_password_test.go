@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func TestChangePassword(t *testing.T) {
+	caller := &entity.IDTokenPayload{Sub: "sub-1", Email: testEmail}
+	seed := func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) }
+	body := func(previous, proposed string) string {
+		return jsonBody(t, entity.ChangePasswordRequest{AccessToken: testAccessToken, PreviousPassword: previous, ProposedPassword: proposed})
+	}
+
+	runCases(t, (*Handlers).ChangePassword, []testCase{
+		{
+			Name:   "success",
+			Setup:  seed,
+			Caller: caller,
+			Body:   body(testPassword, "Changed123"),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if user, _ := env.cognito.User(testEmail); user.Password != "Changed123" {
+					t.Fatalf("password was not changed: %q", user.Password)
+				}
+			},
+		},
+		{Name: "wrong current password", Setup: seed, Caller: caller, Body: body("Wrong1234", "Changed123"), Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidCredentials},
+		{Name: "weak new password", Setup: seed, Caller: caller, Body: body(testPassword, "short"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing current password", Setup: seed, Caller: caller, Body: body("", "Changed123"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing access token", Caller: caller, Body: `{"previous_password":"Secret123","proposed_password":"Changed123"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seed, Body: body(testPassword, "Changed123"), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: caller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "rejected by pool policy",
+			Setup: func(env *testEnv) {
+				seed(env)
+				env.cognito.Fail("ChangePassword", &types.InvalidPasswordException{Message: aws.String("Password must have symbol characters")})
+			},
+			Caller: caller,
+			Body:   body(testPassword, "Changed123"),
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodePasswordPolicy,
+		},
+		{
+			Name: "rate limited",
+			Setup: func(env *testEnv) {
+				seed(env)
+				env.cognito.Fail("ChangePassword", &types.LimitExceededException{Message: aws.String("Attempt limit exceeded")})
+			},
+			Caller: caller,
+			Body:   body(testPassword, "Changed123"),
+			Status: http.StatusTooManyRequests,
+			Code:   errorpackage.CodeRateLimited,
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func (h *Handlers) Confirm(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.ConfirmRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	if req.Code == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Confirmation code is required")
+	}
+
+	_, err := h.Cognito.ConfirmSignUp(ctx, &cognitoidentityprovider.ConfirmSignUpInput{
+		ClientId:         &h.Config.CognitoClientID,
+		Username:         &req.Email,
+		ConfirmationCode: &req.Code,
+	})
+	if err != nil {
+		if errorpackage.IsInvalidConfirmationCodeError(err) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidCode, "Invalid confirm code")
+		}
+		if errorpackage.IsExpiredConfirmationCodeError(err) {
+			return errorpackage.CodedError(errorpackage.CodeExpiredCode, "Confirmation code expired")
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to confirm sign-up with Cognito: %w", err))
+	}
+
+	response := map[string]string{
+		"message": "Email confirmed successfully",
+	}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal confirm response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func TestConfirm(t *testing.T) {
+	pending := func(env *testEnv) { signUp(env, testEmail) }
+
+	runCases(t, (*Handlers).Confirm, []testCase{
+		{
+			Name:   "success",
+			Setup:  pending,
+			Body:   `{"email":"` + testEmail + `","code":"` + awsapi.ConfirmationCode + `"}`,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if user, _ := env.cognito.User(testEmail); !user.Confirmed {
+					t.Fatal("expected the user to be confirmed")
+				}
+			},
+		},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "invalid email", Body: `{"email":"ada","code":"1"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing code", Body: `{"email":"` + testEmail + `"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "wrong code", Setup: pending, Body: `{"email":"` + testEmail + `","code":"000000"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCode},
+		{
+			Name: "expired code",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("ConfirmSignUp", &types.ExpiredCodeException{Message: aws.String("Invalid code provided, please request a code again.")})
+			},
+			Body:   `{"email":"` + testEmail + `","code":"123456"}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeExpiredCode,
+		},
+		{Name: "unknown user", Body: `{"email":"` + testEmail + `","code":"123456"}`, Status: http.StatusNotFound, Code: errorpackage.CodeUserNotFound},
+	})
+}
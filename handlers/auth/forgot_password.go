@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func (h *Handlers) ForgotPassword(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.ForgotPasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	_, err := h.Cognito.ForgotPassword(ctx, &cognitoidentityprovider.ForgotPasswordInput{
+		ClientId: &h.Config.CognitoClientID,
+		Username: &req.Email,
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsUserNotFoundError(err):
+			// Answer exactly as for a known user so the endpoint cannot be used to enumerate accounts.
+			log.Printf("Forgot password requested for an unknown user")
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many password reset attempts, please try again later")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to start password reset: %w", err))
+		}
+	}
+
+	response := map[string]string{
+		"message": "If the account exists, a password reset code has been sent",
+	}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal forgot password response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func TestForgotPassword(t *testing.T) {
+	body := `{"email":"` + testEmail + `"}`
+
+	runCases(t, (*Handlers).ForgotPassword, []testCase{
+		{
+			Name:   "known user",
+			Setup:  func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) },
+			Body:   body,
+			Status: http.StatusOK,
+		},
+		{Name: "unknown user answers the same", Body: body, Status: http.StatusOK},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "invalid email", Body: `{"email":"ada"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name: "rate limited",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("ForgotPassword", &types.LimitExceededException{Message: aws.String("Attempt limit exceeded")})
+			},
+			Body:   body,
+			Status: http.StatusTooManyRequests,
+			Code:   errorpackage.CodeRateLimited,
+		},
+		{
+			Name:   "cognito failure",
+			Setup:  func(env *testEnv) { env.cognito.Fail("ForgotPassword", errors.New("connection reset")) },
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
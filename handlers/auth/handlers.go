@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/repository/profile"
+	"mentorship-app-backend/repository/revocation"
+	"strings"
+)
+
+// Handlers serves the Cognito-backed account endpoints. Each Lambda under handlers/auth starts one method;
+// the local server mounts all of them.
+type Handlers struct {
+	Config      config.Config
+	Cognito     awsapi.CognitoAPI
+	Profiles    profile.Repository
+	Revocations revocation.Repository
+	S3          awsapi.S3API
+	BucketName  string
+}
+
+// New builds the handlers for one environment; the bucket is only written by registration's profile picture.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		Config:      cfg,
+		Cognito:     clients.Cognito,
+		Profiles:    profile.NewDynamoRepository(clients.DynamoDB, cfg.UserProfileDDBTableName),
+		Revocations: revocation.NewDynamoRepository(clients.DynamoDB, cfg.RevokedTokensDDBTableName),
+		S3:          clients.S3,
+		BucketName:  cfg.BucketName,
+	}
+}
+
+func extractUserPoolID(cognitoPoolArn string) string {
+	parts := strings.Split(cognitoPoolArn, "/")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/handlers/handlertest"
+	"mentorship-app-backend/repository/profile"
+	"mentorship-app-backend/repository/revocation"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+const (
+	testPoolArn  = "arn:aws:cognito-idp:us-east-1:123456789012:userpool/us-east-1_TestPool"
+	testClientID = "test-client-id"
+	testBucket   = "test-bucket"
+	testEmail    = "ada@example.com"
+	testPassword = "Secret123"
+
+	// MemoryCognito hands out "access-<sub>" access tokens, numbers subs and challenge sessions from 1 and
+	// refresh tokens and their origin_jti from 0, so the first user seeded in a test and the first sign-in it
+	// completes or challenge it opens get these.
+	testAccessToken  = "access-sub-1"
+	testSession      = "session-1"
+	testRefreshToken = "refresh-sub-1-0"
+	testOriginJTI    = "origin-sub-1-0"
+)
+
+type testEnv struct {
+	handlers    *Handlers
+	cognito     *awsapi.MemoryCognito
+	profiles    *profile.MemoryRepository
+	revocations *revocation.MemoryRepository
+	s3          *awsapi.MemoryS3
+}
+
+func newTestEnv() *testEnv {
+	env := &testEnv{
+		cognito:     awsapi.NewMemoryCognito(testClientID),
+		profiles:    profile.NewMemoryRepository(),
+		revocations: revocation.NewMemoryRepository(),
+		s3:          awsapi.NewMemoryS3(),
+	}
+	env.handlers = &Handlers{
+		Config:      config.Config{CognitoClientID: testClientID, CognitoPoolArn: testPoolArn},
+		Cognito:     env.cognito,
+		Profiles:    env.profiles,
+		Revocations: env.revocations,
+		S3:          env.s3,
+		BucketName:  testBucket,
+	}
+	return env
+}
+
+type testCase = handlertest.Case[*testEnv]
+
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], tests []testCase) {
+	t.Helper()
+	handlertest.Run(t, newTestEnv, func(env *testEnv) *Handlers { return env.handlers }, handler, tests)
+}
+
+func jsonBody(t *testing.T, v interface{}) string {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	return string(raw)
+}
+
+func decodeBody(t *testing.T, body string) map[string]interface{} {
+	t.Helper()
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatalf("body is not valid JSON: %v\n%s", err, body)
+	}
+	return decoded
+}
+
+// signUp registers an unconfirmed user, leaving awsapi.ConfirmationCode pending for it.
+func signUp(env *testEnv, email string) {
+	_, _ = env.cognito.SignUp(context.Background(), &cognitoidentityprovider.SignUpInput{
+		ClientId: aws.String(testClientID),
+		Username: aws.String(email),
+		Password: aws.String(testPassword),
+	})
+}
+
+// seedMFAUser adds the test user with an authenticator app enrolled, verified and switched on.
+func seedMFAUser(env *testEnv) {
+	env.cognito.AddUser(testEmail, testPassword, map[string]string{"custom:role": "mentor"})
+	env.cognito.Update(testEmail, func(user *awsapi.CognitoUser) {
+		user.TOTPSecret = "SECRET"
+		user.TOTPVerified = true
+		user.TOTPEnabled = true
+	})
+}
+
+// signIn signs the test user in with their password. That opens testSession if they owe a challenge and
+// issues testRefreshToken otherwise.
+func signIn(env *testEnv) {
+	_, _ = env.cognito.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		AuthParameters: map[string]string{"USERNAME": testEmail, "PASSWORD": testPassword},
+	})
+}
+
+func TestExtractUserPoolID(t *testing.T) {
+	if got := extractUserPoolID(testPoolArn); got != "us-east-1_TestPool" {
+		t.Fatalf("unexpected pool ID %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func (h *Handlers) Login(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.AuthRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	resp, err := h.Cognito.InitiateAuth(ctx, &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow: types.AuthFlowTypeUserPasswordAuth,
+		ClientId: &h.Config.CognitoClientID,
+		AuthParameters: map[string]string{
+			"USERNAME": req.Email,
+			"PASSWORD": req.Password,
+		},
+	})
+	if err != nil {
+		if errorpackage.IsInvalidCredentialsError(err) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidCredentials, "Invalid credentials")
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to authenticate with Cognito provider: %w", err))
+	}
+
+	if resp.ChallengeName != "" {
+		return challengeResponse(req.Email, resp.ChallengeName, resp.Session)
+	}
+
+	return h.signedIn(ctx, req.Email, resp.AuthenticationResult)
+}
+
+// signedIn answers a completed sign-in with the user's tokens and whether their email is verified.
+func (h *Handlers) signedIn(ctx context.Context, email string, result *types.AuthenticationResultType) (events.APIGatewayProxyResponse, error) {
+	if result == nil {
+		return errorpackage.ServerError("Authentication failed: empty authentication result from Cognito")
+	}
+
+	userPoolId := extractUserPoolID(h.Config.CognitoPoolArn)
+
+	userDetails, err := h.Cognito.AdminGetUser(ctx, &cognitoidentityprovider.AdminGetUserInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(email),
+	})
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to retrieve user details: %s", err.Error()))
+	}
+
+	isConfirmed := false
+	for _, attr := range userDetails.UserAttributes {
+		if *attr.Name == "email_verified" && *attr.Value == "true" {
+			isConfirmed = true
+			break
+		}
+	}
+
+	tokens := map[string]interface{}{
+		"email":        email,
+		"isConfirmed":  isConfirmed,
+		"access_token": *result.AccessToken,
+	}
+
+	if result.IdToken != nil {
+		tokens["id_token"] = *result.IdToken
+	}
+	if result.RefreshToken != nil {
+		tokens["refresh_token"] = *result.RefreshToken
+	}
+
+	responseBody, err := json.Marshal(tokens)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal authentication tokens")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
+
+// challengeResponse hands the client the next step of a sign-in, such as SOFTWARE_TOKEN_MFA or
+// NEW_PASSWORD_REQUIRED. The session is only valid for a few minutes.
+func challengeResponse(email string, challenge types.ChallengeNameType, session *string) (events.APIGatewayProxyResponse, error) {
+	responseBody, err := json.Marshal(entity.AuthChallenge{
+		ChallengeName: string(challenge),
+		Session:       aws.ToString(session),
+		Email:         email,
+	})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal authentication challenge")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestLogin(t *testing.T) {
+	seedUser := func(env *testEnv) {
+		env.cognito.AddUser(testEmail, testPassword, map[string]string{"custom:role": "mentee"})
+	}
+	credentials := `{"email":"` + testEmail + `","password":"` + testPassword + `"}`
+
+	runCases(t, (*Handlers).Login, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedUser,
+			Body:   credentials,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				tokens := decodeBody(t, body)
+				if tokens["isConfirmed"] != true || tokens["id_token"] == nil || tokens["refresh_token"] == nil {
+					t.Fatalf("unexpected tokens %v", tokens)
+				}
+			},
+		},
+		{
+			Name:   "mfa challenge",
+			Setup:  seedMFAUser,
+			Body:   credentials,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				challenge := decodeBody(t, body)
+				if challenge["challenge_name"] != "SOFTWARE_TOKEN_MFA" || challenge["session"] != testSession || challenge["email"] != testEmail {
+					t.Fatalf("unexpected challenge %v", challenge)
+				}
+				if _, ok := challenge["id_token"]; ok {
+					t.Fatalf("tokens issued before the challenge was answered: %v", challenge)
+				}
+			},
+		},
+		{
+			Name: "new password required",
+			Setup: func(env *testEnv) {
+				seedUser(env)
+				env.cognito.Update(testEmail, func(user *awsapi.CognitoUser) { user.ForceChangePassword = true })
+			},
+			Body:   credentials,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				if challenge := decodeBody(t, body); challenge["challenge_name"] != "NEW_PASSWORD_REQUIRED" {
+					t.Fatalf("unexpected challenge %v", challenge)
+				}
+			},
+		},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "invalid email", Body: `{"email":"ada","password":"x"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "wrong password", Setup: seedUser, Body: `{"email":"` + testEmail + `","password":"Wrong123"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidCredentials},
+		{Name: "unknown user", Body: credentials, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidCredentials},
+		{
+			Name:   "unconfirmed user",
+			Setup:  func(env *testEnv) { signUp(env, testEmail) },
+			Body:   credentials,
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeUserNotConfirmed,
+		},
+		{
+			Name: "cognito failure",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("InitiateAuth", errors.New("connection reset"))
+			},
+			Body:   credentials,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name: "user lookup failure",
+			Setup: func(env *testEnv) {
+				seedUser(env)
+				env.cognito.Fail("AdminGetUser", errors.New("connection reset"))
+			},
+			Body:   credentials,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/revocation"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// Logout ends the caller's current sign-in. Cognito revokes the refresh token and the access tokens issued from
+// it, but ID tokens are verified locally, so the caller's jti and origin_jti go on the denylist too. Every ID and
+// access token of one sign-in shares the origin_jti, so the whole session stops working at once. The refresh
+// token must belong to the caller's sign-in: anyone holding someone else's token could otherwise sign them out.
+func (h *Handlers) Logout(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.LogoutRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.RefreshToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Refresh token is required")
+	}
+
+	owner, err := h.refreshTokenOwner(ctx, req.RefreshToken)
+	if err != nil {
+		if errorpackage.IsInvalidRefreshTokenError(err) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidRefreshToken, errorpackage.ErrInvalidRefreshToken.Error())
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to resolve the refresh token's session: %w", err))
+	}
+	if owner.Sub != caller.Sub || owner.OriginTokenID != caller.OriginTokenID {
+		return errorpackage.CodedError(errorpackage.CodeForbidden, "The refresh token does not belong to this session")
+	}
+
+	_, err = h.Cognito.RevokeToken(ctx, &cognitoidentityprovider.RevokeTokenInput{
+		ClientId: &h.Config.CognitoClientID,
+		Token:    &req.RefreshToken,
+	})
+	if err != nil {
+		if errorpackage.IsInvalidRefreshTokenError(err) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidRefreshToken, errorpackage.ErrInvalidRefreshToken.Error())
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to revoke refresh token: %w", err))
+	}
+
+	// The caller's own token expires when its exp says, but other tokens of the sign-in can have been issued
+	// later and outlive it, so the origin_jti is kept as long as any token may live, like a user-wide entry.
+	if err = h.Revocations.RevokeTokens(ctx, []string{caller.TokenID}, time.Unix(caller.ExpiresAt, 0)); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to denylist signed-out tokens: %w", err))
+	}
+	if err = h.Revocations.RevokeTokens(ctx, []string{caller.OriginTokenID}, time.Now().Add(revocation.MaxTokenLifetime)); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to denylist signed-out tokens: %w", err))
+	}
+
+	return logoutResponse("Signed out")
+}
+
+// refreshTokenOwner finds out which sign-in issued a refresh token. Refresh tokens are opaque, so the token is
+// exchanged once and the sub and origin_jti read from the ID token Cognito returns; that token belongs to the
+// same sign-in and is revoked with it.
+func (h *Handlers) refreshTokenOwner(ctx context.Context, refreshToken string) (*entity.IDTokenPayload, error) {
+	resp, err := h.Cognito.InitiateAuth(ctx, &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeRefreshTokenAuth,
+		ClientId:       &h.Config.CognitoClientID,
+		AuthParameters: map[string]string{"REFRESH_TOKEN": refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.AuthenticationResult == nil || resp.AuthenticationResult.IdToken == nil {
+		return nil, fmt.Errorf("empty authentication result from Cognito")
+	}
+	return validator.DecodeIDToken(*resp.AuthenticationResult.IdToken)
+}
+
+// LogoutAll signs the caller out on every device, for instance after a device was lost. GlobalSignOut revokes all
+// of the user's refresh and access tokens, and a user-wide denylist entry rejects every ID token issued so far.
+// The access token must be the caller's own, so both halves hit the same account and nobody holding another
+// user's access token can sign them out.
+func (h *Handlers) LogoutAll(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.LogoutAllRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	user, err := h.Cognito.GetUser(ctx, &cognitoidentityprovider.GetUserInput{AccessToken: &req.AccessToken})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to resolve the access token's user: %w", err))
+	}
+	if userAttribute(user.UserAttributes, "sub") != caller.Sub {
+		return errorpackage.CodedError(errorpackage.CodeForbidden, "The access token does not belong to the caller")
+	}
+
+	_, err = h.Cognito.GlobalSignOut(ctx, &cognitoidentityprovider.GlobalSignOutInput{AccessToken: &req.AccessToken})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to sign out globally: %w", err))
+	}
+
+	if err = h.Revocations.RevokeUser(ctx, caller.Sub); err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to denylist signed-out tokens: %w", err))
+	}
+
+	return logoutResponse("Signed out on all devices")
+}
+
+func logoutResponse(message string) (events.APIGatewayProxyResponse, error) {
+	responseBody, err := json.Marshal(map[string]string{"message": message})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal logout response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/repository/revocation"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// signedInCaller is the ID token of the sign-in that issued testRefreshToken a minute ago.
+func signedInCaller() *entity.IDTokenPayload {
+	now := time.Now()
+	return &entity.IDTokenPayload{
+		Sub:           "sub-1",
+		Email:         testEmail,
+		TokenID:       "id-jti",
+		OriginTokenID: testOriginJTI,
+		IssuedAt:      now.Add(-time.Minute).Unix(),
+		ExpiresAt:     now.Add(time.Hour).Unix(),
+	}
+}
+
+func seedSignedIn(env *testEnv) {
+	env.cognito.AddUser(testEmail, testPassword, nil)
+	signIn(env)
+}
+
+func refreshWorks(env *testEnv, refreshToken string) bool {
+	_, err := env.cognito.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeRefreshTokenAuth,
+		AuthParameters: map[string]string{"REFRESH_TOKEN": refreshToken},
+	})
+	return err == nil
+}
+
+// revoked reports whether the denylist rejects the caller's token after applying change to a copy of it.
+func revoked(t *testing.T, env *testEnv, change func(token *entity.IDTokenPayload)) bool {
+	t.Helper()
+	token := signedInCaller()
+	change(token)
+	revoked, err := env.revocations.Revoked(context.Background(), token)
+	if err != nil {
+		t.Fatalf("failed to check revocation: %v", err)
+	}
+	return revoked
+}
+
+func TestLogout(t *testing.T) {
+	body := `{"refresh_token":"` + testRefreshToken + `"}`
+
+	runCases(t, (*Handlers).Logout, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedSignedIn,
+			Caller: signedInCaller(),
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if refreshWorks(env, testRefreshToken) {
+					t.Fatal("refresh token still works after logout")
+				}
+				if !revoked(t, env, func(*entity.IDTokenPayload) {}) {
+					t.Fatal("the caller's ID token is not denylisted")
+				}
+				// An access token of the same sign-in has its own jti but shares the origin_jti.
+				if !revoked(t, env, func(token *entity.IDTokenPayload) { token.TokenID = "access-jti" }) {
+					t.Fatal("the access token of the signed-out session is not denylisted")
+				}
+				if revoked(t, env, func(token *entity.IDTokenPayload) { token.TokenID, token.OriginTokenID = "other-jti", "other-origin" }) {
+					t.Fatal("logout revoked another session")
+				}
+				// Tokens of the session issued after the caller's can outlive it, so the origin_jti stays longer.
+				origin, ok := env.revocations.Entry(testOriginJTI)
+				if !ok || time.Unix(origin.ExpiresAt, 0).Before(time.Now().Add(revocation.MaxTokenLifetime-time.Minute)) {
+					t.Fatalf("origin_jti is not kept for the longest token lifetime: %+v", origin)
+				}
+			},
+		},
+		{
+			Name: "refresh token of another session",
+			Setup: func(env *testEnv) {
+				seedSignedIn(env)
+				signIn(env)
+			},
+			Caller: signedInCaller(),
+			Body:   `{"refresh_token":"refresh-sub-1-1"}`,
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeForbidden,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if !refreshWorks(env, "refresh-sub-1-1") {
+					t.Fatal("the other session's refresh token was revoked")
+				}
+				if revoked(t, env, func(*entity.IDTokenPayload) {}) {
+					t.Fatal("the caller's token was denylisted by a rejected logout")
+				}
+			},
+		},
+		{
+			Name: "refresh token of another user",
+			Setup: func(env *testEnv) {
+				seedSignedIn(env)
+				env.cognito.AddUser("grace@example.com", testPassword, nil)
+				_, _ = env.cognito.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+					AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+					AuthParameters: map[string]string{"USERNAME": "grace@example.com", "PASSWORD": testPassword},
+				})
+			},
+			Caller: signedInCaller(),
+			Body:   `{"refresh_token":"refresh-sub-2-1"}`,
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeForbidden,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if !refreshWorks(env, "refresh-sub-2-1") {
+					t.Fatal("another user's refresh token was revoked")
+				}
+			},
+		},
+		{Name: "unknown refresh token", Setup: seedSignedIn, Caller: signedInCaller(), Body: `{"refresh_token":"forged"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidRefreshToken},
+		{Name: "missing refresh token", Caller: signedInCaller(), Body: `{}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seedSignedIn, Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: signedInCaller(), Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "denylist failure",
+			Setup: func(env *testEnv) {
+				seedSignedIn(env)
+				env.revocations.Fail("RevokeTokens", errors.New("connection reset"))
+			},
+			Caller: signedInCaller(),
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
+
+func TestLogoutAll(t *testing.T) {
+	body := `{"access_token":"` + testAccessToken + `"}`
+	seedTwoDevices := func(env *testEnv) {
+		seedSignedIn(env)
+		signIn(env)
+	}
+
+	runCases(t, (*Handlers).LogoutAll, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedTwoDevices,
+			Caller: signedInCaller(),
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if refreshWorks(env, testRefreshToken) || refreshWorks(env, "refresh-sub-1-1") {
+					t.Fatal("a refresh token still works after signing out everywhere")
+				}
+				if !revoked(t, env, func(token *entity.IDTokenPayload) { token.TokenID, token.OriginTokenID = "laptop-jti", "laptop-origin" }) {
+					t.Fatal("tokens issued before the sign-out are not denylisted")
+				}
+				if revoked(t, env, func(token *entity.IDTokenPayload) { token.IssuedAt = time.Now().Add(time.Minute).Unix() }) {
+					t.Fatal("a token issued after the sign-out is denylisted")
+				}
+				if revoked(t, env, func(token *entity.IDTokenPayload) { token.Sub = "sub-2" }) {
+					t.Fatal("signing out everywhere revoked another user's tokens")
+				}
+			},
+		},
+		{
+			Name: "access token of another user",
+			Setup: func(env *testEnv) {
+				seedSignedIn(env)
+				env.cognito.AddUser("grace@example.com", testPassword, nil)
+				_, _ = env.cognito.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+					AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+					AuthParameters: map[string]string{"USERNAME": "grace@example.com", "PASSWORD": testPassword},
+				})
+			},
+			Caller: signedInCaller(),
+			Body:   `{"access_token":"access-sub-2"}`,
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeForbidden,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if !refreshWorks(env, "refresh-sub-2-1") {
+					t.Fatal("another user was signed out everywhere")
+				}
+				if !refreshWorks(env, testRefreshToken) || revoked(t, env, func(*entity.IDTokenPayload) {}) {
+					t.Fatal("the caller was signed out although the request was refused")
+				}
+			},
+		},
+		{Name: "invalid access token", Setup: seedSignedIn, Caller: signedInCaller(), Body: `{"access_token":"forged"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing access token", Caller: signedInCaller(), Body: `{}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seedSignedIn, Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: signedInCaller(), Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "denylist failure",
+			Setup: func(env *testEnv) {
+				seedSignedIn(env)
+				env.revocations.Fail("RevokeUser", errors.New("connection reset"))
+			},
+			Caller: signedInCaller(),
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) Me(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if validator.ValidateEmail(payload.Email) != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Invalid email format")
+	}
+
+	profileType := payload.CustomRole
+	if profileType == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "ProfileType (custom:role) is missing in the token")
+	}
+
+	userDetails, err := h.Profiles.Get(ctx, profile.UserID(payload), profileType)
+	if err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeProfileNotFound, "User profile not found")
+		}
+		return errorpackage.ServerError(err.Error())
+	}
+
+	responseBody := map[string]interface{}{
+		"email":        payload.Email,
+		"profile_type": profileType,
+		"is_verified":  payload.EmailVerified,
+		"details":      userDetails,
+	}
+
+	responseJSON, err := json.Marshal(responseBody)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal user details")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersGet(""),
+		Body:       string(responseJSON),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestMe(t *testing.T) {
+	caller := &entity.IDTokenPayload{Sub: "sub-1", Email: testEmail, CustomRole: "mentee", EmailVerified: true}
+	seedProfile := func(env *testEnv) {
+		_ = env.profiles.Create(context.Background(), &entity.Profile{UserID: "sub-1", User: entity.User{Email: testEmail, Name: "Ada", Role: "mentee"}})
+	}
+
+	runCases(t, (*Handlers).Me, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedProfile,
+			Caller: caller,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				me := decodeBody(t, body)
+				details, _ := me["details"].(map[string]interface{})
+				if me["profile_type"] != "mentee" || me["is_verified"] != true || details["name"] != "Ada" {
+					t.Fatalf("unexpected body %v", me)
+				}
+			},
+		},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "invalid email", Caller: &entity.IDTokenPayload{Email: "ada", CustomRole: "mentee"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing role", Caller: &entity.IDTokenPayload{Email: testEmail}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing profile", Caller: caller, Status: http.StatusNotFound, Code: errorpackage.CodeProfileNotFound},
+		{
+			Name:   "repository failure",
+			Setup:  func(env *testEnv) { env.profiles.Fail("Get", errors.New("throughput exceeded")) },
+			Caller: caller,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
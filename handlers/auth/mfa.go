@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// defaultTOTPIssuer labels the account in authenticator apps when no app name is configured.
+const defaultTOTPIssuer = "mentorship"
+
+// MFAAssociate starts authenticator app enrollment. The returned secret, or the otpauth URI rendered as a QR
+// code, is added to the app, and MFAVerify then proves the app produces valid codes.
+func (h *Handlers) MFAAssociate(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	caller, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.MFAAssociateRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	resp, err := h.Cognito.AssociateSoftwareToken(ctx, &cognitoidentityprovider.AssociateSoftwareTokenInput{
+		AccessToken: &req.AccessToken,
+	})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to associate software token: %w", err))
+	}
+
+	secret := aws.ToString(resp.SecretCode)
+	return mfaResponse(entity.MFAAssociateResponse{
+		SecretCode: secret,
+		OTPAuthURI: otpauthURI(h.totpIssuer(), caller.Email, secret),
+	})
+}
+
+// MFAVerify checks a code from the newly enrolled authenticator app. MFA stays off until MFAPreference enables it.
+func (h *Handlers) MFAVerify(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := wrapper.Caller(ctx); err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.MFAVerifyRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	if err := validator.ValidateTOTPCode(req.Code); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	input := &cognitoidentityprovider.VerifySoftwareTokenInput{
+		AccessToken: &req.AccessToken,
+		UserCode:    &req.Code,
+	}
+	if req.DeviceName != "" {
+		input.FriendlyDeviceName = &req.DeviceName
+	}
+
+	resp, err := h.Cognito.VerifySoftwareToken(ctx, input)
+	if err != nil {
+		switch {
+		case errorpackage.IsCodeMismatchError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidCode, errorpackage.ErrCodeMismatch.Error())
+		case errorpackage.IsMFANotEnrolledError(err):
+			return errorpackage.CodedError(errorpackage.CodeMFANotEnrolled, "Start authenticator enrollment before verifying a code")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to verify software token: %w", err))
+		}
+	}
+
+	if resp.Status != types.VerifySoftwareTokenResponseTypeSuccess {
+		return errorpackage.CodedError(errorpackage.CodeInvalidCode, errorpackage.ErrCodeMismatch.Error())
+	}
+
+	return mfaResponse(map[string]string{
+		"message": "Authenticator app verified, enable MFA to require it at sign-in",
+	})
+}
+
+// MFAPreference switches TOTP MFA on or off for the caller. Enabling it requires a verified authenticator app.
+func (h *Handlers) MFAPreference(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if _, err := wrapper.Caller(ctx); err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.MFAPreferenceRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.AccessToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Access token is required")
+	}
+
+	_, err := h.Cognito.SetUserMFAPreference(ctx, &cognitoidentityprovider.SetUserMFAPreferenceInput{
+		AccessToken: &req.AccessToken,
+		SoftwareTokenMfaSettings: &types.SoftwareTokenMfaSettingsType{
+			Enabled:      req.Enabled,
+			PreferredMfa: req.Enabled && req.Preferred,
+		},
+	})
+	if err != nil {
+		if errorpackage.IsMFANotEnrolledError(err) {
+			return errorpackage.CodedError(errorpackage.CodeMFANotEnrolled, errorpackage.ErrMFANotEnrolled.Error())
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to set MFA preference: %w", err))
+	}
+
+	return mfaResponse(map[string]bool{
+		"mfa_enabled": req.Enabled,
+		"preferred":   req.Enabled && req.Preferred,
+	})
+}
+
+func (h *Handlers) totpIssuer() string {
+	if h.Config.AppName != "" {
+		return h.Config.AppName
+	}
+	return defaultTOTPIssuer
+}
+
+// otpauthURI follows the Key Uri Format authenticator apps read from QR codes.
+func otpauthURI(issuer, account, secret string) string {
+	query := url.Values{"secret": {secret}, "issuer": {issuer}}
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
+
+func mfaResponse(body interface{}) (events.APIGatewayProxyResponse, error) {
+	responseBody, err := json.Marshal(body)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal MFA response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
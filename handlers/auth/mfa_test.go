@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+var mfaCaller = &entity.IDTokenPayload{Sub: "sub-1", Email: testEmail}
+
+func seedTOTPUser(verified bool) func(env *testEnv) {
+	return func(env *testEnv) {
+		env.cognito.AddUser(testEmail, testPassword, nil)
+		env.cognito.Update(testEmail, func(user *awsapi.CognitoUser) {
+			user.TOTPSecret = "SECRET"
+			user.TOTPVerified = verified
+		})
+	}
+}
+
+func TestMFAAssociate(t *testing.T) {
+	seedUser := func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) }
+	body := `{"access_token":"` + testAccessToken + `"}`
+
+	runCases(t, (*Handlers).MFAAssociate, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedUser,
+			Caller: mfaCaller,
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				response := decodeBody(t, body)
+				user, _ := env.cognito.User(testEmail)
+				if response["secret_code"] != user.TOTPSecret || user.TOTPSecret == "" {
+					t.Fatalf("unexpected secret %v for %+v", response, user)
+				}
+				uri, _ := response["otpauth_uri"].(string)
+				if !strings.HasPrefix(uri, "otpauth://totp/mentorship:ada@example.com?") || !strings.Contains(uri, "secret="+user.TOTPSecret) {
+					t.Fatalf("unexpected otpauth URI %q", uri)
+				}
+			},
+		},
+		{Name: "no caller", Setup: seedUser, Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing access token", Setup: seedUser, Caller: mfaCaller, Body: `{}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid access token", Setup: seedUser, Caller: mfaCaller, Body: `{"access_token":"forged"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: mfaCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "cognito failure",
+			Setup: func(env *testEnv) {
+				seedUser(env)
+				env.cognito.Fail("AssociateSoftwareToken", errors.New("connection reset"))
+			},
+			Caller: mfaCaller,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
+
+func TestMFAVerify(t *testing.T) {
+	verify := func(code string) string {
+		return jsonBody(t, entity.MFAVerifyRequest{AccessToken: testAccessToken, Code: code, DeviceName: "phone"})
+	}
+
+	runCases(t, (*Handlers).MFAVerify, []testCase{
+		{
+			Name:   "success",
+			Setup:  seedTOTPUser(false),
+			Caller: mfaCaller,
+			Body:   verify(awsapi.TOTPCode),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if user, _ := env.cognito.User(testEmail); !user.TOTPVerified || user.TOTPEnabled {
+					t.Fatalf("expected a verified but not yet enabled authenticator, got %+v", user)
+				}
+			},
+		},
+		{Name: "wrong code", Setup: seedTOTPUser(false), Caller: mfaCaller, Body: verify("000000"), Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCode},
+		{Name: "malformed code", Setup: seedTOTPUser(false), Caller: mfaCaller, Body: verify("1234567"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "not associated",
+			Setup:  func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) },
+			Caller: mfaCaller,
+			Body:   verify(awsapi.TOTPCode),
+			Status: http.StatusConflict,
+			Code:   errorpackage.CodeMFANotEnrolled,
+		},
+		{Name: "missing access token", Caller: mfaCaller, Body: `{"code":"123456"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seedTOTPUser(false), Body: verify(awsapi.TOTPCode), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: mfaCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+	})
+}
+
+func TestMFAPreference(t *testing.T) {
+	preference := func(enabled bool) string {
+		return jsonBody(t, entity.MFAPreferenceRequest{AccessToken: testAccessToken, Enabled: enabled, Preferred: true})
+	}
+
+	runCases(t, (*Handlers).MFAPreference, []testCase{
+		{
+			Name:   "enable",
+			Setup:  seedTOTPUser(true),
+			Caller: mfaCaller,
+			Body:   preference(true),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if response := decodeBody(t, body); response["mfa_enabled"] != true || response["preferred"] != true {
+					t.Fatalf("unexpected response %v", response)
+				}
+				if user, _ := env.cognito.User(testEmail); !user.TOTPEnabled {
+					t.Fatalf("MFA not enabled: %+v", user)
+				}
+			},
+		},
+		{
+			Name: "disable",
+			Setup: func(env *testEnv) {
+				seedMFAUser(env)
+			},
+			Caller: mfaCaller,
+			Body:   preference(false),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				if response := decodeBody(t, body); response["mfa_enabled"] != false || response["preferred"] != false {
+					t.Fatalf("unexpected response %v", response)
+				}
+				if user, _ := env.cognito.User(testEmail); user.TOTPEnabled {
+					t.Fatalf("MFA still enabled: %+v", user)
+				}
+			},
+		},
+		{Name: "enable before verifying", Setup: seedTOTPUser(false), Caller: mfaCaller, Body: preference(true), Status: http.StatusConflict, Code: errorpackage.CodeMFANotEnrolled},
+		{Name: "missing access token", Caller: mfaCaller, Body: `{"enabled":true}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no caller", Setup: seedTOTPUser(true), Body: preference(true), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: mfaCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+	})
+}
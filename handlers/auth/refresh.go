@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func (h *Handlers) Refresh(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.RefreshRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if req.RefreshToken == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Refresh token is required")
+	}
+
+	resp, err := h.Cognito.InitiateAuth(ctx, &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow: types.AuthFlowTypeRefreshTokenAuth,
+		ClientId: &h.Config.CognitoClientID,
+		AuthParameters: map[string]string{
+			"REFRESH_TOKEN": req.RefreshToken,
+		},
+	})
+	if err != nil {
+		if errorpackage.IsInvalidRefreshTokenError(err) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidRefreshToken, errorpackage.ErrInvalidRefreshToken.Error())
+		}
+		return errorpackage.FromError(fmt.Errorf("failed to refresh tokens with Cognito provider: %w", err))
+	}
+
+	if resp.AuthenticationResult == nil || resp.AuthenticationResult.IdToken == nil {
+		return errorpackage.ServerError("Token refresh failed: empty authentication result from Cognito")
+	}
+
+	payload, err := validator.DecodeAndValidateIDToken(*resp.AuthenticationResult.IdToken)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to decode refreshed ID token: %s", err.Error()))
+	}
+
+	// Cognito only issues a new refresh token when rotation is enabled on the
+	// app client, otherwise the caller keeps using the one it already has.
+	refreshToken := req.RefreshToken
+	if resp.AuthenticationResult.RefreshToken != nil {
+		refreshToken = *resp.AuthenticationResult.RefreshToken
+	}
+
+	tokens := map[string]interface{}{
+		"email":         payload.Email,
+		"isConfirmed":   payload.EmailVerified,
+		"access_token":  *resp.AuthenticationResult.AccessToken,
+		"id_token":      *resp.AuthenticationResult.IdToken,
+		"refresh_token": refreshToken,
+	}
+
+	responseBody, err := json.Marshal(tokens)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal authentication tokens")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func TestRefresh(t *testing.T) {
+	var refreshToken string
+	loggedIn := func(env *testEnv) {
+		env.cognito.AddUser(testEmail, testPassword, map[string]string{"custom:role": "mentee"})
+		output, err := env.cognito.InitiateAuth(context.Background(), &cognitoidentityprovider.InitiateAuthInput{
+			AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+			AuthParameters: map[string]string{"USERNAME": testEmail, "PASSWORD": testPassword},
+		})
+		if err != nil {
+			panic(err)
+		}
+		refreshToken = *output.AuthenticationResult.RefreshToken
+	}
+
+	runCases(t, (*Handlers).Refresh, []testCase{
+		{
+			Name:   "success keeps the refresh token",
+			Setup:  loggedIn,
+			Body:   `{"refresh_token":"refresh-sub-1-0"}`,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				tokens := decodeBody(t, body)
+				if tokens["email"] != testEmail || tokens["refresh_token"] != refreshToken || tokens["id_token"] == nil {
+					t.Fatalf("unexpected tokens %v", tokens)
+				}
+			},
+		},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing token", Body: `{}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "revoked token", Body: `{"refresh_token":"refresh-unknown"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidRefreshToken},
+		{
+			Name:   "cognito failure",
+			Setup:  func(env *testEnv) { env.cognito.Fail("InitiateAuth", errors.New("connection reset")) },
+			Body:   `{"refresh_token":"refresh-sub-1-0"}`,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) Register(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.AuthRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateFields(req.Name, req.Email, req.Password, req.Role); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	signUpOutput, err := h.Cognito.SignUp(ctx, &cognitoidentityprovider.SignUpInput{
+		ClientId: &h.Config.CognitoClientID,
+		Username: &req.Email,
+		Password: &req.Password,
+		UserAttributes: []types.AttributeType{
+			{Name: aws.String("email"), Value: &req.Email},
+			{Name: aws.String("name"), Value: &req.Name},
+		},
+	})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to register user: %w", err))
+	}
+
+	_, err = h.Cognito.AdminUpdateUserAttributes(ctx, &cognitoidentityprovider.AdminUpdateUserAttributesInput{
+		UserPoolId: aws.String(extractUserPoolID(h.Config.CognitoPoolArn)),
+		Username:   &req.Email,
+		UserAttributes: []types.AttributeType{
+			{Name: aws.String("custom:role"), Value: &req.Role},
+		},
+	})
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to update user role: %s", err.Error()))
+	}
+
+	pictureKey, err := h.uploadProfilePicture(ctx, aws.ToString(signUpOutput.UserSub), req.FileName, req.ProfilePicture, request.Headers["x-file-content-type"])
+	if err != nil {
+		_, delErr := h.Cognito.AdminDeleteUser(ctx, &cognitoidentityprovider.AdminDeleteUserInput{
+			UserPoolId: aws.String(extractUserPoolID(h.Config.CognitoPoolArn)),
+			Username:   &req.Email,
+		})
+		if delErr != nil {
+			return errorpackage.ServerError(fmt.Sprintf("Failed to upload profile picture: %s; rolling back the Cognito user also failed: %s", err.Error(), delErr.Error()))
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to upload profile picture: %s", err.Error()))
+	}
+
+	err = h.Profiles.Create(ctx, &entity.Profile{
+		UserID: aws.ToString(signUpOutput.UserSub),
+		User: entity.User{
+			Email:          req.Email,
+			Name:           req.Name,
+			ProfilePicture: pictureKey,
+			Role:           req.Role,
+		},
+	})
+	if err != nil {
+		_, delErr := h.Cognito.AdminDeleteUser(ctx, &cognitoidentityprovider.AdminDeleteUserInput{
+			UserPoolId: aws.String(extractUserPoolID(h.Config.CognitoPoolArn)),
+			Username:   &req.Email,
+		})
+		if delErr != nil {
+			return errorpackage.ServerError(fmt.Sprintf("Failed to save user profile: %s; rolling back the Cognito user also failed: %s", err.Error(), delErr.Error()))
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to save user profile: %s", err.Error()))
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusCreated,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       `{"message":"User registered and profile created successfully"}`,
+	}, nil
+}
+
+// uploadProfilePicture writes the picture straight to the new user's profile folder. The caller has no ID token
+// yet, so the owner comes from the sub Cognito just assigned rather than from the protected upload endpoint. The
+// profile keeps the object key; the bucket is private, so clients read the picture through download-url, which
+// lets any signed-in user read a profile folder.
+func (h *Handlers) uploadProfilePicture(ctx context.Context, sub, fileName, base64Image, contentType string) (string, error) {
+	key, err := ownership.ProfilePictureKey(sub, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	fileData, err := base64.StdEncoding.DecodeString(base64Image)
+	if err != nil {
+		return "", fmt.Errorf("invalid file data: %w", err)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_, err = h.S3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(h.BucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(fileData),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload profile picture: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestRegister(t *testing.T) {
+	valid := `{"name":"Ada","email":"` + testEmail + `","password":"` + testPassword + `","role":"mentor","file_name":"avatar.png","profile_picture":"aGk="}`
+
+	expectRolledBack := func(t *testing.T, env *testEnv, _ string) {
+		if _, ok := env.cognito.User(testEmail); ok {
+			t.Fatal("expected the Cognito user to be deleted after a failed registration")
+		}
+	}
+
+	runCases(t, (*Handlers).Register, []testCase{
+		{
+			Name:   "success",
+			Body:   valid,
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				user, ok := env.cognito.User(testEmail)
+				if !ok || user.Attributes["custom:role"] != "mentor" {
+					t.Fatalf("unexpected Cognito user %+v", user)
+				}
+				if object, ok := env.s3.Object(testBucket, "users/"+user.Sub+"/profile/avatar.png"); !ok || string(object.Body) != "hi" {
+					t.Fatalf("profile picture was not uploaded: %+v", object)
+				}
+				saved, err := env.profiles.Get(context.Background(), user.Sub, "mentor")
+				if err != nil || saved.Email != testEmail || saved.Name != "Ada" || saved.ProfilePicture != "users/"+user.Sub+"/profile/avatar.png" {
+					t.Fatalf("unexpected profile %+v %v", saved, err)
+				}
+			},
+		},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name:   "weak password",
+			Body:   `{"name":"Ada","email":"` + testEmail + `","password":"short","role":"mentor"}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "unknown role",
+			Body:   `{"name":"Ada","email":"` + testEmail + `","password":"` + testPassword + `","role":"admin"}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "existing user",
+			Setup:  func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) },
+			Body:   valid,
+			Status: http.StatusConflict,
+			Code:   errorpackage.CodeUserExists,
+		},
+		{
+			Name:   "invalid picture is rolled back",
+			Body:   `{"name":"Ada","email":"` + testEmail + `","password":"` + testPassword + `","role":"mentor","file_name":"avatar.png","profile_picture":"%%%"}`,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectRolledBack,
+		},
+		{
+			Name:   "upload failure is rolled back",
+			Setup:  func(env *testEnv) { env.s3.Fail("PutObject", errors.New("slow down")) },
+			Body:   valid,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectRolledBack,
+		},
+		{
+			Name:   "profile failure is rolled back",
+			Setup:  func(env *testEnv) { env.profiles.Fail("Create", errors.New("throughput exceeded")) },
+			Body:   valid,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+			Check:  expectRolledBack,
+		},
+		{
+			Name: "failed rollback",
+			Setup: func(env *testEnv) {
+				env.profiles.Fail("Create", errors.New("throughput exceeded"))
+				env.cognito.Fail("AdminDeleteUser", errors.New("connection reset"))
+			},
+			Body:   valid,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "role update failure",
+			Setup:  func(env *testEnv) { env.cognito.Fail("AdminUpdateUserAttributes", errors.New("connection reset")) },
+			Body:   valid,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func (h *Handlers) Resend(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.ResendRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	_, err := h.Cognito.ResendConfirmationCode(ctx, &cognitoidentityprovider.ResendConfirmationCodeInput{
+		ClientId: &h.Config.CognitoClientID,
+		Username: &req.Email,
+	})
+	if err != nil {
+		return errorpackage.FromError(fmt.Errorf("failed to resend confirmation code: %w", err))
+	}
+
+	response := map[string]string{
+		"message": "Confirmation code resent successfully",
+	}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal resend response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestResend(t *testing.T) {
+	body := `{"email":"` + testEmail + `"}`
+
+	runCases(t, (*Handlers).Resend, []testCase{
+		{Name: "success", Setup: func(env *testEnv) { signUp(env, testEmail) }, Body: body, Status: http.StatusOK},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "invalid email", Body: `{"email":"ada"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "already confirmed",
+			Setup:  func(env *testEnv) { env.cognito.AddUser(testEmail, testPassword, nil) },
+			Body:   body,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{Name: "unknown user", Body: body, Status: http.StatusNotFound, Code: errorpackage.CodeUserNotFound},
+		{
+			Name:   "cognito failure",
+			Setup:  func(env *testEnv) { env.cognito.Fail("ResendConfirmationCode", errors.New("connection reset")) },
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
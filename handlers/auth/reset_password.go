@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func (h *Handlers) ResetPassword(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.ResetPasswordRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	if req.Code == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Reset code is required")
+	}
+
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	_, err := h.Cognito.ConfirmForgotPassword(ctx, &cognitoidentityprovider.ConfirmForgotPasswordInput{
+		ClientId:         &h.Config.CognitoClientID,
+		Username:         &req.Email,
+		ConfirmationCode: &req.Code,
+		Password:         &req.NewPassword,
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsCodeMismatchError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidCode, errorpackage.ErrCodeMismatch.Error())
+		case errorpackage.IsExpiredConfirmationCodeError(err):
+			return errorpackage.CodedError(errorpackage.CodeExpiredCode, errorpackage.ErrExpiredCode.Error())
+		case errorpackage.IsInvalidPasswordError(err):
+			return errorpackage.CodedError(errorpackage.CodePasswordPolicy, errorpackage.ErrPasswordPolicy.Error())
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many password reset attempts, please try again later")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to reset password: %w", err))
+		}
+	}
+
+	response := map[string]string{
+		"message": "Password reset successfully",
+	}
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal reset password response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseBody),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+func TestResetPassword(t *testing.T) {
+	requested := func(env *testEnv) {
+		env.cognito.AddUser(testEmail, testPassword, nil)
+		_, _ = env.handlers.ForgotPassword(context.Background(), events.APIGatewayProxyRequest{Body: `{"email":"` + testEmail + `"}`})
+	}
+	withCode := func(code string) string {
+		return `{"email":"` + testEmail + `","code":"` + code + `","new_password":"Newer456"}`
+	}
+
+	runCases(t, (*Handlers).ResetPassword, []testCase{
+		{
+			Name:   "success",
+			Setup:  requested,
+			Body:   withCode(awsapi.ConfirmationCode),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				if user, _ := env.cognito.User(testEmail); user.Password != "Newer456" {
+					t.Fatalf("password was not changed: %q", user.Password)
+				}
+			},
+		},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing code", Body: withCode(""), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "weak password",
+			Body:   `{"email":"` + testEmail + `","code":"123456","new_password":"short"}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{Name: "wrong code", Setup: requested, Body: withCode("000000"), Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCode},
+		{
+			Name: "expired code",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("ConfirmForgotPassword", &types.ExpiredCodeException{Message: aws.String("Invalid code provided, please request a code again.")})
+			},
+			Body:   withCode(awsapi.ConfirmationCode),
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeExpiredCode,
+		},
+		{
+			Name: "password policy",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("ConfirmForgotPassword", &types.InvalidPasswordException{Message: aws.String("Password does not conform to policy")})
+			},
+			Body:   withCode(awsapi.ConfirmationCode),
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodePasswordPolicy,
+		},
+		{
+			Name: "rate limited",
+			Setup: func(env *testEnv) {
+				env.cognito.Fail("ConfirmForgotPassword", &types.LimitExceededException{Message: aws.String("Attempt limit exceeded")})
+			},
+			Body:   withCode(awsapi.ConfirmationCode),
+			Status: http.StatusTooManyRequests,
+			Code:   errorpackage.CodeRateLimited,
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// RespondChallenge answers a challenge returned by Login. It either completes the sign-in with tokens or, when
+// Cognito asks for yet another step, returns the next challenge in the same shape as Login.
+func (h *Handlers) RespondChallenge(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	var req entity.RespondChallengeRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Email validation failed")
+	}
+
+	if req.Session == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Session is required")
+	}
+
+	challenge := types.ChallengeNameType(req.ChallengeName)
+	responses := map[string]string{"USERNAME": req.Email}
+	switch challenge {
+	case types.ChallengeNameTypeSoftwareTokenMfa:
+		if err := validator.ValidateTOTPCode(req.Code); err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+		}
+		responses["SOFTWARE_TOKEN_MFA_CODE"] = req.Code
+	case types.ChallengeNameTypeNewPasswordRequired:
+		if err := validator.ValidatePassword(req.NewPassword); err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+		}
+		responses["NEW_PASSWORD"] = req.NewPassword
+	default:
+		return errorpackage.ClientError(http.StatusBadRequest, fmt.Sprintf("Unsupported challenge %q", req.ChallengeName))
+	}
+
+	resp, err := h.Cognito.RespondToAuthChallenge(ctx, &cognitoidentityprovider.RespondToAuthChallengeInput{
+		ClientId:           &h.Config.CognitoClientID,
+		ChallengeName:      challenge,
+		Session:            &req.Session,
+		ChallengeResponses: responses,
+	})
+	if err != nil {
+		switch {
+		case errorpackage.IsInvalidSessionError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidSession, errorpackage.ErrInvalidSession.Error())
+		case errorpackage.IsCodeMismatchError(err):
+			return errorpackage.CodedError(errorpackage.CodeInvalidCode, errorpackage.ErrCodeMismatch.Error())
+		case errorpackage.IsInvalidPasswordError(err):
+			return errorpackage.CodedError(errorpackage.CodePasswordPolicy, errorpackage.ErrPasswordPolicy.Error())
+		case errorpackage.IsLimitExceededError(err):
+			return errorpackage.CodedError(errorpackage.CodeRateLimited, "Too many attempts, please try again later")
+		default:
+			return errorpackage.FromError(fmt.Errorf("failed to respond to %s challenge: %w", challenge, err))
+		}
+	}
+
+	if resp.ChallengeName != "" {
+		return challengeResponse(req.Email, resp.ChallengeName, resp.Session)
+	}
+
+	return h.signedIn(ctx, req.Email, resp.AuthenticationResult)
+}
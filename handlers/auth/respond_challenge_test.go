@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestRespondChallenge(t *testing.T) {
+	mfaChallenge := func(env *testEnv) {
+		seedMFAUser(env)
+		signIn(env)
+	}
+	newPasswordChallenge := func(env *testEnv) {
+		env.cognito.AddUser(testEmail, testPassword, nil)
+		env.cognito.Update(testEmail, func(user *awsapi.CognitoUser) { user.ForceChangePassword = true })
+		signIn(env)
+	}
+	answer := func(challenge, code, newPassword string) string {
+		return jsonBody(t, entity.RespondChallengeRequest{
+			Email:         testEmail,
+			ChallengeName: challenge,
+			Session:       testSession,
+			Code:          code,
+			NewPassword:   newPassword,
+		})
+	}
+	expectTokens := func(t *testing.T, _ *testEnv, body string) {
+		tokens := decodeBody(t, body)
+		if tokens["id_token"] == nil || tokens["refresh_token"] == nil || tokens["isConfirmed"] != true {
+			t.Fatalf("unexpected tokens %v", tokens)
+		}
+	}
+
+	runCases(t, (*Handlers).RespondChallenge, []testCase{
+		{
+			Name:   "mfa code completes sign-in",
+			Setup:  mfaChallenge,
+			Body:   answer("SOFTWARE_TOKEN_MFA", awsapi.TOTPCode, ""),
+			Status: http.StatusOK,
+			Check:  expectTokens,
+		},
+		{
+			Name:   "new password completes sign-in",
+			Setup:  newPasswordChallenge,
+			Body:   answer("NEW_PASSWORD_REQUIRED", "", "Permanent123"),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, body string) {
+				expectTokens(t, env, body)
+				if user, _ := env.cognito.User(testEmail); user.Password != "Permanent123" || user.ForceChangePassword {
+					t.Fatalf("password not changed: %+v", user)
+				}
+			},
+		},
+		{
+			Name: "new password leads to mfa",
+			Setup: func(env *testEnv) {
+				seedMFAUser(env)
+				env.cognito.Update(testEmail, func(user *awsapi.CognitoUser) { user.ForceChangePassword = true })
+				signIn(env)
+			},
+			Body:   answer("NEW_PASSWORD_REQUIRED", "", "Permanent123"),
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *testEnv, body string) {
+				challenge := decodeBody(t, body)
+				if challenge["challenge_name"] != "SOFTWARE_TOKEN_MFA" || challenge["session"] == testSession || challenge["session"] == "" {
+					t.Fatalf("unexpected challenge %v", challenge)
+				}
+			},
+		},
+		{Name: "wrong code", Setup: mfaChallenge, Body: answer("SOFTWARE_TOKEN_MFA", "000000", ""), Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCode},
+		{Name: "malformed code", Setup: mfaChallenge, Body: answer("SOFTWARE_TOKEN_MFA", "12ab", ""), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "weak new password", Setup: newPasswordChallenge, Body: answer("NEW_PASSWORD_REQUIRED", "", "short"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "expired session", Setup: seedMFAUser, Body: answer("SOFTWARE_TOKEN_MFA", awsapi.TOTPCode, ""), Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidSession},
+		{Name: "unsupported challenge", Setup: mfaChallenge, Body: answer("SMS_MFA", "123456", ""), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "missing session", Body: `{"email":"` + testEmail + `","challenge_name":"SOFTWARE_TOKEN_MFA","code":"123456"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid email", Body: `{"email":"ada","session":"s"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "malformed body", Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{
+			Name: "cognito failure",
+			Setup: func(env *testEnv) {
+				mfaChallenge(env)
+				env.cognito.Fail("RespondToAuthChallenge", errors.New("connection reset"))
+			},
+			Body:   answer("SOFTWARE_TOKEN_MFA", awsapi.TOTPCode, ""),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package bootstrap
+
+import (
+	"log"
+	"os"
+
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/components/notifier"
+	"mentorship-app-backend/components/secrets"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/revocation"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+var (
+	cfg         config.Config
+	clients     config.Clients
+	secretCache *secrets.Cache
+	verifier    *validator.TokenVerifier
+	loaded      bool
+)
+
+// Init loads the environment's configuration and shared AWS clients once per container. Handlers call it
+// before constructing their own dependencies.
+func Init() config.Config {
+	if loaded {
+		return cfg
+	}
+
+	environment := os.Getenv("ENVIRONMENT")
+	log.Printf("Loading configuration for environment: %s", environment)
+
+	var err error
+	cfg, err = config.LoadConfig(environment)
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+
+	clients, err = config.NewClients(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize AWS clients: %v", err)
+	}
+	secretCache = secrets.NewCache(secrets.NewSecretsManagerStore(clients.SecretsManager), cfg.SecretsCacheTTL, cfg.SecretsRefreshAhead)
+
+	loaded = true
+	return cfg
+}
+
+// Clients returns the container-wide AWS clients. Init must have been called.
+func Clients() config.Clients {
+	return clients
+}
+
+// Secrets returns the container-wide secrets cache. Init must have been called.
+func Secrets() *secrets.Cache {
+	return secretCache
+}
+
+// Start wraps handler in the standard middleware chain of the route served by the Lambda routeName and hands it
+// to the Lambda runtime.
+func Start(handler wrapper.Handler, routeName string) {
+	route, ok := routes.Lookup(routeName)
+	if !ok {
+		log.Fatalf("no route for lambda %q", routeName)
+	}
+	lambda.Start(Wrap(route, handler))
+}
+
+// Wrap builds the chain a deployed Lambda runs handler behind: the route's handler name and notification
+// channel, and for protected routes verification of the caller's ID token, read with wrapper.Caller. The local
+// server wraps every route with it, so local runs notify and authenticate exactly as deployed ones.
+func Wrap(route routes.Route, handler wrapper.Handler) wrapper.Handler {
+	cfg := Init()
+
+	var extra []wrapper.Middleware
+	if route.Protected {
+		extra = append(extra, wrapper.Authenticate(tokenVerifier(cfg)))
+	}
+	return wrapper.HandlerWrapper(handler, newNotifier(cfg, route.Handler), route.Channel, route.Handler, extra...)
+}
+
+// tokenVerifier is shared by every route in the process, so the local server fetches the JWKS once.
+func tokenVerifier(cfg config.Config) *validator.TokenVerifier {
+	if verifier != nil {
+		return verifier
+	}
+	created, err := validator.NewCognitoTokenVerifier(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize token verifier: %v", err)
+	}
+	denylist := revocation.NewDynamoRepository(clients.DynamoDB, cfg.RevokedTokensDDBTableName)
+	verifier = created.WithDenylist(denylist)
+	return verifier
+}
+
+// newNotifier applies the handler's policy, strips sensitive values and sends off the request path. Monitoring
+// must not take the API down with it, so a misconfigured backend or policy degrades instead of failing.
+func newNotifier(cfg config.Config, handlerName string) notifier.Notifier {
+	backend, err := notifier.New(cfg, secretCache, clients.SNS)
+	if err != nil {
+		log.Printf("failed to initialize notifier, falling back to logs: %v", err)
+		backend = notifier.LogNotifier{}
+	}
+
+	policy, err := notifier.PolicyFromConfig(cfg.Notifications.PolicyFor(handlerName))
+	if err != nil {
+		log.Printf("invalid notification policy for %s, sending everything: %v", handlerName, err)
+		policy = notifier.Policy{MinLevel: notifier.LevelInfo, SuccessSampleRate: 1}
+	}
+
+	return notifier.NewFilter(notifier.NewAsync(notifier.NewRedactor(backend), notifier.DefaultQueueSize), policy)
+}
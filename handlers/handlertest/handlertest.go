@@ -0,0 +1,72 @@
+// Package handlertest runs the table-driven handler tests of every handler group. A group supplies its test
+// environment, the in-memory fakes its Handlers are built on, and the cases; Run does the rest.
+package handlertest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/wrapper"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Method is a handler method expression such as (*auth.Handlers).Login.
+type Method[H any] func(h H, ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Case drives one handler call in a fresh environment of type E. Setup seeds or breaks the fakes, Caller is the
+// ID token the authorizer would pass on, if any, and Check inspects the fakes or the body once the status and,
+// when set, the error code have matched.
+type Case[E any] struct {
+	Name    string
+	Setup   func(env E)
+	Caller  *entity.IDTokenPayload
+	Query   map[string]string
+	Headers map[string]string
+	Body    string
+	Status  int
+	Code    errorpackage.Code
+	Check   func(t *testing.T, env E, body string)
+}
+
+// Run calls method once per case, on the Handlers that handlers builds from the environment newEnv returns.
+// Handlers report failures in the response, so any Go error fails the case.
+func Run[E, H any](t *testing.T, newEnv func() E, handlers func(env E) H, method Method[H], cases []Case[E]) {
+	t.Helper()
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			env := newEnv()
+			if tt.Setup != nil {
+				tt.Setup(env)
+			}
+			ctx := context.Background()
+			if tt.Caller != nil {
+				ctx = wrapper.WithCaller(ctx, tt.Caller)
+			}
+
+			response, err := method(handlers(env), ctx, events.APIGatewayProxyRequest{
+				Body:                  tt.Body,
+				Headers:               tt.Headers,
+				QueryStringParameters: tt.Query,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.StatusCode != tt.Status {
+				t.Fatalf("expected status %d, got %d: %s", tt.Status, response.StatusCode, response.Body)
+			}
+			if tt.Code != "" {
+				var envelope errorpackage.Envelope
+				if err = json.Unmarshal([]byte(response.Body), &envelope); err != nil || envelope.Code != tt.Code {
+					t.Fatalf("expected code %s, got %s", tt.Code, response.Body)
+				}
+			}
+			if tt.Check != nil {
+				tt.Check(t, env, response.Body)
+			}
+		})
+	}
+}
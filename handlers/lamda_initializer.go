@@ -3,69 +3,134 @@ package handlers
 import (
 	"fmt"
 	"github.com/aws/aws-cdk-go/awscdk/v2"
-	"github.com/aws/aws-cdk-go/awscdk/v2/awsdynamodb"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awslambda"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
 	"github.com/aws/jsii-runtime-go"
 	"log"
-	"mentorship-app-backend/api"
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/components/dynamoDB"
 	"mentorship-app-backend/config"
 	"mentorship-app-backend/permissions"
 )
 
-func InitializeLambda(stack awscdk.Stack, bucket awss3.Bucket, table awsdynamodb.Table, functionName string, dependentLambdas map[string]awslambda.Function, cfg config.Config) awslambda.Function {
-	fullFunctionName := fmt.Sprintf("%s-%s", functionName, cfg.Environment)
+// InitializeLambda creates the Lambda serving route, sized and granted as the route declares.
+func InitializeLambda(stack awscdk.Stack, bucket awss3.Bucket, tables dynamoDB.Tables, route routes.Route, cfg config.Config) awslambda.Function {
+	fullFunctionName := routes.FunctionName(route.Name, cfg.Environment)
 
-	envVars := getLambdaEnvironmentVars(cfg.CognitoClientID, cfg.CognitoPoolArn, cfg.Environment, *bucket.BucketName(), *table.TableName())
+	envVars := getLambdaEnvironmentVars(cfg.CognitoClientID, cfg.CognitoPoolArn, cfg.Environment, *bucket.BucketName(), tables)
 
-	log.Printf("env vars: %v", envVars)
+	memory := route.MemoryMB
+	if memory == 0 {
+		memory = routes.DefaultMemoryMB
+	}
+	timeout := route.Timeout
+	if timeout == 0 {
+		timeout = routes.DefaultTimeout
+	}
 
 	lambdaFunction := awslambda.NewFunction(stack, jsii.String(fullFunctionName), &awslambda.FunctionProps{
 		Runtime:      awslambda.Runtime_PROVIDED_AL2(),
 		Handler:      jsii.String("bootstrap"),
 		FunctionName: jsii.String(fullFunctionName),
-		Code:         awslambda.Code_FromAsset(jsii.String(fmt.Sprintf("./output/%s_function.zip", functionName)), nil),
+		Code:         awslambda.Code_FromAsset(jsii.String(fmt.Sprintf("./output/%s_function.zip", route.Name)), nil),
 		Environment:  &envVars,
-		Timeout:      awscdk.Duration_Seconds(jsii.Number(15)),
+		MemorySize:   jsii.Number(memory),
+		Timeout:      awscdk.Duration_Seconds(jsii.Number(timeout.Seconds())),
 	})
 
-	grantPermissions(lambdaFunction, dependentLambdas, functionName, bucket, table, cfg)
+	grantPermissions(lambdaFunction, route, bucket, tables, cfg)
 
 	return lambdaFunction
 }
 
-func getLambdaEnvironmentVars(cognitoClientID, arn, environment, bucketName, tableName string) map[string]*string {
+func getLambdaEnvironmentVars(cognitoClientID, arn, environment, bucketName string, tables dynamoDB.Tables) map[string]*string {
 	return map[string]*string{
-		"BUCKET_NAME":              jsii.String(bucketName),
-		"ENVIRONMENT":              jsii.String(environment),
-		"COGNITO_CLIENT_ID":        jsii.String(cognitoClientID),
-		"COGNITO_POOL_ARN":         jsii.String(arn),
-		"ACCOUNT":                  jsii.String(config.AppConfig.Account),
-		"REGION":                   jsii.String(config.AppConfig.Region),
-		"SLACK_WEBHOOK_SECRET_ARN": jsii.String(config.AppConfig.SlackWebhookSecretARN),
-		"DDB_TABLE_NAME":           jsii.String(tableName),
+		"BUCKET_NAME":                   jsii.String(bucketName),
+		"ENVIRONMENT":                   jsii.String(environment),
+		"COGNITO_CLIENT_ID":             jsii.String(cognitoClientID),
+		"COGNITO_POOL_ARN":              jsii.String(arn),
+		"ACCOUNT":                       jsii.String(config.AppConfig.Account),
+		"REGION":                        jsii.String(config.AppConfig.Region),
+		"SLACK_WEBHOOK_SECRET_ARN":      jsii.String(config.AppConfig.SlackWebhookSecretARN),
+		"DDB_TABLE_NAME":                tables.Profile.TableName(),
+		"REQUESTS_DDB_TABLE_NAME":       tables.MentorshipRequests.TableName(),
+		"AVAILABILITY_DDB_TABLE_NAME":   tables.Availability.TableName(),
+		"BOOKINGS_DDB_TABLE_NAME":       tables.Bookings.TableName(),
+		"REVOKED_TOKENS_DDB_TABLE_NAME": tables.RevokedTokens.TableName(),
+		"ACCOUNT_JOBS_DDB_TABLE_NAME":   tables.AccountJobs.TableName(),
 	}
 }
 
-func grantPermissions(lambdaFunction awslambda.Function, dependentLambdas map[string]awslambda.Function, functionName string, bucket awss3.Bucket, table awsdynamodb.Table, cfg config.Config) {
-	switch functionName {
-	case api.RegisterLambdaName:
-		permissions.GrantCognitoRegisterPermissions(lambdaFunction)
-		if uploadLambda, exists := dependentLambdas[api.UploadLambdaName]; exists {
-			permissions.GrantLambdaInvokePermission(lambdaFunction, uploadLambda)
+func grantPermissions(lambdaFunction awslambda.Function, route routes.Route, bucket awss3.Bucket, tables dynamoDB.Tables, cfg config.Config) {
+	for _, permission := range route.Permissions {
+		switch permission {
+		case routes.CognitoRegister:
+			permissions.GrantCognitoRegisterPermissions(lambdaFunction)
+		case routes.CognitoLogin:
+			permissions.GrantCognitoLoginPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoConfirm:
+			permissions.GrantCognitoConfirmationPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoResend:
+			permissions.GrantCognitoResendPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoRefresh:
+			permissions.GrantCognitoRefreshPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoPasswordReset:
+			permissions.GrantCognitoPasswordResetPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoChallenge:
+			permissions.GrantCognitoChallengePermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoMFA:
+			permissions.GrantCognitoMFAPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoSignOut:
+			permissions.GrantCognitoSignOutPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoAccount:
+			permissions.GrantCognitoAccountPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoReadUser:
+			permissions.GrantCognitoReadUserPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoDeleteUser:
+			permissions.GrantCognitoDeleteUserPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoDescribe:
+			permissions.GrantCognitoDescribePermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.CognitoTokenValidation:
+			permissions.GrantCognitoTokenValidationPermissions(lambdaFunction, cfg.CognitoPoolArn)
+		case routes.BucketRead:
+			permissions.GrantBucketRead(lambdaFunction, bucket)
+		case routes.BucketReadWrite:
+			permissions.GrantBucketReadWrite(lambdaFunction, bucket)
+		case routes.BucketPut:
+			permissions.GrantBucketPut(lambdaFunction, bucket)
+		case routes.ProfilePictureUpload:
+			permissions.GrantProfilePictureUpload(lambdaFunction, bucket)
+		case routes.RequestsTable:
+			permissions.GrantDynamoDBPermissions(lambdaFunction, tables.MentorshipRequests)
+		case routes.AvailabilityTable:
+			permissions.GrantDynamoDBPermissions(lambdaFunction, tables.Availability)
+		case routes.BookingsTable:
+			permissions.GrantDynamoDBPermissions(lambdaFunction, tables.Bookings)
+		case routes.RevokedTokensTable:
+			permissions.GrantDynamoDBPermissions(lambdaFunction, tables.RevokedTokens)
+		case routes.AccountJobsTable:
+			permissions.GrantDynamoDBPermissions(lambdaFunction, tables.AccountJobs)
+		default:
+			log.Fatalf("route %s declares unknown permission %q", route.Name, permission)
 		}
-	case api.LoginLambdaName:
-		permissions.GrantCognitoLoginPermissions(lambdaFunction, cfg.CognitoPoolArn)
-	case api.ConfirmLambdaName:
-		permissions.GrantCognitoConfirmationPermissions(lambdaFunction, cfg.CognitoPoolArn)
-	case api.ResendLambdaName:
-		permissions.GrantCognitoResendPermissions(lambdaFunction, cfg.CognitoPoolArn)
-	default:
-		permissions.GrantAccessForBucket(lambdaFunction, bucket, functionName)
-		permissions.GrantCognitoDescribePermissions(lambdaFunction, cfg.CognitoPoolArn)
-		permissions.GrantCognitoTokenValidationPermissions(lambdaFunction, cfg.CognitoPoolArn)
 	}
 
-	permissions.GrantDynamoDBPermissions(lambdaFunction, table)
+	if route.Protected {
+		permissions.GrantDynamoDBReadPermissions(lambdaFunction, tables.RevokedTokens)
+	}
+	permissions.GrantDynamoDBPermissions(lambdaFunction, tables.Profile)
 	permissions.GrantSecretManagerReadWritePermissions(lambdaFunction, cfg.SlackWebhookSecretARN)
+	if cfg.NotificationTopicARN != "" {
+		permissions.GrantSNSPublishPermissions(lambdaFunction, cfg.NotificationTopicARN)
+	}
+}
+
+// GrantInvocations lets each Lambda invoke the workers its route lists in Invokes. It runs once every Lambda,
+// workers included, exists.
+func GrantInvocations(lambdas map[string]awslambda.Function) {
+	for _, route := range append(routes.All(), routes.Workers()...) {
+		for _, target := range route.Invokes {
+			permissions.GrantLambdaInvokePermission(lambdas[route.Name], lambdas[target])
+		}
+	}
 }
@@ -0,0 +1,21 @@
+package mentorship
+
+import (
+	"mentorship-app-backend/config"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+)
+
+// Handlers serves the mentorship request endpoints.
+type Handlers struct {
+	Profiles profile.Repository
+	Requests mentorshiprepo.Repository
+}
+
+// New reads mentors from the profile table and keeps requests in the mentorship requests table.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		Profiles: profile.NewDynamoRepository(clients.DynamoDB, cfg.UserProfileDDBTableName),
+		Requests: mentorshiprepo.NewDynamoRepository(clients.DynamoDB, cfg.MentorshipRequestsDDBTableName),
+	}
+}
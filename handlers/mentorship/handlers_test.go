@@ -0,0 +1,61 @@
+package mentorship
+
+import (
+	"testing"
+	"time"
+
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+)
+
+const (
+	mentorEmail = "grace@example.com"
+	menteeEmail = "ada@example.com"
+
+	mentorID = "sub-mentor"
+	menteeID = "sub-mentee"
+)
+
+var (
+	mentorCaller = &entity.IDTokenPayload{Sub: mentorID, Email: mentorEmail, CustomRole: "mentor"}
+	menteeCaller = &entity.IDTokenPayload{Sub: menteeID, Email: menteeEmail, CustomRole: "mentee"}
+)
+
+type testEnv struct {
+	profiles *profile.MemoryRepository
+	requests *mentorshiprepo.MemoryRepository
+}
+
+type testCase = handlertest.Case[*testEnv]
+
+// runCases calls handler once per case with a registered mentor and the given requests already stored.
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], seed []entity.MentorshipRequest, tests []testCase) {
+	t.Helper()
+	newEnv := func() *testEnv {
+		return &testEnv{
+			profiles: profile.NewMemoryRepository(entity.Profile{
+				UserID: mentorID,
+				User:   entity.User{Email: mentorEmail, Name: "Grace", Role: "mentor"},
+			}),
+			requests: mentorshiprepo.NewMemoryRepository(seed...),
+		}
+	}
+	handlers := func(env *testEnv) *Handlers { return &Handlers{Profiles: env.profiles, Requests: env.requests} }
+	handlertest.Run(t, newEnv, handlers, handler, tests)
+}
+
+// storedRequest builds a request between the test mentor and mentee created age ago with the default TTL.
+func storedRequest(id string, status entity.MentorshipStatus, age time.Duration) entity.MentorshipRequest {
+	created := time.Now().UTC().Add(-age)
+	return entity.MentorshipRequest{
+		RequestID: id,
+		MentorID:  mentorID,
+		MenteeID:  menteeID,
+		Status:    status,
+		CreatedAt: created.Format(time.RFC3339),
+		UpdatedAt: created.Format(time.RFC3339),
+		ExpiresAt: created.Add(mentorshiprepo.RequestTTL).Format(time.RFC3339),
+	}
+}
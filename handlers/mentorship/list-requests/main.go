@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/bootstrap"
+	"mentorship-app-backend/handlers/mentorship"
+)
+
+func main() {
+	handlers := mentorship.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.ListRequests, routes.ListRequestsLambdaName)
+}
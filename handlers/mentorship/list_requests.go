@@ -0,0 +1,65 @@
+package mentorship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) ListRequests(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	status := request.QueryStringParameters["status"]
+	if status != "" {
+		if err = validator.ValidateMentorshipStatus(status); err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	var found []entity.MentorshipRequest
+	switch payload.CustomRole {
+	case validator.RoleMentor:
+		found, err = h.Requests.ListByMentor(ctx, profile.UserID(payload))
+	case validator.RoleMentee:
+		found, err = h.Requests.ListByMentee(ctx, profile.UserID(payload))
+	default:
+		return errorpackage.ClientError(http.StatusForbidden, "Unknown profile type")
+	}
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to list mentorship requests: %s", err.Error()))
+	}
+
+	now := time.Now()
+	result := make([]entity.MentorshipRequest, 0, len(found))
+	for _, item := range found {
+		item.Status = mentorshiprepo.EffectiveStatus(&item, now)
+		if status != "" && string(item.Status) != status {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	responseJSON, err := json.Marshal(map[string]interface{}{"requests": result})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal mentorship requests")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersGet(""),
+		Body:       string(responseJSON),
+	}, nil
+}
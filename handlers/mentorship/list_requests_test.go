@@ -0,0 +1,59 @@
+package mentorship
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestListRequests(t *testing.T) {
+	seed := []entity.MentorshipRequest{
+		storedRequest("old", entity.MentorshipStatusPending, 30*24*time.Hour),
+		storedRequest("new", entity.MentorshipStatusPending, time.Hour),
+		storedRequest("done", entity.MentorshipStatusAccepted, 2*time.Hour),
+	}
+	expect := func(want ...string) func(*testing.T, *testEnv, string) {
+		return func(t *testing.T, _ *testEnv, body string) {
+			var result struct {
+				Requests []entity.MentorshipRequest `json:"requests"`
+			}
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("body is not valid JSON: %v", err)
+			}
+			var got []string
+			for _, request := range result.Requests {
+				got = append(got, request.RequestID+":"+string(request.Status))
+			}
+			if len(got) != len(want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("expected %v, got %v", want, got)
+				}
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).ListRequests, seed, []testCase{
+		{Name: "mentor sees newest first", Caller: mentorCaller, Status: http.StatusOK, Check: expect("new:pending", "done:accepted", "old:expired")},
+		{Name: "mentee", Caller: menteeCaller, Status: http.StatusOK, Check: expect("new:pending", "done:accepted", "old:expired")},
+		{Name: "expired filter", Caller: menteeCaller, Query: map[string]string{"status": "expired"}, Status: http.StatusOK, Check: expect("old:expired")},
+		{Name: "other mentor", Caller: &entity.IDTokenPayload{Sub: "sub-linus", Email: "linus@example.com", CustomRole: "mentor"}, Status: http.StatusOK, Check: expect()},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "invalid status", Caller: mentorCaller, Query: map[string]string{"status": "maybe"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "unknown role", Caller: &entity.IDTokenPayload{Email: menteeEmail, CustomRole: "admin"}, Status: http.StatusForbidden, Code: errorpackage.CodeForbidden},
+		{
+			Name:   "repository failure",
+			Setup:  func(env *testEnv) { env.requests.Fail("ListByMentor", errors.New("throughput exceeded")) },
+			Caller: mentorCaller,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
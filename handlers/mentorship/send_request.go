@@ -0,0 +1,83 @@
+package mentorship
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) SendRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if payload.CustomRole != validator.RoleMentee {
+		return errorpackage.ClientError(http.StatusForbidden, "Only mentees can send mentorship requests")
+	}
+	menteeID := profile.UserID(payload)
+
+	var req entity.CreateMentorshipRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err = validator.ValidateMentorshipRequest(&req, menteeID); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err = h.Profiles.Get(ctx, req.MentorID, validator.RoleMentor); err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeMentorNotFound, "Mentor not found")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to look up mentor: %s", err.Error()))
+	}
+
+	// The listing only tells the caller which kind of request already exists. Create is what keeps two
+	// concurrent sends from both getting through.
+	existing, err := h.Requests.ListByMentee(ctx, menteeID)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to list mentorship requests: %s", err.Error()))
+	}
+	for i := range existing {
+		if existing[i].MentorID != req.MentorID {
+			continue
+		}
+		switch mentorshiprepo.EffectiveStatus(&existing[i], time.Now()) {
+		case entity.MentorshipStatusPending:
+			return errorpackage.CodedError(errorpackage.CodeRequestExists, "A mentorship request to this mentor is already pending")
+		case entity.MentorshipStatusAccepted:
+			return errorpackage.CodedError(errorpackage.CodeRequestExists, "You are already mentored by this mentor")
+		}
+	}
+
+	created, err := h.Requests.Create(ctx, req.MentorID, menteeID, req.Message)
+	if err != nil {
+		if errors.Is(err, errorpackage.ErrRequestExists) {
+			return errorpackage.CodedError(errorpackage.CodeRequestExists, "A mentorship request to this mentor is already pending")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to create mentorship request: %s", err.Error()))
+	}
+
+	responseJSON, err := json.Marshal(created)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal mentorship request")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusCreated,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseJSON),
+	}, nil
+}
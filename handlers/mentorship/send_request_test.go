@@ -0,0 +1,121 @@
+package mentorship
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+)
+
+func TestSendRequest(t *testing.T) {
+	body := `{"mentor_id":"` + mentorID + `","message":"Hi Grace"}`
+
+	runCases(t, (*Handlers).SendRequest, nil, []testCase{
+		{
+			Name:   "success",
+			Caller: menteeCaller,
+			Body:   body,
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				sent, _ := env.requests.ListByMentee(context.Background(), menteeID)
+				if len(sent) != 1 || sent[0].Status != entity.MentorshipStatusPending || sent[0].Message != "Hi Grace" {
+					t.Fatalf("unexpected requests %+v", sent)
+				}
+			},
+		},
+		{Name: "no caller", Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "mentor cannot send", Caller: mentorCaller, Body: body, Status: http.StatusForbidden, Code: errorpackage.CodeForbidden},
+		{Name: "malformed body", Caller: menteeCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing mentor", Caller: menteeCaller, Body: `{}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "unknown mentor", Caller: menteeCaller, Body: `{"mentor_id":"sub-nobody"}`, Status: http.StatusNotFound, Code: errorpackage.CodeMentorNotFound},
+		{
+			Name:   "mentor lookup failure",
+			Setup:  func(env *testEnv) { env.profiles.Fail("Get", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "create failure",
+			Setup:  func(env *testEnv) { env.requests.Fail("Create", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
+
+func TestSendRequestRejectsDuplicates(t *testing.T) {
+	body := `{"mentor_id":"` + mentorID + `"}`
+
+	tests := []struct {
+		name     string
+		existing entity.MentorshipRequest
+		status   int
+	}{
+		{"pending", storedRequest("r1", entity.MentorshipStatusPending, time.Hour), http.StatusConflict},
+		{"accepted", storedRequest("r1", entity.MentorshipStatusAccepted, time.Hour), http.StatusConflict},
+		{"declined", storedRequest("r1", entity.MentorshipStatusDeclined, time.Hour), http.StatusCreated},
+		{"expired while pending", storedRequest("r1", entity.MentorshipStatusPending, 30*24*time.Hour), http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		runCases(t, (*Handlers).SendRequest, []entity.MentorshipRequest{tt.existing}, []testCase{
+			{Name: tt.name, Caller: menteeCaller, Body: body, Status: tt.status},
+		})
+	}
+}
+
+// staleListing answers ListByMentee as if no request had been sent yet, which is what two concurrent sends
+// to the same mentor both see.
+type staleListing struct {
+	*mentorshiprepo.MemoryRepository
+}
+
+func (staleListing) ListByMentee(context.Context, string) ([]entity.MentorshipRequest, error) {
+	return nil, nil
+}
+
+func TestSendRequestConcurrentDuplicate(t *testing.T) {
+	body := `{"mentor_id":"` + mentorID + `"}`
+	newEnv := func() *testEnv {
+		return &testEnv{
+			profiles: profile.NewMemoryRepository(entity.Profile{
+				UserID: mentorID,
+				User:   entity.User{Email: mentorEmail, Name: "Grace", Role: "mentor"},
+			}),
+			requests: mentorshiprepo.NewMemoryRepository(),
+		}
+	}
+	handlers := func(env *testEnv) *Handlers {
+		return &Handlers{Profiles: env.profiles, Requests: staleListing{env.requests}}
+	}
+
+	handlertest.Run(t, newEnv, handlers, (*Handlers).SendRequest, []testCase{{
+		Name: "second send while the first is in flight",
+		Setup: func(env *testEnv) {
+			if _, err := env.requests.Create(context.Background(), mentorID, menteeID, ""); err != nil {
+				t.Fatalf("first send failed: %v", err)
+			}
+		},
+		Caller: menteeCaller,
+		Body:   body,
+		Status: http.StatusConflict,
+		Code:   errorpackage.CodeRequestExists,
+		Check: func(t *testing.T, env *testEnv, _ string) {
+			sent, _ := env.requests.ListByMentee(context.Background(), menteeID)
+			if len(sent) != 1 {
+				t.Fatalf("expected one request, got %+v", sent)
+			}
+		},
+	}})
+}
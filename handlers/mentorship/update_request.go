@@ -0,0 +1,82 @@
+package mentorship
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	mentorshiprepo "mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) UpdateRequest(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.UpdateMentorshipRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+	if req.RequestID == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "request_id is required")
+	}
+
+	target, actorRole, err := mentorshiprepo.ResolveAction(req.Action)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	current, err := h.Requests.Get(ctx, req.RequestID)
+	if err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeRequestNotFound, "Mentorship request not found")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to load mentorship request: %s", err.Error()))
+	}
+
+	callerID := profile.UserID(payload)
+	participant := current.MentorID
+	if actorRole == validator.RoleMentee {
+		participant = current.MenteeID
+	}
+	if payload.CustomRole != actorRole || participant != callerID {
+		return errorpackage.ClientError(http.StatusForbidden, fmt.Sprintf("Only the %s of this request can %s it", actorRole, req.Action))
+	}
+
+	if mentorshiprepo.EffectiveStatus(current, time.Now()) == entity.MentorshipStatusExpired && current.Status == entity.MentorshipStatusPending {
+		if _, expireErr := h.Requests.Transition(ctx, current.RequestID, entity.MentorshipStatusPending, entity.MentorshipStatusExpired); expireErr != nil {
+			log.Printf("Failed to persist expiry of mentorship request %s: %v", current.RequestID, expireErr)
+		}
+		return errorpackage.CodedError(errorpackage.CodeInvalidTransition, "Mentorship request has expired")
+	}
+
+	updated, err := h.Requests.Transition(ctx, current.RequestID, current.Status, target)
+	if err != nil {
+		if errors.Is(err, errorpackage.ErrInvalidTransition) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidTransition, fmt.Sprintf("Mentorship request is already %s", current.Status))
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to update mentorship request: %s", err.Error()))
+	}
+
+	responseJSON, err := json.Marshal(updated)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal mentorship request")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPatch(),
+		Body:       string(responseJSON),
+	}, nil
+}
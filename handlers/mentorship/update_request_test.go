@@ -0,0 +1,75 @@
+package mentorship
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestUpdateRequest(t *testing.T) {
+	seed := []entity.MentorshipRequest{
+		storedRequest("pending", entity.MentorshipStatusPending, time.Hour),
+		storedRequest("declined", entity.MentorshipStatusDeclined, time.Hour),
+		storedRequest("stale", entity.MentorshipStatusPending, 30*24*time.Hour),
+	}
+	action := func(requestID, action string) string {
+		return `{"request_id":"` + requestID + `","action":"` + action + `"}`
+	}
+	expectStatus := func(requestID string, want entity.MentorshipStatus) func(*testing.T, *testEnv, string) {
+		return func(t *testing.T, env *testEnv, _ string) {
+			stored, err := env.requests.Get(context.Background(), requestID)
+			if err != nil || stored.Status != want {
+				t.Fatalf("expected %s, got %+v %v", want, stored, err)
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).UpdateRequest, seed, []testCase{
+		{Name: "mentor accepts", Caller: mentorCaller, Body: action("pending", "accept"), Status: http.StatusOK, Check: expectStatus("pending", entity.MentorshipStatusAccepted)},
+		{Name: "mentor declines", Caller: mentorCaller, Body: action("pending", "decline"), Status: http.StatusOK, Check: expectStatus("pending", entity.MentorshipStatusDeclined)},
+		{Name: "mentee withdraws", Caller: menteeCaller, Body: action("pending", "withdraw"), Status: http.StatusOK, Check: expectStatus("pending", entity.MentorshipStatusWithdrawn)},
+		{Name: "no caller", Body: action("pending", "accept"), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: mentorCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing request ID", Caller: mentorCaller, Body: `{"action":"accept"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "unknown action", Caller: mentorCaller, Body: action("pending", "ignore"), Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "unknown request", Caller: mentorCaller, Body: action("missing", "accept"), Status: http.StatusNotFound, Code: errorpackage.CodeRequestNotFound},
+		{Name: "mentee cannot accept", Caller: menteeCaller, Body: action("pending", "accept"), Status: http.StatusForbidden, Code: errorpackage.CodeForbidden},
+		{
+			Name:   "other mentor cannot accept",
+			Caller: &entity.IDTokenPayload{Sub: "sub-linus", Email: "linus@example.com", CustomRole: "mentor"},
+			Body:   action("pending", "accept"),
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeForbidden,
+		},
+		{Name: "already decided", Caller: mentorCaller, Body: action("declined", "accept"), Status: http.StatusConflict, Code: errorpackage.CodeInvalidTransition},
+		{
+			Name:   "expired request is persisted as expired",
+			Caller: mentorCaller,
+			Body:   action("stale", "accept"),
+			Status: http.StatusConflict,
+			Code:   errorpackage.CodeInvalidTransition,
+			Check:  expectStatus("stale", entity.MentorshipStatusExpired),
+		},
+		{
+			Name:   "load failure",
+			Setup:  func(env *testEnv) { env.requests.Fail("Get", errors.New("throughput exceeded")) },
+			Caller: mentorCaller,
+			Body:   action("pending", "accept"),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "transition failure",
+			Setup:  func(env *testEnv) { env.requests.Fail("Transition", errors.New("throughput exceeded")) },
+			Caller: mentorCaller,
+			Body:   action("pending", "accept"),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package profile
+
+import (
+	"mentorship-app-backend/config"
+	profilerepo "mentorship-app-backend/repository/profile"
+)
+
+// Handlers serves the profile endpoints.
+type Handlers struct {
+	Profiles profilerepo.Repository
+}
+
+// New backs the handlers with the profile table, whose ProfileType index serves mentor search.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		Profiles: profilerepo.NewDynamoRepository(clients.DynamoDB, cfg.UserProfileDDBTableName),
+	}
+}
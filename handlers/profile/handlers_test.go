@@ -0,0 +1,19 @@
+package profile
+
+import (
+	"testing"
+
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+	profilerepo "mentorship-app-backend/repository/profile"
+)
+
+type testCase = handlertest.Case[*profilerepo.MemoryRepository]
+
+// runCases calls handler once per case against a fresh repository seeded with seed.
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], seed []entity.Profile, tests []testCase) {
+	t.Helper()
+	newRepository := func() *profilerepo.MemoryRepository { return profilerepo.NewMemoryRepository(seed...) }
+	handlers := func(profiles *profilerepo.MemoryRepository) *Handlers { return &Handlers{Profiles: profiles} }
+	handlertest.Run(t, newRepository, handlers, handler, tests)
+}
@@ -0,0 +1,96 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 50
+)
+
+func (h *Handlers) Mentors(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	params := request.QueryStringParameters
+
+	filter := entity.MentorSearchFilter{
+		Skill:     params["skill"],
+		Industry:  params["industry"],
+		Language:  params["language"],
+		Seniority: params["seniority"],
+	}
+	if filter.Seniority != "" {
+		if err := validator.ValidateSeniority(filter.Seniority); err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+		}
+	}
+	if available := params["available"]; available != "" {
+		value, err := strconv.ParseBool(available)
+		if err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, "available must be true or false")
+		}
+		filter.Available = &value
+	}
+
+	limit := defaultPageSize
+	if rawLimit := params["limit"]; rawLimit != "" {
+		value, err := strconv.Atoi(rawLimit)
+		if err != nil || value < 1 || value > maxPageSize {
+			return errorpackage.ClientError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxPageSize))
+		}
+		limit = value
+	}
+
+	mentors, nextCursor, err := h.Profiles.SearchMentors(ctx, filter, int32(limit), params["cursor"])
+	if err != nil {
+		if errors.Is(err, errorpackage.ErrInvalidCursor) {
+			return errorpackage.CodedError(errorpackage.CodeInvalidCursor, err.Error())
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to search mentors: %s", err.Error()))
+	}
+
+	summaries := make([]entity.MentorSummary, 0, len(mentors))
+	for _, mentor := range mentors {
+		summaries = append(summaries, mentorSummary(mentor))
+	}
+
+	responseJSON, err := json.Marshal(entity.MentorSearchResponse{
+		Mentors:    summaries,
+		NextCursor: nextCursor,
+	})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal mentor search results")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersGet(""),
+		Body:       string(responseJSON),
+	}, nil
+}
+
+func mentorSummary(mentor entity.Profile) entity.MentorSummary {
+	return entity.MentorSummary{
+		UserID:            mentor.UserID,
+		Name:              mentor.Name,
+		ProfilePicture:    mentor.ProfilePicture,
+		Bio:               mentor.Bio,
+		Languages:         mentor.Languages,
+		SocialLinks:       mentor.SocialLinks,
+		Skills:            mentor.Skills,
+		Industry:          mentor.Industry,
+		Seniority:         mentor.Seniority,
+		YearsOfExperience: mentor.YearsOfExperience,
+		Available:         mentor.Available,
+	}
+}
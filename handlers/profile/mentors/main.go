@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/bootstrap"
+	"mentorship-app-backend/handlers/profile"
+)
+
+func main() {
+	handlers := profile.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.Mentors, routes.MentorsLambdaName)
+}
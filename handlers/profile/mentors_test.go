@@ -0,0 +1,104 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	profilerepo "mentorship-app-backend/repository/profile"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func mentor(email, seniority string, skills ...string) entity.Profile {
+	return entity.Profile{
+		UserID:    email,
+		User:      entity.User{Email: email, Name: email, Role: "mentor"},
+		Skills:    skills,
+		Seniority: seniority,
+		Available: true,
+	}
+}
+
+func TestMentors(t *testing.T) {
+	seed := []entity.Profile{
+		mentor("ada@example.com", "senior", "go"),
+		mentor("grace@example.com", "lead", "cobol"),
+		mentor("linus@example.com", "senior", "c", "go"),
+		{UserID: "mentee@example.com", User: entity.User{Email: "mentee@example.com", Role: "mentee"}},
+	}
+	expectMentors := func(want ...string) func(*testing.T, *profilerepo.MemoryRepository, string) {
+		return func(t *testing.T, _ *profilerepo.MemoryRepository, body string) {
+			var result entity.MentorSearchResponse
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("body is not valid JSON: %v", err)
+			}
+			if len(result.Mentors) != len(want) {
+				t.Fatalf("expected %v, got %+v", want, result.Mentors)
+			}
+			for i, email := range want {
+				if result.Mentors[i].UserID != email {
+					t.Fatalf("expected %v, got %+v", want, result.Mentors)
+				}
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).Mentors, seed, []testCase{
+		{Name: "all mentors", Status: http.StatusOK, Check: expectMentors("ada@example.com", "grace@example.com", "linus@example.com")},
+		{Name: "by skill", Query: map[string]string{"skill": "go"}, Status: http.StatusOK, Check: expectMentors("ada@example.com", "linus@example.com")},
+		{Name: "by seniority", Query: map[string]string{"seniority": "lead"}, Status: http.StatusOK, Check: expectMentors("grace@example.com")},
+		{Name: "first page", Query: map[string]string{"limit": "2"}, Status: http.StatusOK, Check: expectMentors("ada@example.com", "grace@example.com")},
+		{
+			Name:   "results leave out emails",
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *profilerepo.MemoryRepository, body string) {
+				if strings.Contains(body, `"email"`) {
+					t.Fatalf("expected no emails in %s", body)
+				}
+			},
+		},
+		{Name: "unknown seniority", Query: map[string]string{"seniority": "wizard"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid available", Query: map[string]string{"available": "maybe"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "limit out of range", Query: map[string]string{"limit": "51"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid cursor", Query: map[string]string{"cursor": "not-a-cursor"}, Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidCursor},
+		{
+			Name: "repository failure",
+			Setup: func(profiles *profilerepo.MemoryRepository) {
+				profiles.Fail("SearchMentors", errors.New("throughput exceeded"))
+			},
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
+
+func TestMentorsPagination(t *testing.T) {
+	handlers := &Handlers{Profiles: profilerepo.NewMemoryRepository(mentor("ada@example.com", "senior"), mentor("grace@example.com", "lead"))}
+
+	var seen []string
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		response, _ := handlers.Mentors(context.Background(), events.APIGatewayProxyRequest{
+			QueryStringParameters: map[string]string{"limit": "1", "cursor": cursor},
+		})
+		var result entity.MentorSearchResponse
+		if err := json.Unmarshal([]byte(response.Body), &result); err != nil {
+			t.Fatalf("unexpected body %s", response.Body)
+		}
+		for _, profile := range result.Mentors {
+			seen = append(seen, profile.UserID)
+		}
+		if cursor = result.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(seen) != 2 || seen[0] != "ada@example.com" || seen[1] != "grace@example.com" {
+		t.Fatalf("unexpected pages %v", seen)
+	}
+}
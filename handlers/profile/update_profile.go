@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	profilerepo "mentorship-app-backend/repository/profile"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) UpdateProfile(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var req entity.ProfileUpdateRequest
+	decoder := json.NewDecoder(bytes.NewReader([]byte(request.Body)))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err = validator.ValidateProfileUpdate(&req, payload.CustomRole); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+	if req.ProfilePicture != nil {
+		if err = ownership.AuthorizeProfilePicture(payload.Sub, *req.ProfilePicture); err != nil {
+			return errorpackage.HandleKeyError(err)
+		}
+	}
+
+	updated, err := h.Profiles.Update(ctx, profilerepo.UserID(payload), payload.CustomRole, *req.Version, profilerepo.ChangesFromRequest(&req))
+	if err != nil {
+		switch {
+		case errorpackage.IsDynamoDBNotFoundError(err):
+			return errorpackage.CodedError(errorpackage.CodeProfileNotFound, "User profile not found")
+		case errors.Is(err, errorpackage.ErrVersionConflict):
+			return errorpackage.CodedError(errorpackage.CodeProfileConflict, "Profile was modified by another request, reload it and try again")
+		default:
+			return errorpackage.ServerError(fmt.Sprintf("Failed to update user profile: %s", err.Error()))
+		}
+	}
+
+	responseJSON, err := json.Marshal(map[string]interface{}{
+		"email":        payload.Email,
+		"profile_type": payload.CustomRole,
+		"details":      updated,
+	})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal updated profile")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPatch(),
+		Body:       string(responseJSON),
+	}, nil
+}
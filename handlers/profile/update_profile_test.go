@@ -0,0 +1,73 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	profilerepo "mentorship-app-backend/repository/profile"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	mentorCaller := &entity.IDTokenPayload{Sub: "sub-1", Email: "ada@example.com", CustomRole: "mentor"}
+	seed := []entity.Profile{{
+		UserID:  "sub-1",
+		User:    entity.User{Email: "ada@example.com", Name: "Ada", Role: "mentor"},
+		Version: 3,
+	}}
+
+	runCases(t, (*Handlers).UpdateProfile, seed, []testCase{
+		{
+			Name:   "success",
+			Caller: mentorCaller,
+			Body:   `{"version":3,"bio":"Compilers","skills":["go"],"seniority":"senior"}`,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, profiles *profilerepo.MemoryRepository, _ string) {
+				saved, err := profiles.Get(context.Background(), "sub-1", "mentor")
+				if err != nil || saved.Bio != "Compilers" || saved.Seniority != "senior" || saved.Version != 4 {
+					t.Fatalf("unexpected profile %+v %v", saved, err)
+				}
+			},
+		},
+		{
+			Name:   "own profile picture",
+			Caller: mentorCaller,
+			Body:   `{"version":3,"profile_picture":"users/sub-1/profile/avatar.png"}`,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, profiles *profilerepo.MemoryRepository, _ string) {
+				saved, err := profiles.Get(context.Background(), "sub-1", "mentor")
+				if err != nil || saved.ProfilePicture != "users/sub-1/profile/avatar.png" {
+					t.Fatalf("unexpected profile %+v %v", saved, err)
+				}
+			},
+		},
+		{Name: "profile picture URL", Caller: mentorCaller, Body: `{"version":3,"profile_picture":"https://example.com/avatar.png"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "private file as profile picture", Caller: mentorCaller, Body: `{"version":3,"profile_picture":"users/sub-1/cv.pdf"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "another user's profile picture", Caller: mentorCaller, Body: `{"version":3,"profile_picture":"users/sub-2/profile/avatar.png"}`, Status: http.StatusForbidden, Code: errorpackage.CodeFileForbidden},
+		{Name: "no caller", Body: `{"version":3,"bio":"x"}`, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "unknown field", Caller: mentorCaller, Body: `{"version":3,"email":"x@example.com"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing version", Caller: mentorCaller, Body: `{"bio":"x"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "mentee field on a mentor", Caller: mentorCaller, Body: `{"version":3,"goals":"x"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "stale version", Caller: mentorCaller, Body: `{"version":2,"bio":"x"}`, Status: http.StatusConflict, Code: errorpackage.CodeProfileConflict},
+		{
+			Name:   "missing profile",
+			Caller: &entity.IDTokenPayload{Sub: "sub-2", Email: "grace@example.com", CustomRole: "mentor"},
+			Body:   `{"version":1,"bio":"x"}`,
+			Status: http.StatusNotFound,
+			Code:   errorpackage.CodeProfileNotFound,
+		},
+		{
+			Name: "repository failure",
+			Setup: func(profiles *profilerepo.MemoryRepository) {
+				profiles.Fail("Update", errors.New("throughput exceeded"))
+			},
+			Caller: mentorCaller,
+			Body:   `{"version":3,"bio":"x"}`,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
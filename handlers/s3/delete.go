@@ -0,0 +1,44 @@
+package s3
+
+import (
+	"context"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) Delete(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	key := request.QueryStringParameters["key"]
+
+	if err = validator.ValidateKey(key); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Invalid or missing key parameter")
+	}
+
+	if err = ownership.Authorize(payload, key); err != nil {
+		return errorpackage.HandleKeyError(err)
+	}
+
+	_, err = h.S3.DeleteObject(ctx, &awss3.DeleteObjectInput{
+		Bucket: aws.String(h.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errorpackage.HandleS3Error(err)
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersDelete(),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package s3
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestDelete(t *testing.T) {
+	notes := map[string]string{"key": "users/sub-1/notes.txt"}
+	expectDeleted := func(deleted bool) func(*testing.T, *awsapi.MemoryS3, string) {
+		return func(t *testing.T, store *awsapi.MemoryS3, _ string) {
+			if _, ok := store.Object(testBucket, "users/sub-1/notes.txt"); ok == deleted {
+				t.Fatalf("expected deleted=%v", deleted)
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).Delete, []testCase{
+		{Name: "owner", Caller: owner, Query: notes, Status: http.StatusOK, Check: expectDeleted(true)},
+		{Name: "admin", Caller: admin, Query: notes, Status: http.StatusOK, Check: expectDeleted(true)},
+		{Name: "no caller", Query: notes, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing key", Caller: owner, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "someone else's file",
+			Caller: stranger,
+			Query:  notes,
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeFileForbidden,
+			Check:  expectDeleted(false),
+		},
+		{
+			Name:   "someone else's profile picture",
+			Setup:  func(store *awsapi.MemoryS3) { store.Put(testBucket, "users/sub-1/profile/avatar.png", awsapi.Object{}) },
+			Caller: stranger,
+			Query:  map[string]string{"key": "users/sub-1/profile/avatar.png"},
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeFileForbidden,
+		},
+		{
+			Name:   "s3 failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("DeleteObject", errors.New("connection reset")) },
+			Caller: owner,
+			Query:  notes,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
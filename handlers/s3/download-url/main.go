@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/bootstrap"
+	"mentorship-app-backend/handlers/s3"
+)
+
+func main() {
+	handlers := s3.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.DownloadURL, routes.DownloadURLLambdaName)
+}
@@ -0,0 +1,56 @@
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) Download(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	fileName := request.QueryStringParameters["file_name"]
+	if fileName == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "Invalid or missing key parameter")
+	}
+
+	if err = ownership.AuthorizeRead(payload, fileName); err != nil {
+		return errorpackage.HandleKeyError(err)
+	}
+
+	output, err := h.S3.GetObject(ctx, &awss3.GetObjectInput{
+		Bucket: aws.String(h.BucketName),
+		Key:    aws.String(fileName),
+	})
+	if err != nil {
+		return errorpackage.HandleS3Error(err)
+	}
+	defer output.Body.Close()
+
+	fileContent, err := io.ReadAll(output.Body)
+	if err != nil {
+		log.Printf("Failed to read file content: %v", err)
+		return errorpackage.ServerError("Failed to read file content")
+	}
+
+	base64File := base64.StdEncoding.EncodeToString(fileContent)
+
+	return events.APIGatewayProxyResponse{
+		StatusCode:      http.StatusOK,
+		Body:            base64File,
+		IsBase64Encoded: true,
+		Headers:         wrapper.SetHeadersGet(aws.ToString(output.ContentType)),
+	}, nil
+}
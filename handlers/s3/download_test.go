@@ -0,0 +1,42 @@
+package s3
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestDownload(t *testing.T) {
+	notes := map[string]string{"file_name": "users/sub-1/notes.txt"}
+	avatar := map[string]string{"file_name": "users/sub-1/profile/avatar.png"}
+	withAvatar := func(store *awsapi.MemoryS3) {
+		store.Put(testBucket, "users/sub-1/profile/avatar.png", awsapi.Object{Body: []byte("hello"), ContentType: "image/png"})
+	}
+	expectNotes := func(t *testing.T, _ *awsapi.MemoryS3, body string) {
+		if body != "aGVsbG8=" {
+			t.Fatalf("unexpected body %q", body)
+		}
+	}
+
+	runCases(t, (*Handlers).Download, []testCase{
+		{Name: "owner", Caller: owner, Query: notes, Status: http.StatusOK, Check: expectNotes},
+		{Name: "admin", Caller: admin, Query: notes, Status: http.StatusOK, Check: expectNotes},
+		{Name: "no caller", Query: notes, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing name", Caller: owner, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "someone else's file", Caller: stranger, Query: notes, Status: http.StatusForbidden, Code: errorpackage.CodeFileForbidden},
+		{Name: "someone else's profile picture", Setup: withAvatar, Caller: stranger, Query: avatar, Status: http.StatusOK, Check: expectNotes},
+		{Name: "profile picture without a subject", Setup: withAvatar, Caller: noSubject, Query: avatar, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidToken},
+		{Name: "missing file", Caller: owner, Query: map[string]string{"file_name": "users/sub-1/gone.txt"}, Status: http.StatusNotFound, Code: errorpackage.CodeFileNotFound},
+		{
+			Name:   "s3 failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("GetObject", errors.New("connection reset")) },
+			Caller: owner,
+			Query:  notes,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
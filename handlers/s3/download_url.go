@@ -0,0 +1,62 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) DownloadURL(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	fileName := request.QueryStringParameters["file_name"]
+	if err = ownership.AuthorizeRead(payload, fileName); err != nil {
+		return errorpackage.HandleKeyError(err)
+	}
+
+	// Presigning never touches S3, so check the object exists to return 404 now rather than from the URL later.
+	_, err = h.S3.HeadObject(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(h.BucketName),
+		Key:    aws.String(fileName),
+	})
+	if err != nil {
+		return errorpackage.HandleS3Error(err)
+	}
+
+	presigned, err := h.Presign.PresignGetObject(ctx, &awss3.GetObjectInput{
+		Bucket: aws.String(h.BucketName),
+		Key:    aws.String(fileName),
+	})
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to presign download: %s", err.Error()))
+	}
+
+	responseJSON, err := json.Marshal(entity.PresignedURLResponse{
+		URL:       presigned.URL,
+		Method:    presigned.Method,
+		Key:       fileName,
+		ExpiresAt: time.Now().Add(PresignExpiry).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal presigned URL")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Body:       string(responseJSON),
+		Headers:    wrapper.SetHeadersGet(""),
+	}, nil
+}
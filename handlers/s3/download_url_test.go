@@ -0,0 +1,61 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestDownloadURL(t *testing.T) {
+	notes := map[string]string{"file_name": "users/sub-1/notes.txt"}
+
+	runCases(t, (*Handlers).DownloadURL, []testCase{
+		{
+			Name:   "success",
+			Caller: owner,
+			Query:  notes,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *awsapi.MemoryS3, body string) {
+				var presigned entity.PresignedURLResponse
+				if err := json.Unmarshal([]byte(body), &presigned); err != nil {
+					t.Fatalf("body is not valid JSON: %v", err)
+				}
+				if presigned.Key != "users/sub-1/notes.txt" || presigned.Method != http.MethodGet || presigned.URL == "" {
+					t.Fatalf("unexpected response %+v", presigned)
+				}
+			},
+		},
+		{Name: "no caller", Query: notes, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing name", Caller: owner, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "someone else's file", Caller: stranger, Query: notes, Status: http.StatusForbidden, Code: errorpackage.CodeFileForbidden},
+		{
+			Name: "someone else's profile picture",
+			Setup: func(store *awsapi.MemoryS3) {
+				store.Put(testBucket, "users/sub-1/profile/avatar.png", awsapi.Object{Body: []byte("png"), ContentType: "image/png"})
+			},
+			Caller: stranger,
+			Query:  map[string]string{"file_name": "users/sub-1/profile/avatar.png"},
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *awsapi.MemoryS3, body string) {
+				var presigned entity.PresignedURLResponse
+				if err := json.Unmarshal([]byte(body), &presigned); err != nil || presigned.Key != "users/sub-1/profile/avatar.png" || presigned.URL == "" {
+					t.Fatalf("unexpected response %s", body)
+				}
+			},
+		},
+		{Name: "missing file", Caller: owner, Query: map[string]string{"file_name": "users/sub-1/gone.txt"}, Status: http.StatusNotFound, Code: errorpackage.CodeFileNotFound},
+		{
+			Name:   "presign failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("PresignGetObject", errors.New("no credentials")) },
+			Caller: owner,
+			Query:  notes,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package s3
+
+import (
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/config"
+	"time"
+
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	PresignExpiry  = 15 * time.Minute
+	MaxUploadBytes = 25 << 20
+)
+
+// Handlers serves the file endpoints. Every key is scoped to the caller's prefix through the ownership package.
+type Handlers struct {
+	S3         awsapi.S3API
+	Presign    awsapi.PresignAPI
+	BucketName string
+}
+
+// New signs URLs with the Lambda's own credentials, valid for PresignExpiry.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		S3: clients.S3,
+		Presign: awss3.NewPresignClient(clients.S3, func(options *awss3.PresignOptions) {
+			options.Expires = PresignExpiry
+		}),
+		BucketName: cfg.BucketName,
+	}
+}
@@ -0,0 +1,35 @@
+package s3
+
+import (
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+)
+
+const testBucket = "test-bucket"
+
+var (
+	owner    = &entity.IDTokenPayload{Sub: "sub-1", Email: "ada@example.com", CustomRole: "mentee"}
+	stranger = &entity.IDTokenPayload{Sub: "sub-2", Email: "grace@example.com", CustomRole: "mentor"}
+	admin    = &entity.IDTokenPayload{Sub: "sub-3", Email: "ops@example.com", CustomRole: "mentor", Groups: []string{"admin"}}
+	// noSubject has no sub claim to build an ownership prefix from.
+	noSubject = &entity.IDTokenPayload{Email: "old@example.com", CustomRole: "mentee"}
+)
+
+type testCase = handlertest.Case[*awsapi.MemoryS3]
+
+// runCases calls handler once per case against a bucket holding users/sub-1/notes.txt.
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], tests []testCase) {
+	t.Helper()
+	newBucket := func() *awsapi.MemoryS3 {
+		store := awsapi.NewMemoryS3()
+		store.Put(testBucket, "users/sub-1/notes.txt", awsapi.Object{Body: []byte("hello"), ContentType: "text/plain"})
+		return store
+	}
+	handlers := func(store *awsapi.MemoryS3) *Handlers {
+		return &Handlers{S3: store, Presign: store, BucketName: testBucket}
+	}
+	handlertest.Run(t, newBucket, handlers, handler, tests)
+}
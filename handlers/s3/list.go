@@ -0,0 +1,61 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) List(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if payload.Sub == "" {
+		return errorpackage.CodedError(errorpackage.CodeInvalidToken, errorpackage.ErrMissingSubject.Error())
+	}
+
+	log.Printf("S3 bucket name: %s", h.BucketName)
+
+	paginator := awss3.NewListObjectsV2Paginator(h.S3, &awss3.ListObjectsV2Input{
+		Bucket: aws.String(h.BucketName),
+		Prefix: aws.String(ownership.UserPrefix(payload.Sub)),
+	})
+
+	files := []entity.File{}
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return errorpackage.HandleS3Error(err)
+		}
+
+		for _, item := range resp.Contents {
+			files = append(files, entity.File{
+				Key:  aws.ToString(item.Key),
+				Size: aws.ToInt64(item.Size),
+			})
+		}
+	}
+
+	filesJSON, err := json.Marshal(files)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to marshal file list: %s", err.Error()))
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Body:       string(filesJSON),
+		Headers:    wrapper.SetHeadersGet(""),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/wrapper"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/smithy-go"
+)
+
+func TestList(t *testing.T) {
+	expectKeys := func(want ...string) func(*testing.T, *awsapi.MemoryS3, string) {
+		return func(t *testing.T, _ *awsapi.MemoryS3, body string) {
+			var files []entity.File
+			if err := json.Unmarshal([]byte(body), &files); err != nil || files == nil {
+				t.Fatalf("unexpected body %s", body)
+			}
+			if len(files) != len(want) {
+				t.Fatalf("expected %v, got %+v", want, files)
+			}
+			for i, key := range want {
+				if files[i].Key != key {
+					t.Fatalf("expected %v, got %+v", want, files)
+				}
+			}
+		}
+	}
+	withOthers := func(store *awsapi.MemoryS3) {
+		store.Put(testBucket, "users/sub-1/cv.pdf", awsapi.Object{Body: []byte("%PDF-")})
+		store.Put(testBucket, "users/sub-2/secret.txt", awsapi.Object{Body: []byte("no")})
+	}
+
+	runCases(t, (*Handlers).List, []testCase{
+		{Name: "own prefix only", Setup: withOthers, Caller: owner, Status: http.StatusOK, Check: expectKeys("users/sub-1/cv.pdf", "users/sub-1/notes.txt")},
+		{Name: "empty prefix", Caller: stranger, Status: http.StatusOK, Check: expectKeys()},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "token without subject", Caller: noSubject, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidToken},
+		{
+			Name:   "s3 failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("ListObjectsV2", errors.New("connection reset")) },
+			Caller: owner,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "s3 throttling",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("ListObjectsV2", &smithy.GenericAPIError{Code: "SlowDown"}) },
+			Caller: owner,
+			Status: http.StatusTooManyRequests,
+			Code:   errorpackage.CodeRateLimited,
+		},
+	})
+}
+
+func TestListFailureCarriesRequestID(t *testing.T) {
+	store := awsapi.NewMemoryS3()
+	store.Fail("ListObjectsV2", errors.New("connection reset"))
+	handlers := &Handlers{S3: store, Presign: store, BucketName: testBucket}
+
+	request := events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: "req-123"}}
+	response, err := wrapper.Chain(handlers.List, wrapper.RequestID())(wrapper.WithCaller(context.Background(), owner), request)
+	if err != nil || response.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected a 500 envelope, got %d %v", response.StatusCode, err)
+	}
+
+	var envelope errorpackage.Envelope
+	if err = json.Unmarshal([]byte(response.Body), &envelope); err != nil {
+		t.Fatalf("body is not an envelope: %s", response.Body)
+	}
+	if envelope.Code != errorpackage.CodeInternal || envelope.RequestID != "req-123" || strings.Contains(response.Body, "connection reset") {
+		t.Fatalf("unexpected envelope %s", response.Body)
+	}
+}
@@ -0,0 +1,117 @@
+package ownership
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+)
+
+const (
+	AdminGroup  = "admin"
+	usersPrefix = "users/"
+	// profileFolder is the folder under each user's prefix that any signed-in user may read, so the mentor
+	// avatars search returns can be fetched by the mentees browsing them. Only the owner may delete from it.
+	profileFolder = "profile/"
+	// ExportsPrefix holds the data exports. It is outside every user's prefix, so exports never show up among
+	// a user's files or in the next export.
+	ExportsPrefix = "exports/"
+	// ExportRetentionDays is how long an export is kept. The bucket's lifecycle rule deletes it afterwards.
+	ExportRetentionDays = 7
+)
+
+// UserPrefix is the key prefix every object owned by sub lives under.
+func UserPrefix(sub string) string {
+	return usersPrefix + sub + "/"
+}
+
+// ExportPrefix is the key prefix of sub's data exports.
+func ExportPrefix(sub string) string {
+	return ExportsPrefix + sub + "/"
+}
+
+// KeyFor namespaces a client-supplied file name under the owner's prefix. The owner always comes from the
+// verified token, never from the request, so a client cannot write into another user's prefix.
+func KeyFor(sub, fileName string) (string, error) {
+	if sub == "" {
+		return "", errorpackage.ErrMissingSubject
+	}
+	if err := validator.ValidateObjectKey(fileName); err != nil {
+		return "", err
+	}
+	return UserPrefix(sub) + fileName, nil
+}
+
+// ProfilePictureKey namespaces a profile picture under the owner's public-read profile folder.
+func ProfilePictureKey(sub, fileName string) (string, error) {
+	return KeyFor(sub, profileFolder+fileName)
+}
+
+func IsAdmin(payload *entity.IDTokenPayload) bool {
+	for _, group := range payload.Groups {
+		if group == AdminGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize reports whether the caller may delete or privately read key: admins may touch any key, everyone else only
+// keys under their own prefix.
+func Authorize(payload *entity.IDTokenPayload, key string) error {
+	if err := validator.ValidateObjectKey(key); err != nil {
+		return err
+	}
+	if IsAdmin(payload) {
+		return nil
+	}
+	if payload.Sub == "" {
+		return errorpackage.ErrMissingSubject
+	}
+
+	if path.Clean(key) != key || !strings.HasPrefix(key, UserPrefix(payload.Sub)) {
+		return fmt.Errorf("%w: %s", errorpackage.ErrForbiddenKey, key)
+	}
+	return nil
+}
+
+// AuthorizeRead is Authorize for reads. On top of the caller's own keys it allows any signed-in caller to read
+// another user's profile folder.
+func AuthorizeRead(payload *entity.IDTokenPayload, key string) error {
+	err := Authorize(payload, key)
+	if errors.Is(err, errorpackage.ErrForbiddenKey) && isProfileKey(key) {
+		return nil
+	}
+	return err
+}
+
+// AuthorizeProfilePicture checks a profile picture set through PATCH /me. Like the one stored at registration it
+// must be an object key in the caller's own profile folder, so it can be fetched through download-url; URLs and
+// keys in another user's folder are rejected.
+func AuthorizeProfilePicture(sub, key string) error {
+	if sub == "" {
+		return errorpackage.ErrMissingSubject
+	}
+	if err := validator.ValidateObjectKey(key); err != nil {
+		return fmt.Errorf("profile_picture: %w", err)
+	}
+	if !isProfileKey(key) {
+		return fmt.Errorf("profile_picture must be an object key under %s%s", UserPrefix(sub), profileFolder)
+	}
+	if !strings.HasPrefix(key, UserPrefix(sub)+profileFolder) {
+		return fmt.Errorf("%w: %s", errorpackage.ErrForbiddenKey, key)
+	}
+	return nil
+}
+
+func isProfileKey(key string) bool {
+	if path.Clean(key) != key || !strings.HasPrefix(key, usersPrefix) {
+		return false
+	}
+	sub, rest, ok := strings.Cut(strings.TrimPrefix(key, usersPrefix), "/")
+	return ok && sub != "" && strings.HasPrefix(rest, profileFolder) && rest != profileFolder
+}
@@ -0,0 +1,139 @@
+package ownership
+
+import (
+	"errors"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestKeyFor(t *testing.T) {
+	key, err := KeyFor("alice-sub", "avatars/me.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key != "users/alice-sub/avatars/me.png" {
+		t.Fatalf("unexpected key %q", key)
+	}
+
+	for _, fileName := range []string{"", "../bob-sub/me.png", "/users/bob-sub/me.png"} {
+		if _, err = KeyFor("alice-sub", fileName); err == nil {
+			t.Errorf("expected %q to be rejected", fileName)
+		}
+	}
+	if _, err = KeyFor("", "me.png"); !errors.Is(err, errorpackage.ErrMissingSubject) {
+		t.Errorf("expected ErrMissingSubject, got %v", err)
+	}
+}
+
+func TestProfilePictureKey(t *testing.T) {
+	key, err := ProfilePictureKey("alice-sub", "me.png")
+	if err != nil || key != "users/alice-sub/profile/me.png" {
+		t.Fatalf("unexpected key %q, %v", key, err)
+	}
+}
+
+func TestAuthorizeRead(t *testing.T) {
+	alice := &entity.IDTokenPayload{Sub: "alice-sub"}
+
+	tests := []struct {
+		name    string
+		caller  *entity.IDTokenPayload
+		key     string
+		allowed bool
+	}{
+		{"own key", alice, "users/alice-sub/notes.txt", true},
+		{"other user's profile picture", alice, "users/bob-sub/profile/me.png", true},
+		{"other user's private key", alice, "users/bob-sub/notes.txt", false},
+		{"other user's profile folder itself", alice, "users/bob-sub/profile/", false},
+		{"profile folder nested deeper", alice, "users/bob-sub/private/profile/me.png", false},
+		{"traversal out of a profile folder", alice, "users/bob-sub/profile/../notes.txt", false},
+		{"missing subject", &entity.IDTokenPayload{}, "users/bob-sub/profile/me.png", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := AuthorizeRead(tt.caller, tt.key); (err == nil) != tt.allowed {
+				t.Fatalf("expected allowed=%v, got %v", tt.allowed, err)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	alice := &entity.IDTokenPayload{Sub: "alice-sub"}
+	admin := &entity.IDTokenPayload{Sub: "admin-sub", Groups: []string{"support", AdminGroup}}
+
+	tests := []struct {
+		name    string
+		caller  *entity.IDTokenPayload
+		key     string
+		allowed bool
+		wantErr error
+	}{
+		{"own key", alice, "users/alice-sub/me.png", true, nil},
+		{"other user's key", alice, "users/bob-sub/me.png", false, errorpackage.ErrForbiddenKey},
+		{"sub used as a prefix of another sub", alice, "users/alice-sub-2/me.png", false, errorpackage.ErrForbiddenKey},
+		{"un-namespaced legacy key", alice, "me.png", false, errorpackage.ErrForbiddenKey},
+		{"redundant separators", alice, "users/alice-sub//me.png", false, errorpackage.ErrForbiddenKey},
+		{"traversal out of own prefix", alice, "users/alice-sub/../bob-sub/me.png", false, nil},
+		{"missing subject", &entity.IDTokenPayload{}, "users/alice-sub/me.png", false, errorpackage.ErrMissingSubject},
+		{"admin reads other user's key", admin, "users/bob-sub/me.png", true, nil},
+		{"other user's profile picture", alice, "users/bob-sub/profile/me.png", false, errorpackage.ErrForbiddenKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.caller, tt.key)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected the key to be rejected")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeProfilePicture(t *testing.T) {
+	tests := []struct {
+		name    string
+		sub     string
+		key     string
+		allowed bool
+		wantErr error
+	}{
+		{"own profile folder", "alice-sub", "users/alice-sub/profile/me.png", true, nil},
+		{"other user's profile folder", "alice-sub", "users/bob-sub/profile/me.png", false, errorpackage.ErrForbiddenKey},
+		{"sub used as a prefix of another sub", "alice-sub", "users/alice-sub-2/profile/me.png", false, errorpackage.ErrForbiddenKey},
+		{"own private file", "alice-sub", "users/alice-sub/me.png", false, nil},
+		{"https URL", "alice-sub", "https://example.com/me.png", false, nil},
+		{"traversal out of own profile folder", "alice-sub", "users/alice-sub/profile/../me.png", false, nil},
+		{"missing subject", "", "users/alice-sub/profile/me.png", false, errorpackage.ErrMissingSubject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AuthorizeProfilePicture(tt.sub, tt.key)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
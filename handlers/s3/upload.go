@@ -0,0 +1,66 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func (h *Handlers) Upload(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var uploadReq entity.UploadRequest
+	err = json.Unmarshal([]byte(request.Body), &uploadReq)
+	if err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request payload")
+	}
+
+	key, err := ownership.KeyFor(payload.Sub, uploadReq.Filename)
+	if err != nil {
+		return errorpackage.HandleKeyError(err)
+	}
+
+	fileData, err := base64.StdEncoding.DecodeString(uploadReq.FileContent)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "Invalid file data")
+	}
+	contentType := request.Headers["x-file-content-type"]
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_, err = h.S3.PutObject(ctx, &awss3.PutObjectInput{
+		Bucket:      aws.String(h.BucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(fileData),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return errorpackage.HandleS3Error(err)
+	}
+
+	responseJSON, err := json.Marshal(entity.UploadResponse{Key: key})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal upload response")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Body:       string(responseJSON),
+		Headers:    wrapper.SetHeadersPost(),
+	}, nil
+}
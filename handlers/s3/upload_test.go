@@ -0,0 +1,58 @@
+package s3
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestUpload(t *testing.T) {
+	body := `{"file_name":"cv.pdf","file_content":"JVBERi0="}`
+
+	runCases(t, (*Handlers).Upload, []testCase{
+		{
+			Name:    "success",
+			Caller:  owner,
+			Headers: map[string]string{"x-file-content-type": "application/pdf"},
+			Body:    body,
+			Status:  http.StatusOK,
+			Check: func(t *testing.T, store *awsapi.MemoryS3, body string) {
+				object, ok := store.Object(testBucket, "users/sub-1/cv.pdf")
+				if !ok || string(object.Body) != "%PDF-" || object.ContentType != "application/pdf" {
+					t.Fatalf("unexpected object %+v", object)
+				}
+				if body != `{"key":"users/sub-1/cv.pdf"}` {
+					t.Fatalf("response does not name the key: %s", body)
+				}
+			},
+		},
+		{
+			Name:   "default content type",
+			Caller: owner,
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, store *awsapi.MemoryS3, _ string) {
+				if object, _ := store.Object(testBucket, "users/sub-1/cv.pdf"); object.ContentType != "application/octet-stream" {
+					t.Fatalf("unexpected content type %q", object.ContentType)
+				}
+			},
+		},
+		{Name: "no caller", Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: owner, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "missing name", Caller: owner, Body: `{"file_content":"aGk="}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "path traversal", Caller: owner, Body: `{"file_name":"../sub-2/cv.pdf","file_content":"aGk="}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "token without subject", Caller: noSubject, Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidToken},
+		{Name: "invalid content", Caller: owner, Body: `{"file_name":"cv.pdf","file_content":"%%%"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "s3 failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("PutObject", errors.New("connection reset")) },
+			Caller: owner,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
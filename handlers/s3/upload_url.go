@@ -0,0 +1,77 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// UploadURL returns a presigned PUT URL so the client uploads straight to S3. Content-Type and
+// Content-Length are signed into the URL, so S3 rejects a body of a different type or size than was approved.
+func (h *Handlers) UploadURL(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	var uploadReq entity.PresignUploadRequest
+	err = json.Unmarshal([]byte(request.Body), &uploadReq)
+	if err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request payload")
+	}
+
+	if err = validator.ValidatePresignUploadRequest(&uploadReq, MaxUploadBytes); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	key, err := ownership.KeyFor(payload.Sub, uploadReq.Filename)
+	if err != nil {
+		return errorpackage.HandleKeyError(err)
+	}
+
+	presigned, err := h.Presign.PresignPutObject(ctx, &awss3.PutObjectInput{
+		Bucket:        aws.String(h.BucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(uploadReq.ContentType),
+		ContentLength: aws.Int64(uploadReq.ContentLength),
+	})
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to presign upload: %s", err.Error()))
+	}
+
+	headers := map[string]string{}
+	for name, values := range presigned.SignedHeader {
+		if name == "Host" || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+
+	responseJSON, err := json.Marshal(entity.PresignedURLResponse{
+		URL:       presigned.URL,
+		Method:    presigned.Method,
+		Headers:   headers,
+		Key:       key,
+		ExpiresAt: time.Now().Add(PresignExpiry).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal presigned URL")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Body:       string(responseJSON),
+		Headers:    wrapper.SetHeadersPost(),
+	}, nil
+}
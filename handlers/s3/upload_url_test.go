@@ -0,0 +1,53 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func TestUploadURL(t *testing.T) {
+	body := `{"file_name":"cv.pdf","content_type":"application/pdf","content_length":1024}`
+
+	runCases(t, (*Handlers).UploadURL, []testCase{
+		{
+			Name:   "success",
+			Caller: owner,
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, _ *awsapi.MemoryS3, body string) {
+				var presigned entity.PresignedURLResponse
+				if err := json.Unmarshal([]byte(body), &presigned); err != nil {
+					t.Fatalf("body is not valid JSON: %v", err)
+				}
+				if presigned.Key != "users/sub-1/cv.pdf" || presigned.Method != http.MethodPut || presigned.URL == "" {
+					t.Fatalf("unexpected response %+v", presigned)
+				}
+				if presigned.Headers["Content-Type"] != "application/pdf" || presigned.Headers["Content-Length"] != "1024" {
+					t.Fatalf("size and type must be signed into the URL: %v", presigned.Headers)
+				}
+				if _, ok := presigned.Headers["Host"]; ok {
+					t.Fatal("the Host header is set by the client and must not be returned")
+				}
+			},
+		},
+		{Name: "no caller", Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "malformed body", Caller: owner, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "content type not allowed", Caller: owner, Body: `{"file_name":"run.sh","content_type":"text/x-sh","content_length":10}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "too large", Caller: owner, Body: `{"file_name":"cv.pdf","content_type":"application/pdf","content_length":26214401}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "token without subject", Caller: noSubject, Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeInvalidToken},
+		{
+			Name:   "presign failure",
+			Setup:  func(store *awsapi.MemoryS3) { store.Fail("PresignPutObject", errors.New("no credentials")) },
+			Caller: owner,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
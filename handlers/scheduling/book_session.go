@@ -0,0 +1,104 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/mentorship"
+	"mentorship-app-backend/repository/profile"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) BookSession(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if payload.CustomRole != validator.RoleMentee {
+		return errorpackage.ClientError(http.StatusForbidden, "Only mentees can book sessions")
+	}
+	menteeID := profile.UserID(payload)
+
+	var req entity.BookSessionRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	start, err := validator.ValidateBookSessionRequest(&req, menteeID)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	now := time.Now()
+	mentored, err := h.hasAcceptedMentorship(ctx, menteeID, req.MentorID, now)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to list mentorship requests: %s", err.Error()))
+	}
+	if !mentored {
+		return errorpackage.ClientError(http.StatusForbidden, "You can only book sessions with mentors who accepted your request")
+	}
+
+	availability, err := h.Schedules.GetAvailability(ctx, req.MentorID)
+	if err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeAvailabilityNotFound, "Mentor has not published availability")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to get availability: %s", err.Error()))
+	}
+
+	end, err := schedulingrepo.CheckSlot(availability, start, now)
+	if err != nil {
+		if errors.Is(err, errorpackage.ErrSlotOutsideAvailability) {
+			return errorpackage.CodedError(errorpackage.CodeSlotUnavailable, err.Error())
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to check slot: %s", err.Error()))
+	}
+
+	booking := &entity.Booking{
+		StartTime: schedulingrepo.SlotKey(start),
+		EndTime:   schedulingrepo.SlotKey(end),
+		MentorID:  req.MentorID,
+		MenteeID:  menteeID,
+		Note:      req.Note,
+	}
+	if err = h.Schedules.Book(ctx, booking); err != nil {
+		if errors.Is(err, errorpackage.ErrSlotAlreadyBooked) {
+			return errorpackage.CodedError(errorpackage.CodeSlotTaken, err.Error())
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to book session: %s", err.Error()))
+	}
+
+	responseJSON, err := json.Marshal(booking)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal booking")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusCreated,
+		Headers:    wrapper.SetHeadersPost(),
+		Body:       string(responseJSON),
+	}, nil
+}
+
+func (h *Handlers) hasAcceptedMentorship(ctx context.Context, menteeID, mentorID string, now time.Time) (bool, error) {
+	existing, err := h.Requests.ListByMentee(ctx, menteeID)
+	if err != nil {
+		return false, err
+	}
+	for i := range existing {
+		if existing[i].MentorID == mentorID && mentorship.EffectiveStatus(&existing[i], now) == entity.MentorshipStatusAccepted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,98 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/repository/mentorship"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+func TestBookSession(t *testing.T) {
+	start := slotIn(2, 10)
+	bodyAt := func(start time.Time) string {
+		return `{"mentor_id":"` + mentorID + `","start_time":"` + start.Format(time.RFC3339) + `","note":"Career chat"}`
+	}
+
+	runCases(t, (*Handlers).BookSession, []testCase{
+		{
+			Name:   "success",
+			Caller: menteeCaller,
+			Body:   bodyAt(start),
+			Status: http.StatusCreated,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				for _, owner := range []string{mentorID, menteeID} {
+					booking, err := env.schedules.GetBooking(context.Background(), owner, schedulingrepo.SlotKey(start))
+					if err != nil || booking.EndTime != schedulingrepo.SlotKey(start.Add(time.Hour)) || booking.BookingID == "" {
+						t.Fatalf("unexpected booking for %s: %+v %v", owner, booking, err)
+					}
+				}
+			},
+		},
+		{Name: "no caller", Body: bodyAt(start), Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "mentor cannot book", Caller: mentorCaller, Body: bodyAt(start), Status: http.StatusForbidden, Code: errorpackage.CodeForbidden},
+		{Name: "malformed body", Caller: menteeCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "invalid start", Caller: menteeCaller, Body: `{"mentor_id":"` + mentorID + `","start_time":"soon"}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "not mentored",
+			Caller: &entity.IDTokenPayload{Sub: "sub-linus", Email: "linus@example.com", CustomRole: "mentee"},
+			Body:   bodyAt(start),
+			Status: http.StatusForbidden,
+			Code:   errorpackage.CodeForbidden,
+		},
+		{Name: "outside availability", Caller: menteeCaller, Body: bodyAt(slotIn(2, 20)), Status: http.StatusBadRequest, Code: errorpackage.CodeSlotUnavailable},
+		{Name: "misaligned", Caller: menteeCaller, Body: bodyAt(start.Add(30 * time.Minute)), Status: http.StatusBadRequest, Code: errorpackage.CodeSlotUnavailable},
+		{Name: "already booked", Setup: func(env *testEnv) { book(env, start) }, Caller: menteeCaller, Body: bodyAt(start), Status: http.StatusConflict, Code: errorpackage.CodeSlotTaken},
+		{
+			Name: "overlaps a booking made on another grid",
+			Setup: func(env *testEnv) {
+				_ = env.schedules.Book(context.Background(), &entity.Booking{
+					StartTime: schedulingrepo.SlotKey(start.Add(30 * time.Minute)),
+					EndTime:   schedulingrepo.SlotKey(start.Add(90 * time.Minute)),
+					MentorID:  mentorID,
+					MenteeID:  "sub-linus",
+				})
+			},
+			Caller: menteeCaller,
+			Body:   bodyAt(start),
+			Status: http.StatusConflict,
+			Code:   errorpackage.CodeSlotTaken,
+		},
+		{
+			Name: "no availability",
+			Setup: func(env *testEnv) {
+				env.requests = mentorship.NewMemoryRepository(entity.MentorshipRequest{
+					RequestID: "accepted",
+					MentorID:  "sub-linus",
+					MenteeID:  menteeID,
+					Status:    entity.MentorshipStatusAccepted,
+				})
+			},
+			Caller: menteeCaller,
+			Body:   `{"mentor_id":"sub-linus","start_time":"` + start.Format(time.RFC3339) + `"}`,
+			Status: http.StatusNotFound,
+			Code:   errorpackage.CodeAvailabilityNotFound,
+		},
+		{
+			Name:   "book failure",
+			Setup:  func(env *testEnv) { env.schedules.Fail("Book", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Body:   bodyAt(start),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "mentorship lookup failure",
+			Setup:  func(env *testEnv) { env.requests.Fail("ListByMentee", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Body:   bodyAt(start),
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
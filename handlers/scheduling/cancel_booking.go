@@ -0,0 +1,55 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) CancelBooking(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	startParam := request.QueryStringParameters["start_time"]
+	if startParam == "" {
+		return errorpackage.ClientError(http.StatusBadRequest, "start_time query parameter is required")
+	}
+	start, err := time.Parse(time.RFC3339, startParam)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, "start_time must be an RFC 3339 timestamp")
+	}
+	if !start.After(time.Now()) {
+		return errorpackage.ClientError(http.StatusBadRequest, "Past sessions cannot be cancelled")
+	}
+
+	// The caller's own copy is looked up first, so a user can only cancel bookings they take part in.
+	booking, err := h.Schedules.GetBooking(ctx, profile.UserID(payload), schedulingrepo.SlotKey(start))
+	if err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeBookingNotFound, "Booking not found")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to get booking: %s", err.Error()))
+	}
+
+	if err = h.Schedules.Cancel(ctx, booking); err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeBookingNotFound, "Booking not found")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to cancel booking: %s", err.Error()))
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersDelete(),
+		Body:       `{"message": "Booking cancelled successfully"}`,
+	}, nil
+}
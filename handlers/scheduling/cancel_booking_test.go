@@ -0,0 +1,67 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+func TestCancelBooking(t *testing.T) {
+	start := slotIn(2, 10)
+	booked := func(env *testEnv) { book(env, start) }
+	at := map[string]string{"start_time": start.Format(time.RFC3339)}
+
+	runCases(t, (*Handlers).CancelBooking, []testCase{
+		{
+			Name:   "mentee cancels both copies",
+			Setup:  booked,
+			Caller: menteeCaller,
+			Query:  at,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				for _, owner := range []string{mentorID, menteeID} {
+					if _, err := env.schedules.GetBooking(context.Background(), owner, schedulingrepo.SlotKey(start)); !errors.Is(err, errorpackage.ErrNoSuchKey) {
+						t.Fatalf("expected %s's copy to be deleted, got %v", owner, err)
+					}
+				}
+			},
+		},
+		{Name: "mentor cancels", Setup: booked, Caller: mentorCaller, Query: at, Status: http.StatusOK},
+		{Name: "no caller", Query: at, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "missing start", Caller: menteeCaller, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid start", Caller: menteeCaller, Query: map[string]string{"start_time": "soon"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "past session",
+			Caller: menteeCaller,
+			Query:  map[string]string{"start_time": time.Now().Add(-time.Hour).Format(time.RFC3339)},
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{Name: "no booking", Caller: menteeCaller, Query: at, Status: http.StatusNotFound, Code: errorpackage.CodeBookingNotFound},
+		{
+			Name:   "outsider cannot cancel",
+			Setup:  booked,
+			Caller: &entity.IDTokenPayload{Sub: "sub-linus", Email: "linus@example.com", CustomRole: "mentee"},
+			Query:  at,
+			Status: http.StatusNotFound,
+			Code:   errorpackage.CodeBookingNotFound,
+		},
+		{
+			Name: "cancel failure",
+			Setup: func(env *testEnv) {
+				booked(env)
+				env.schedules.Fail("Cancel", errors.New("throughput exceeded"))
+			},
+			Caller: menteeCaller,
+			Query:  at,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	defaultSlotRange = 14 * 24 * time.Hour
+	maxSlotRange     = 31 * 24 * time.Hour
+)
+
+func (h *Handlers) GetAvailability(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	mentorID := request.QueryStringParameters["mentor_id"]
+	if mentorID == "" {
+		if payload.CustomRole != validator.RoleMentor {
+			return errorpackage.ClientError(http.StatusBadRequest, "mentor_id query parameter is required")
+		}
+		mentorID = profile.UserID(payload)
+	}
+
+	from, to, err := slotRange(request.QueryStringParameters["from"], request.QueryStringParameters["to"])
+	if err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	availability, err := h.Schedules.GetAvailability(ctx, mentorID)
+	if err != nil {
+		if errorpackage.IsDynamoDBNotFoundError(err) {
+			return errorpackage.CodedError(errorpackage.CodeAvailabilityNotFound, "Mentor has not published availability")
+		}
+		return errorpackage.ServerError(fmt.Sprintf("Failed to get availability: %s", err.Error()))
+	}
+
+	// A session that started up to one session length before from can still run into the range.
+	booked, err := h.Schedules.ListBookings(ctx, mentorID, schedulingrepo.SlotKey(from.Add(-schedulingrepo.MaxSessionLength)), schedulingrepo.SlotKey(to))
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to list bookings: %s", err.Error()))
+	}
+
+	slots, err := schedulingrepo.OpenSlots(availability, from, to, booked)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to compute open slots: %s", err.Error()))
+	}
+	if slots == nil {
+		slots = []string{}
+	}
+
+	responseJSON, err := json.Marshal(entity.AvailabilityResponse{Availability: availability, OpenSlots: slots})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal availability")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersGet(""),
+		Body:       string(responseJSON),
+	}, nil
+}
+
+func slotRange(fromParam, toParam string) (time.Time, time.Time, error) {
+	from := time.Now().UTC()
+	if fromParam != "" {
+		parsed, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("from must be an RFC 3339 timestamp")
+		}
+		if parsed.After(from) {
+			from = parsed.UTC()
+		}
+	}
+
+	to := from.Add(defaultSlotRange)
+	if toParam != "" {
+		parsed, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to must be an RFC 3339 timestamp")
+		}
+		to = parsed.UTC()
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be after from")
+	}
+	if to.Sub(from) > maxSlotRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("range must not exceed %d days", int(maxSlotRange.Hours()/24))
+	}
+	return from, to, nil
+}
@@ -0,0 +1,76 @@
+package scheduling
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+func TestGetAvailability(t *testing.T) {
+	day := map[string]string{
+		"mentor_id": mentorID,
+		"from":      slotIn(2, 0).Format(time.RFC3339),
+		"to":        slotIn(3, 0).Format(time.RFC3339),
+	}
+	expectSlots := func(want int, missing time.Time) func(*testing.T, *testEnv, string) {
+		return func(t *testing.T, _ *testEnv, body string) {
+			var result entity.AvailabilityResponse
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("body is not valid JSON: %v", err)
+			}
+			if len(result.OpenSlots) != want {
+				t.Fatalf("expected %d open slots, got %v", want, result.OpenSlots)
+			}
+			for _, slot := range result.OpenSlots {
+				if slot == schedulingrepo.SlotKey(missing) {
+					t.Fatalf("booked slot %s is reported as open", slot)
+				}
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).GetAvailability, []testCase{
+		{Name: "mentee views a mentor", Caller: menteeCaller, Query: day, Status: http.StatusOK, Check: expectSlots(10, time.Time{})},
+		{
+			Name:   "booked slots are hidden",
+			Setup:  func(env *testEnv) { book(env, slotIn(2, 10)) },
+			Caller: menteeCaller,
+			Query:  day,
+			Status: http.StatusOK,
+			Check:  expectSlots(9, slotIn(2, 10)),
+		},
+		{
+			Name:   "mentor defaults to own schedule",
+			Caller: mentorCaller,
+			Query:  map[string]string{"from": day["from"], "to": day["to"]},
+			Status: http.StatusOK,
+			Check:  expectSlots(10, time.Time{}),
+		},
+		{Name: "no caller", Query: day, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "mentee must name a mentor", Caller: menteeCaller, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid range", Caller: menteeCaller, Query: map[string]string{"mentor_id": mentorID, "to": "later"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "no availability", Caller: menteeCaller, Query: map[string]string{"mentor_id": "sub-linus"}, Status: http.StatusNotFound, Code: errorpackage.CodeAvailabilityNotFound},
+		{
+			Name:   "bookings failure",
+			Setup:  func(env *testEnv) { env.schedules.Fail("ListBookings", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Query:  day,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+		{
+			Name:   "availability failure",
+			Setup:  func(env *testEnv) { env.schedules.Fail("GetAvailability", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Query:  day,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/repository/mentorship"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+// Handlers serves the availability and booking endpoints.
+type Handlers struct {
+	Requests  mentorship.Repository
+	Schedules schedulingrepo.Repository
+}
+
+// New needs the requests table as well, since only an accepted mentorship request may book a session.
+func New(cfg config.Config, clients config.Clients) *Handlers {
+	return &Handlers{
+		Requests:  mentorship.NewDynamoRepository(clients.DynamoDB, cfg.MentorshipRequestsDDBTableName),
+		Schedules: schedulingrepo.NewDynamoRepository(clients.DynamoDB, cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName),
+	}
+}
@@ -0,0 +1,104 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/handlertest"
+	"mentorship-app-backend/repository/mentorship"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+const (
+	mentorEmail = "grace@example.com"
+	menteeEmail = "ada@example.com"
+
+	mentorID = "sub-mentor"
+	menteeID = "sub-mentee"
+)
+
+var (
+	mentorCaller = &entity.IDTokenPayload{Sub: mentorID, Email: mentorEmail, CustomRole: "mentor"}
+	menteeCaller = &entity.IDTokenPayload{Sub: menteeID, Email: menteeEmail, CustomRole: "mentee"}
+)
+
+type testEnv struct {
+	requests  *mentorship.MemoryRepository
+	schedules *schedulingrepo.MemoryRepository
+}
+
+type testCase = handlertest.Case[*testEnv]
+
+// runCases calls handler once per case. Every case starts with the mentee mentored by the mentor, and the mentor
+// available in hourly sessions from 08:00 to 18:00 UTC every day.
+func runCases(t *testing.T, handler handlertest.Method[*Handlers], tests []testCase) {
+	t.Helper()
+	newEnv := func() *testEnv {
+		env := &testEnv{
+			requests: mentorship.NewMemoryRepository(entity.MentorshipRequest{
+				RequestID: "accepted",
+				MentorID:  mentorID,
+				MenteeID:  menteeID,
+				Status:    entity.MentorshipStatusAccepted,
+			}),
+			schedules: schedulingrepo.NewMemoryRepository(),
+		}
+		_ = env.schedules.PutAvailability(context.Background(), dailyAvailability())
+		return env
+	}
+	handlers := func(env *testEnv) *Handlers { return &Handlers{Requests: env.requests, Schedules: env.schedules} }
+	handlertest.Run(t, newEnv, handlers, handler, tests)
+}
+
+func dailyAvailability() *entity.Availability {
+	availability := &entity.Availability{MentorID: mentorID, TimeZone: "UTC", SessionMinutes: 60}
+	for _, day := range []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"} {
+		availability.Weekly = append(availability.Weekly, entity.WeeklyWindow{Weekday: day, Start: "08:00", End: "18:00"})
+	}
+	return availability
+}
+
+// slotIn returns hour:00 UTC the given number of days from today, a valid slot for hours 8 to 17.
+func slotIn(days, hour int) time.Time {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+}
+
+func book(env *testEnv, start time.Time) {
+	_ = env.schedules.Book(context.Background(), &entity.Booking{
+		StartTime: schedulingrepo.SlotKey(start),
+		EndTime:   schedulingrepo.SlotKey(start.Add(time.Hour)),
+		MentorID:  mentorID,
+		MenteeID:  menteeID,
+	})
+}
+
+func TestSlotRange(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "explicit", from: now.Add(time.Hour).Format(time.RFC3339), to: now.Add(48 * time.Hour).Format(time.RFC3339)},
+		{name: "past from is clamped to now", from: now.Add(-48 * time.Hour).Format(time.RFC3339), to: now.Add(time.Hour).Format(time.RFC3339)},
+		{name: "bad from", from: "tomorrow", wantErr: true},
+		{name: "bad to", to: "later", wantErr: true},
+		{name: "reversed", from: now.Add(48 * time.Hour).Format(time.RFC3339), to: now.Add(time.Hour).Format(time.RFC3339), wantErr: true},
+		{name: "too long", to: now.Add(40 * 24 * time.Hour).Format(time.RFC3339), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		from, to, err := slotRange(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		if !tt.wantErr && (from.Before(now) || !to.After(from)) {
+			t.Fatalf("%s: unexpected range %s - %s", tt.name, from, to)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/handlers/bootstrap"
+	"mentorship-app-backend/handlers/scheduling"
+)
+
+func main() {
+	handlers := scheduling.New(bootstrap.Init(), bootstrap.Clients())
+
+	bootstrap.Start(handlers.ListBookings, routes.ListBookingsLambdaName)
+}
@@ -0,0 +1,62 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) ListBookings(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	now := time.Now()
+	from, to := schedulingrepo.SlotKey(now), schedulingrepo.SlotKey(now.Add(schedulingrepo.BookingHorizon))
+	if value := request.QueryStringParameters["from"]; value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, "from must be an RFC 3339 timestamp")
+		}
+		from = schedulingrepo.SlotKey(parsed)
+	}
+	if value := request.QueryStringParameters["to"]; value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errorpackage.ClientError(http.StatusBadRequest, "to must be an RFC 3339 timestamp")
+		}
+		to = schedulingrepo.SlotKey(parsed)
+	}
+	if to < from {
+		return errorpackage.ClientError(http.StatusBadRequest, "to must be after from")
+	}
+
+	bookings, err := h.Schedules.ListBookings(ctx, profile.UserID(payload), from, to)
+	if err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to list bookings: %s", err.Error()))
+	}
+	if bookings == nil {
+		bookings = []entity.Booking{}
+	}
+
+	responseJSON, err := json.Marshal(map[string]interface{}{"bookings": bookings})
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal bookings")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersGet(""),
+		Body:       string(responseJSON),
+	}, nil
+}
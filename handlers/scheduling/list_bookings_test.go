@@ -0,0 +1,69 @@
+package scheduling
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	schedulingrepo "mentorship-app-backend/repository/scheduling"
+)
+
+func TestListBookings(t *testing.T) {
+	booked := func(env *testEnv) {
+		book(env, slotIn(3, 9))
+		book(env, slotIn(2, 10))
+	}
+	expect := func(want ...time.Time) func(*testing.T, *testEnv, string) {
+		return func(t *testing.T, _ *testEnv, body string) {
+			var result struct {
+				Bookings []entity.Booking `json:"bookings"`
+			}
+			if err := json.Unmarshal([]byte(body), &result); err != nil || result.Bookings == nil {
+				t.Fatalf("unexpected body %s", body)
+			}
+			if len(result.Bookings) != len(want) {
+				t.Fatalf("expected %d bookings, got %+v", len(want), result.Bookings)
+			}
+			for i, start := range want {
+				if result.Bookings[i].StartTime != schedulingrepo.SlotKey(start) {
+					t.Fatalf("expected %v, got %+v", want, result.Bookings)
+				}
+			}
+		}
+	}
+
+	runCases(t, (*Handlers).ListBookings, []testCase{
+		{Name: "mentee sees upcoming in order", Setup: booked, Caller: menteeCaller, Status: http.StatusOK, Check: expect(slotIn(2, 10), slotIn(3, 9))},
+		{Name: "mentor sees the same sessions", Setup: booked, Caller: mentorCaller, Status: http.StatusOK, Check: expect(slotIn(2, 10), slotIn(3, 9))},
+		{
+			Name:   "range",
+			Setup:  booked,
+			Caller: menteeCaller,
+			Query:  map[string]string{"from": slotIn(3, 0).Format(time.RFC3339), "to": slotIn(4, 0).Format(time.RFC3339)},
+			Status: http.StatusOK,
+			Check:  expect(slotIn(3, 9)),
+		},
+		{Name: "empty list", Caller: menteeCaller, Status: http.StatusOK, Check: expect()},
+		{Name: "no caller", Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "invalid from", Caller: menteeCaller, Query: map[string]string{"from": "now"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{Name: "invalid to", Caller: menteeCaller, Query: map[string]string{"to": "later"}, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "reversed range",
+			Caller: menteeCaller,
+			Query:  map[string]string{"from": slotIn(4, 0).Format(time.RFC3339), "to": slotIn(3, 0).Format(time.RFC3339)},
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "repository failure",
+			Setup:  func(env *testEnv) { env.schedules.Fail("ListBookings", errors.New("throughput exceeded")) },
+			Caller: menteeCaller,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+	"mentorship-app-backend/handlers/wrapper"
+	"mentorship-app-backend/repository/profile"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func (h *Handlers) SetAvailability(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	payload, err := wrapper.Caller(ctx)
+	if err != nil {
+		return errorpackage.ClientError(http.StatusUnauthorized, err.Error())
+	}
+
+	if payload.CustomRole != validator.RoleMentor {
+		return errorpackage.ClientError(http.StatusForbidden, "Only mentors can publish availability")
+	}
+
+	var req entity.SetAvailabilityRequest
+	if err = json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return errorpackage.CodedError(errorpackage.CodeInvalidRequestBody, "Invalid request body")
+	}
+
+	if err = validator.ValidateAvailability(&req); err != nil {
+		return errorpackage.ClientError(http.StatusBadRequest, err.Error())
+	}
+
+	availability := &entity.Availability{
+		MentorID:       profile.UserID(payload),
+		TimeZone:       req.TimeZone,
+		SessionMinutes: req.SessionMinutes,
+		Weekly:         req.Weekly,
+		Exceptions:     req.Exceptions,
+	}
+	if err = h.Schedules.PutAvailability(ctx, availability); err != nil {
+		return errorpackage.ServerError(fmt.Sprintf("Failed to save availability: %s", err.Error()))
+	}
+
+	responseJSON, err := json.Marshal(availability)
+	if err != nil {
+		return errorpackage.ServerError("Failed to marshal availability")
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers:    wrapper.SetHeadersPut(),
+		Body:       string(responseJSON),
+	}, nil
+}
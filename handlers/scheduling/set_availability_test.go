@@ -0,0 +1,75 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+)
+
+func TestSetAvailability(t *testing.T) {
+	body := `{"time_zone":"Europe/Istanbul","session_minutes":30,"weekly":[{"weekday":"monday","start":"09:00","end":"11:00"}]}`
+
+	runCases(t, (*Handlers).SetAvailability, []testCase{
+		{
+			Name:   "success",
+			Caller: mentorCaller,
+			Body:   body,
+			Status: http.StatusOK,
+			Check: func(t *testing.T, env *testEnv, _ string) {
+				saved, err := env.schedules.GetAvailability(context.Background(), mentorID)
+				if err != nil || saved.TimeZone != "Europe/Istanbul" || len(saved.Weekly) != 1 || saved.UpdatedAt == "" {
+					t.Fatalf("unexpected availability %+v %v", saved, err)
+				}
+			},
+		},
+		{Name: "no caller", Body: body, Status: http.StatusUnauthorized, Code: errorpackage.CodeUnauthorized},
+		{Name: "mentee cannot publish", Caller: menteeCaller, Body: body, Status: http.StatusForbidden, Code: errorpackage.CodeForbidden},
+		{Name: "malformed body", Caller: mentorCaller, Body: "{", Status: http.StatusBadRequest, Code: errorpackage.CodeInvalidRequestBody},
+		{Name: "unknown time zone", Caller: mentorCaller, Body: `{"time_zone":"Mars/Olympus","session_minutes":30}`, Status: http.StatusBadRequest, Code: errorpackage.CodeValidationFailed},
+		{
+			Name:   "window shorter than a session",
+			Caller: mentorCaller,
+			Body:   `{"time_zone":"UTC","session_minutes":60,"weekly":[{"weekday":"monday","start":"09:00","end":"09:30"}]}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "window off the slot grid",
+			Caller: mentorCaller,
+			Body:   `{"time_zone":"UTC","session_minutes":30,"weekly":[{"weekday":"monday","start":"09:10","end":"10:10"}]}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "overlapping weekly windows",
+			Caller: mentorCaller,
+			Body:   `{"time_zone":"UTC","session_minutes":30,"weekly":[{"weekday":"monday","start":"09:00","end":"11:00"},{"weekday":"monday","start":"10:00","end":"12:00"}]}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "adjacent windows and windows on other days",
+			Caller: mentorCaller,
+			Body:   `{"time_zone":"UTC","session_minutes":30,"weekly":[{"weekday":"monday","start":"09:00","end":"11:00"},{"weekday":"monday","start":"11:00","end":"12:00"},{"weekday":"tuesday","start":"10:00","end":"12:00"}],"exceptions":[{"date":"2025-01-14","start":"08:00","end":"10:00","available":true},{"date":"2025-01-13","start":"10:00","end":"10:30"}]}`,
+			Status: http.StatusOK,
+		},
+		{
+			Name:   "available exception overlapping the weekly windows",
+			Caller: mentorCaller,
+			Body:   `{"time_zone":"UTC","session_minutes":30,"weekly":[{"weekday":"monday","start":"09:00","end":"11:00"}],"exceptions":[{"date":"2025-01-13","start":"10:00","end":"12:00","available":true}]}`,
+			Status: http.StatusBadRequest,
+			Code:   errorpackage.CodeValidationFailed,
+		},
+		{
+			Name:   "repository failure",
+			Setup:  func(env *testEnv) { env.schedules.Fail("PutAvailability", errors.New("throughput exceeded")) },
+			Caller: mentorCaller,
+			Body:   body,
+			Status: http.StatusInternalServerError,
+			Code:   errorpackage.CodeInternal,
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mentorship-app-backend/entity"
+)
+
+const maxObjectKeyLength = 1024
+
+var allowedUploadContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+func ValidateObjectKey(key string) error {
+	if key == "" {
+		return errors.New("file_name is required")
+	}
+	if len(key) > maxObjectKeyLength {
+		return fmt.Errorf("file_name must be at most %d characters long", maxObjectKeyLength)
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, "..") {
+		return errors.New("file_name must be a relative path without '..' segments")
+	}
+	return nil
+}
+
+func ValidatePresignUploadRequest(req *entity.PresignUploadRequest, maxBytes int64) error {
+	if err := ValidateObjectKey(req.Filename); err != nil {
+		return err
+	}
+	if !allowedUploadContentTypes[req.ContentType] {
+		return errors.New("content_type must be one of image/jpeg, image/png, image/webp, application/pdf")
+	}
+	if req.ContentLength <= 0 || req.ContentLength > maxBytes {
+		return fmt.Errorf("content_length must be between 1 and %d bytes", maxBytes)
+	}
+	return nil
+}
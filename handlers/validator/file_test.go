@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"testing"
+
+	"mentorship-app-backend/entity"
+)
+
+func TestValidatePresignUploadRequest(t *testing.T) {
+	const maxBytes = 1024
+
+	tests := []struct {
+		name    string
+		req     entity.PresignUploadRequest
+		wantErr bool
+	}{
+		{"valid", entity.PresignUploadRequest{Filename: "avatars/me.png", ContentType: "image/png", ContentLength: 512}, false},
+		{"missing file name", entity.PresignUploadRequest{ContentType: "image/png", ContentLength: 512}, true},
+		{"path traversal", entity.PresignUploadRequest{Filename: "../secret", ContentType: "image/png", ContentLength: 512}, true},
+		{"absolute path", entity.PresignUploadRequest{Filename: "/root.png", ContentType: "image/png", ContentLength: 512}, true},
+		{"disallowed content type", entity.PresignUploadRequest{Filename: "run.sh", ContentType: "text/x-shellscript", ContentLength: 512}, true},
+		{"empty file", entity.PresignUploadRequest{Filename: "me.png", ContentType: "image/png"}, true},
+		{"too large", entity.PresignUploadRequest{Filename: "me.png", ContentType: "image/png", ContentLength: maxBytes + 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePresignUploadRequest(&tt.req, maxBytes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePresignUploadRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+)
+
+const (
+	defaultJWKSCacheTTL    = time.Hour
+	minJWKSRefreshInterval = time.Minute
+)
+
+// KeySource resolves the RSA public key that signed a token from the token's kid header.
+type KeySource interface {
+	PublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error)
+}
+
+// StaticKeySource serves a fixed set of keys, e.g. a locally generated key pair in tests.
+type StaticKeySource map[string]*rsa.PublicKey
+
+func (s StaticKeySource) PublicKey(_ context.Context, kid string) (*rsa.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, errorpackage.ErrUnknownSigningKey
+	}
+	return key, nil
+}
+
+// JWKSKeySource fetches the user pool's JSON Web Key Set and caches it for the lifetime of the
+// Lambda container. An unknown kid triggers a refetch so key rotation is picked up without a redeploy.
+type JWKSKeySource struct {
+	url        string
+	httpClient *http.Client
+	ttl        time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+func NewJWKSKeySource(url string, httpClient *http.Client, ttl time.Duration) *JWKSKeySource {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	if ttl <= 0 {
+		ttl = defaultJWKSCacheTTL
+	}
+	return &JWKSKeySource{url: url, httpClient: httpClient, ttl: ttl}
+}
+
+func (s *JWKSKeySource) PublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	age := time.Since(s.fetchedAt)
+	s.mu.RUnlock()
+
+	if ok && age < s.ttl {
+		return key, nil
+	}
+	if !ok && s.keys != nil && age < minJWKSRefreshInterval {
+		return nil, errorpackage.ErrUnknownSigningKey
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		if ok {
+			// Serve the stale key rather than failing every request while the JWKS endpoint is unreachable.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok = s.keys[kid]
+	if !ok {
+		return nil, errorpackage.ErrUnknownSigningKey
+	}
+	return key, nil
+}
+
+func (s *JWKSKeySource) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS from %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS from %s: status %d", s.url, resp.StatusCode)
+	}
+
+	var set jsonWebKeySet
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := parseRSAPublicKey(jwk)
+		if err != nil {
+			return fmt.Errorf("failed to parse JWKS key %s: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func parseRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	e := new(big.Int).SetBytes(eBytes)
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("exponent out of range")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: int(e.Int64())}, nil
+}
@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	"mentorship-app-backend/entity"
+)
+
+const maxMentorshipMessageLength = 1000
+
+func ValidateMentorshipRequest(req *entity.CreateMentorshipRequest, callerID string) error {
+	if req.MentorID == "" {
+		return errors.New("mentor_id is required")
+	}
+	if req.MentorID == callerID {
+		return errors.New("you cannot request mentorship from yourself")
+	}
+	if len(req.Message) > maxMentorshipMessageLength {
+		return fmt.Errorf("message must be at most %d characters long", maxMentorshipMessageLength)
+	}
+	return nil
+}
+
+func ValidateMentorshipStatus(status string) error {
+	switch entity.MentorshipStatus(status) {
+	case entity.MentorshipStatusPending, entity.MentorshipStatusAccepted, entity.MentorshipStatusDeclined,
+		entity.MentorshipStatusWithdrawn, entity.MentorshipStatusExpired:
+		return nil
+	default:
+		return errors.New("invalid status; must be one of pending, accepted, declined, withdrawn, expired")
+	}
+}
@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"mentorship-app-backend/entity"
+)
+
+const (
+	RoleMentor = "mentor"
+	RoleMentee = "mentee"
+
+	maxBioLength      = 1000
+	maxGoalsLength    = 1000
+	maxListItems      = 20
+	maxListItemLength = 50
+	maxYearsOfExp     = 70
+	maxSocialLinks    = 5
+)
+
+var seniorityLevels = map[string]bool{
+	"junior":    true,
+	"mid":       true,
+	"senior":    true,
+	"lead":      true,
+	"principal": true,
+	"executive": true,
+}
+
+func ValidateProfileUpdate(req *entity.ProfileUpdateRequest, role string) error {
+	if req.Version == nil {
+		return errors.New("version is required")
+	}
+	if *req.Version < 0 {
+		return errors.New("version must not be negative")
+	}
+
+	if req.Name == nil && req.Bio == nil && req.ProfilePicture == nil && req.Languages == nil && req.SocialLinks == nil &&
+		req.Skills == nil && req.Industry == nil && req.Seniority == nil && req.YearsOfExperience == nil && req.Available == nil &&
+		req.Goals == nil && req.Interests == nil {
+		return errors.New("at least one field must be provided")
+	}
+
+	if req.Name != nil {
+		if err := ValidateName(strings.TrimSpace(*req.Name)); err != nil {
+			return err
+		}
+	}
+	if req.Bio != nil && len(*req.Bio) > maxBioLength {
+		return fmt.Errorf("bio must be at most %d characters long", maxBioLength)
+	}
+	if req.Languages != nil {
+		if err := validateStringList("languages", *req.Languages); err != nil {
+			return err
+		}
+	}
+	if req.SocialLinks != nil {
+		if err := validateSocialLinks(*req.SocialLinks); err != nil {
+			return err
+		}
+	}
+
+	switch role {
+	case RoleMentor:
+		if req.Goals != nil || req.Interests != nil {
+			return errors.New("goals and interests can only be set on mentee profiles")
+		}
+		return validateMentorFields(req)
+	case RoleMentee:
+		if req.Skills != nil || req.Industry != nil || req.Seniority != nil || req.YearsOfExperience != nil || req.Available != nil {
+			return errors.New("skills, industry, seniority, years_of_experience and available can only be set on mentor profiles")
+		}
+		return validateMenteeFields(req)
+	default:
+		return ValidateRole(role)
+	}
+}
+
+func ValidateSeniority(seniority string) error {
+	if !seniorityLevels[seniority] {
+		return errors.New("invalid seniority; must be one of junior, mid, senior, lead, principal, executive")
+	}
+	return nil
+}
+
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.New("must be a valid https URL")
+	}
+	return nil
+}
+
+func validateMentorFields(req *entity.ProfileUpdateRequest) error {
+	if req.Skills != nil {
+		if err := validateStringList("skills", *req.Skills); err != nil {
+			return err
+		}
+	}
+	if req.Industry != nil && (len(strings.TrimSpace(*req.Industry)) == 0 || len(*req.Industry) > maxListItemLength) {
+		return fmt.Errorf("industry must be between 1 and %d characters long", maxListItemLength)
+	}
+	if req.Seniority != nil {
+		if err := ValidateSeniority(*req.Seniority); err != nil {
+			return err
+		}
+	}
+	if req.YearsOfExperience != nil && (*req.YearsOfExperience < 0 || *req.YearsOfExperience > maxYearsOfExp) {
+		return fmt.Errorf("years_of_experience must be between 0 and %d", maxYearsOfExp)
+	}
+	return nil
+}
+
+func validateMenteeFields(req *entity.ProfileUpdateRequest) error {
+	if req.Goals != nil && len(*req.Goals) > maxGoalsLength {
+		return fmt.Errorf("goals must be at most %d characters long", maxGoalsLength)
+	}
+	if req.Interests != nil {
+		if err := validateStringList("interests", *req.Interests); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSocialLinks(links map[string]string) error {
+	if len(links) > maxSocialLinks {
+		return fmt.Errorf("social_links must contain at most %d entries", maxSocialLinks)
+	}
+	for name, link := range links {
+		if len(strings.TrimSpace(name)) == 0 || len(name) > maxListItemLength {
+			return fmt.Errorf("social_links names must be between 1 and %d characters long", maxListItemLength)
+		}
+		if err := ValidateURL(link); err != nil {
+			return fmt.Errorf("social_links.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateStringList(field string, values []string) error {
+	if len(values) > maxListItems {
+		return fmt.Errorf("%s must contain at most %d entries", field, maxListItems)
+	}
+	for _, value := range values {
+		if len(strings.TrimSpace(value)) == 0 || len(value) > maxListItemLength {
+			return fmt.Errorf("%s entries must be between 1 and %d characters long", field, maxListItemLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/repository/scheduling"
+)
+
+const (
+	minSessionMinutes    = int(scheduling.SlotGranularity / time.Minute)
+	maxSessionMinutes    = int(scheduling.MaxSessionLength / time.Minute)
+	maxWeeklyWindows     = 50
+	maxExceptions        = 100
+	maxBookingNoteLength = 500
+)
+
+func ValidateAvailability(req *entity.SetAvailabilityRequest) error {
+	if req.TimeZone == "" {
+		return errors.New("time_zone is required")
+	}
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		return fmt.Errorf("time_zone %q is not a valid IANA time zone", req.TimeZone)
+	}
+
+	if req.SessionMinutes < minSessionMinutes || req.SessionMinutes > maxSessionMinutes || req.SessionMinutes%minSessionMinutes != 0 {
+		return fmt.Errorf("session_minutes must be a multiple of %d between %d and %d", minSessionMinutes, minSessionMinutes, maxSessionMinutes)
+	}
+
+	if len(req.Weekly) > maxWeeklyWindows {
+		return fmt.Errorf("weekly can contain at most %d windows", maxWeeklyWindows)
+	}
+	weekly := map[time.Weekday][]clockRange{}
+	for _, window := range req.Weekly {
+		weekday, err := scheduling.ParseWeekday(window.Weekday)
+		if err != nil {
+			return err
+		}
+		r, err := validateClockRange(window.Start, window.End, req.SessionMinutes)
+		if err != nil {
+			return fmt.Errorf("weekly window on %s: %w", window.Weekday, err)
+		}
+		if r.overlapsAny(weekly[weekday]) {
+			return fmt.Errorf("weekly window on %s: %s-%s overlaps another window that day", window.Weekday, window.Start, window.End)
+		}
+		weekly[weekday] = append(weekly[weekday], r)
+	}
+
+	if len(req.Exceptions) > maxExceptions {
+		return fmt.Errorf("exceptions can contain at most %d entries", maxExceptions)
+	}
+	// Available exceptions add windows to their date, so they must not overlap each other or that weekday's
+	// windows either.
+	added := map[string][]clockRange{}
+	for _, exception := range req.Exceptions {
+		date, err := scheduling.ParseDate(exception.Date)
+		if err != nil {
+			return err
+		}
+		if exception.Start == "" && exception.End == "" && !exception.Available {
+			continue
+		}
+		r, err := validateClockRange(exception.Start, exception.End, req.SessionMinutes)
+		if err != nil {
+			return fmt.Errorf("exception on %s: %w", exception.Date, err)
+		}
+		if !exception.Available {
+			continue
+		}
+		if r.overlapsAny(weekly[date.Weekday()]) || r.overlapsAny(added[exception.Date]) {
+			return fmt.Errorf("exception on %s: %s-%s overlaps another window that day", exception.Date, exception.Start, exception.End)
+		}
+		added[exception.Date] = append(added[exception.Date], r)
+	}
+
+	return nil
+}
+
+func ValidateBookSessionRequest(req *entity.BookSessionRequest, callerID string) (time.Time, error) {
+	if req.MentorID == "" {
+		return time.Time{}, errors.New("mentor_id is required")
+	}
+	if req.MentorID == callerID {
+		return time.Time{}, errors.New("you cannot book a session with yourself")
+	}
+	if len(req.Note) > maxBookingNoteLength {
+		return time.Time{}, fmt.Errorf("note must be at most %d characters long", maxBookingNoteLength)
+	}
+
+	start, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return time.Time{}, errors.New("start_time must be an RFC 3339 timestamp")
+	}
+	return start, nil
+}
+
+// clockRange is a window's start and end in minutes after midnight.
+type clockRange struct {
+	start, end int
+}
+
+func (r clockRange) overlapsAny(others []clockRange) bool {
+	for _, other := range others {
+		if r.start < other.end && r.end > other.start {
+			return true
+		}
+	}
+	return false
+}
+
+func validateClockRange(start, end string, sessionMinutes int) (clockRange, error) {
+	startHour, startMinute, err := scheduling.ParseClock(start)
+	if err != nil {
+		return clockRange{}, err
+	}
+	endHour, endMinute, err := scheduling.ParseClock(end)
+	if err != nil {
+		return clockRange{}, err
+	}
+
+	r := clockRange{start: startHour*60 + startMinute, end: endHour*60 + endMinute}
+	if r.start%minSessionMinutes != 0 || r.end%minSessionMinutes != 0 {
+		return clockRange{}, fmt.Errorf("window %s-%s must start and end on a multiple of %d minutes", start, end, minSessionMinutes)
+	}
+	if r.end-r.start < sessionMinutes {
+		return clockRange{}, fmt.Errorf("window %s-%s must be at least one session long", start, end)
+	}
+	return r, nil
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/entity"
+)
+
+const (
+	signingAlgorithm = "RS256"
+	idTokenUse       = "id"
+	clockSkew        = 30 * time.Second
+)
+
+// TokenVerifier checks Cognito ID tokens cryptographically: RS256 signature against the user pool's keys,
+// issuer, audience, token_use and expiry. With a denylist it also rejects tokens revoked by a sign-out.
+type TokenVerifier struct {
+	keys     KeySource
+	denylist Denylist
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// Denylist reports whether a token that verified has since been revoked.
+type Denylist interface {
+	Revoked(ctx context.Context, payload *entity.IDTokenPayload) (bool, error)
+}
+
+func NewTokenVerifier(cfg config.Config, keys KeySource) (*TokenVerifier, error) {
+	issuer, err := issuerFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CognitoClientID == "" {
+		return nil, fmt.Errorf("cognito client ID is not configured")
+	}
+
+	return &TokenVerifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: cfg.CognitoClientID,
+		now:      time.Now,
+	}, nil
+}
+
+// WithDenylist makes the verifier consult denylist after a token's signature and claims check out. A lookup that
+// fails rejects the token: a signed-out session must not come back because the table was unreachable.
+func (v *TokenVerifier) WithDenylist(denylist Denylist) *TokenVerifier {
+	v.denylist = denylist
+	return v
+}
+
+// NewCognitoTokenVerifier builds a verifier backed by the user pool's published JWKS.
+func NewCognitoTokenVerifier(cfg config.Config) (*TokenVerifier, error) {
+	issuer, err := issuerFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenVerifier(cfg, NewJWKSKeySource(issuer+"/.well-known/jwks.json", nil, 0))
+}
+
+// IssuerFromPoolArn turns arn:aws:cognito-idp:<region>:<account>:userpool/<pool-id> into the
+// https://cognito-idp.<region>.amazonaws.com/<pool-id> issuer Cognito writes into its tokens.
+func IssuerFromPoolArn(poolArn string) (string, error) {
+	arnParts := strings.Split(poolArn, ":")
+	if len(arnParts) != 6 || arnParts[2] != "cognito-idp" {
+		return "", fmt.Errorf("invalid cognito user pool ARN: %q", poolArn)
+	}
+
+	resourceParts := strings.Split(arnParts[5], "/")
+	if len(resourceParts) != 2 || resourceParts[0] != "userpool" || resourceParts[1] == "" {
+		return "", fmt.Errorf("invalid cognito user pool ARN: %q", poolArn)
+	}
+
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", arnParts[3], resourceParts[1]), nil
+}
+
+// issuerFor honours a Cognito endpoint override: local stand-ins issue tokens as <endpoint>/<pool-id>.
+func issuerFor(cfg config.Config) (string, error) {
+	issuer, err := IssuerFromPoolArn(cfg.CognitoPoolArn)
+	if err != nil || cfg.Endpoints.Cognito == "" {
+		return issuer, err
+	}
+	return strings.TrimSuffix(cfg.Endpoints.Cognito, "/") + issuer[strings.LastIndex(issuer, "/"):], nil
+}
+
+func (v *TokenVerifier) VerifyAuthorizationHeader(ctx context.Context, authHeader string) (*entity.IDTokenPayload, error) {
+	idToken, err := ValidateAuthorizationHeader(authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return v.VerifyIDToken(ctx, idToken)
+}
+
+func (v *TokenVerifier) VerifyIDToken(ctx context.Context, idToken string) (*entity.IDTokenPayload, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errorpackage.ErrInvalidTokenFormat
+	}
+
+	var header entity.TokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errorpackage.ErrInvalidTokenFormat
+	}
+	if header.Algorithm != signingAlgorithm {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", errorpackage.ErrInvalidTokenSignature, header.Algorithm)
+	}
+
+	key, err := v.keys.PublicKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errorpackage.ErrInvalidTokenFormat
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errorpackage.ErrInvalidTokenSignature
+	}
+
+	var payload entity.IDTokenPayload
+	if err = decodeSegment(parts[1], &payload); err != nil {
+		return nil, errorpackage.ErrInvalidTokenFormat
+	}
+
+	if err = v.validateClaims(&payload); err != nil {
+		return nil, err
+	}
+	if err = validateProfileClaims(&payload); err != nil {
+		return nil, err
+	}
+
+	if v.denylist != nil {
+		revoked, err := v.denylist.Revoked(ctx, &payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, errorpackage.ErrTokenRevoked
+		}
+	}
+
+	return &payload, nil
+}
+
+func (v *TokenVerifier) validateClaims(payload *entity.IDTokenPayload) error {
+	if payload.Issuer != v.issuer {
+		return fmt.Errorf("%w: unexpected issuer", errorpackage.ErrInvalidTokenClaims)
+	}
+	if payload.Audience != v.audience {
+		return fmt.Errorf("%w: unexpected audience", errorpackage.ErrInvalidTokenClaims)
+	}
+	if payload.TokenUse != idTokenUse {
+		return fmt.Errorf("%w: token_use must be %q", errorpackage.ErrInvalidTokenClaims, idTokenUse)
+	}
+	if payload.ExpiresAt == 0 || v.now().After(time.Unix(payload.ExpiresAt, 0).Add(clockSkew)) {
+		return errorpackage.ErrTokenExpired
+	}
+	return nil
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,259 @@
+package validator
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/entity"
+)
+
+const (
+	testPoolArn  = "arn:aws:cognito-idp:us-east-1:123456789012:userpool/us-east-1_TestPool"
+	testIssuer   = "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_TestPool"
+	testClientID = "test-client-id"
+	testKeyID    = "test-key"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal token segment: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+
+	signingInput := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"sub":            "0b8f0c3e-1111-2222-3333-444455556666",
+		"email":          "mentor@example.com",
+		"email_verified": true,
+		"custom:role":    "mentor",
+		"iss":            testIssuer,
+		"aud":            testClientID,
+		"token_use":      "id",
+		"iat":            now.Add(-time.Minute).Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func newTestVerifier(t *testing.T, key *rsa.PrivateKey, now time.Time) *TokenVerifier {
+	t.Helper()
+	verifier, err := NewTokenVerifier(config.Config{CognitoPoolArn: testPoolArn, CognitoClientID: testClientID},
+		StaticKeySource{testKeyID: &key.PublicKey})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	verifier.now = func() time.Time { return now }
+	return verifier
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	key := generateKey(t)
+	otherKey := generateKey(t)
+	header := map[string]interface{}{"alg": "RS256", "kid": testKeyID}
+
+	with := func(field string, value interface{}) map[string]interface{} {
+		claims := validClaims(now)
+		if value == nil {
+			delete(claims, field)
+		} else {
+			claims[field] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid token", token: signToken(t, key, header, validClaims(now))},
+		{name: "signed by another key", token: signToken(t, otherKey, header, validClaims(now)), wantErr: errorpackage.ErrInvalidTokenSignature},
+		{name: "unknown key id", token: signToken(t, key, map[string]interface{}{"alg": "RS256", "kid": "rotated"}, validClaims(now)), wantErr: errorpackage.ErrUnknownSigningKey},
+		{name: "unsupported algorithm", token: signToken(t, key, map[string]interface{}{"alg": "HS256", "kid": testKeyID}, validClaims(now)), wantErr: errorpackage.ErrInvalidTokenSignature},
+		{name: "wrong issuer", token: signToken(t, key, header, with("iss", "https://cognito-idp.us-east-1.amazonaws.com/other")), wantErr: errorpackage.ErrInvalidTokenClaims},
+		{name: "wrong audience", token: signToken(t, key, header, with("aud", "other-client")), wantErr: errorpackage.ErrInvalidTokenClaims},
+		{name: "access token", token: signToken(t, key, header, with("token_use", "access")), wantErr: errorpackage.ErrInvalidTokenClaims},
+		{name: "expired", token: signToken(t, key, header, with("exp", now.Add(-time.Hour).Unix())), wantErr: errorpackage.ErrTokenExpired},
+		{name: "missing expiry", token: signToken(t, key, header, with("exp", nil)), wantErr: errorpackage.ErrTokenExpired},
+		{name: "missing email", token: signToken(t, key, header, with("email", nil)), wantErr: errorpackage.ErrEmailNotFound},
+		{name: "malformed", token: "not-a-jwt", wantErr: errorpackage.ErrInvalidTokenFormat},
+	}
+
+	verifier := newTestVerifier(t, key, now)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := verifier.VerifyIDToken(context.Background(), tt.token)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("expected token to verify, got %v", err)
+				}
+				if payload.Email != "mentor@example.com" || payload.CustomRole != "mentor" {
+					t.Fatalf("unexpected payload: %+v", payload)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenRejectsTamperedPayload(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	key := generateKey(t)
+	token := signToken(t, key, map[string]interface{}{"alg": "RS256", "kid": testKeyID}, validClaims(now))
+
+	forged := validClaims(now)
+	forged["email"] = "attacker@example.com"
+	raw, _ := json.Marshal(forged)
+
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(raw) + "." + parts[2]
+
+	_, err := newTestVerifier(t, key, now).VerifyIDToken(context.Background(), tampered)
+	if !errors.Is(err, errorpackage.ErrInvalidTokenSignature) {
+		t.Fatalf("expected signature error, got %v", err)
+	}
+}
+
+type denylistFunc func(payload *entity.IDTokenPayload) (bool, error)
+
+func (f denylistFunc) Revoked(_ context.Context, payload *entity.IDTokenPayload) (bool, error) {
+	return f(payload)
+}
+
+func TestVerifyIDTokenConsultsDenylist(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	key := generateKey(t)
+	claims := validClaims(now)
+	claims["jti"] = "id-jti"
+	claims["origin_jti"] = "origin-jti"
+	token := signToken(t, key, map[string]interface{}{"alg": "RS256", "kid": testKeyID}, claims)
+	errUnreachable := errors.New("table unreachable")
+
+	tests := []struct {
+		name     string
+		denylist denylistFunc
+		wantErr  error
+	}{
+		{name: "not revoked", denylist: func(*entity.IDTokenPayload) (bool, error) { return false, nil }},
+		{
+			name: "revoked sign-in",
+			denylist: func(payload *entity.IDTokenPayload) (bool, error) {
+				return payload.OriginTokenID == "origin-jti", nil
+			},
+			wantErr: errorpackage.ErrTokenRevoked,
+		},
+		{
+			name:     "lookup fails",
+			denylist: func(*entity.IDTokenPayload) (bool, error) { return false, errUnreachable },
+			wantErr:  errUnreachable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := newTestVerifier(t, key, now).WithDenylist(tt.denylist).VerifyIDToken(context.Background(), token)
+			if tt.wantErr == nil {
+				if err != nil || payload.TokenID != "id-jti" {
+					t.Fatalf("expected token to verify with its jti, got %+v %v", payload, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJWKSKeySourceCachesKeys(t *testing.T) {
+	key := generateKey(t)
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			Kid: testKeyID,
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		}}})
+	}))
+	defer server.Close()
+
+	source := NewJWKSKeySource(server.URL, server.Client(), time.Hour)
+	for i := 0; i < 3; i++ {
+		got, err := source.PublicKey(context.Background(), testKeyID)
+		if err != nil {
+			t.Fatalf("failed to resolve key: %v", err)
+		}
+		if got.N.Cmp(key.PublicKey.N) != 0 || got.E != key.PublicKey.E {
+			t.Fatal("resolved key does not match the published key")
+		}
+	}
+
+	if _, err := source.PublicKey(context.Background(), "unknown"); !errors.Is(err, errorpackage.ErrUnknownSigningKey) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Fatalf("expected JWKS to be fetched once, got %d", n)
+	}
+}
+
+func TestIssuerFromPoolArn(t *testing.T) {
+	issuer, err := IssuerFromPoolArn(testPoolArn)
+	if err != nil || issuer != testIssuer {
+		t.Fatalf("unexpected issuer %q (err %v)", issuer, err)
+	}
+	if _, err = IssuerFromPoolArn("arn:aws:s3:::bucket"); err == nil {
+		t.Fatal("expected error for non-cognito ARN")
+	}
+}
+
+func TestIssuerForEndpointOverride(t *testing.T) {
+	cfg := config.Config{CognitoPoolArn: testPoolArn, Endpoints: config.EndpointsConfig{Cognito: "http://localhost:9229/"}}
+	issuer, err := issuerFor(cfg)
+	if err != nil || issuer != "http://localhost:9229/us-east-1_TestPool" {
+		t.Fatalf("unexpected issuer %q (err %v)", issuer, err)
+	}
+}
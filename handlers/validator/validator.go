@@ -60,8 +60,16 @@ func ValidatePassword(password string) error {
 	return nil
 }
 
+// ValidateTOTPCode checks the shape of an authenticator app code; Cognito checks its value.
+func ValidateTOTPCode(code string) error {
+	if !regexp.MustCompile(`^\d{6}$`).MatchString(code) {
+		return errors.New("code must be the 6 digits shown by the authenticator app")
+	}
+	return nil
+}
+
 func ValidateRole(role string) error {
-	if role != "mentor" && role != "mentee" {
+	if role != RoleMentor && role != RoleMentee {
 		return errors.New("invalid role; must be either 'mentor' or 'mentee'")
 	}
 	return nil
@@ -97,7 +105,24 @@ func ValidateAuthorizationHeader(authHeader string) (string, error) {
 	return idToken, nil
 }
 
+// DecodeAndValidateIDToken only decodes the payload and does not check the signature. Use it for tokens
+// that were just issued by Cognito; anything supplied by a client goes through TokenVerifier.
 func DecodeAndValidateIDToken(idToken string) (*entity.IDTokenPayload, error) {
+	payload, err := DecodeIDToken(idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateProfileClaims(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// DecodeIDToken is DecodeAndValidateIDToken for callers that only need the token's identity, not the profile
+// claims.
+func DecodeIDToken(idToken string) (*entity.IDTokenPayload, error) {
 	parts := strings.Split(idToken, ".")
 	if len(parts) != 3 {
 		return nil, errorpackage.ErrInvalidTokenFormat
@@ -113,12 +138,18 @@ func DecodeAndValidateIDToken(idToken string) (*entity.IDTokenPayload, error) {
 		return nil, errors.New("failed to unmarshal ID token payload")
 	}
 
+	return &payload, nil
+}
+
+func validateProfileClaims(payload *entity.IDTokenPayload) error {
 	if payload.Email == "" {
-		return nil, errorpackage.ErrEmailNotFound
+		return errorpackage.ErrEmailNotFound
+	}
+	if payload.Sub == "" {
+		return errorpackage.ErrMissingSubject
 	}
 	if payload.CustomRole == "" {
-		return nil, errors.New("custom:role attribute is missing in the token")
+		return errorpackage.ErrMissingRole
 	}
-
-	return &payload, nil
+	return nil
 }
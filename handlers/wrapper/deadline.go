@@ -0,0 +1,46 @@
+package wrapper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	// HandlerHeadroom is kept back from the Lambda deadline for the middlewares that run after the handler.
+	HandlerHeadroom = 2 * time.Second
+	// defaultHandlerTimeout applies when the context carries no deadline, e.g. outside the Lambda runtime.
+	defaultHandlerTimeout = 13 * time.Second
+)
+
+// Deadline gives the handler a context that expires headroom before the Lambda does, so a slow dependency is
+// cut off while there is still time to answer. A handler that failed because that deadline passed is reported
+// as a 504 rather than a generic 500 or a gateway error.
+func Deadline(headroom time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			deadline, ok := ctx.Deadline()
+			if ok {
+				deadline = deadline.Add(-headroom)
+			} else {
+				deadline = time.Now().Add(defaultHandlerTimeout)
+			}
+
+			handlerCtx, cancel := context.WithDeadline(ctx, deadline)
+			defer cancel()
+
+			response, err := next(handlerCtx, request)
+			if errors.Is(handlerCtx.Err(), context.DeadlineExceeded) && (response.StatusCode == 0 || response.StatusCode >= http.StatusInternalServerError) {
+				log.Printf("handler exceeded its deadline: %v", err)
+				return errorpackage.GatewayTimeout()
+			}
+			return response, err
+		}
+	}
+}
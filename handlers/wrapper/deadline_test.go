@@ -0,0 +1,61 @@
+package wrapper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestDeadlineLeavesHeadroom(t *testing.T) {
+	lambdaDeadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), lambdaDeadline)
+	defer cancel()
+
+	var handlerDeadline time.Time
+	handler := func(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		handlerDeadline, _ = ctx.Deadline()
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	}
+
+	if _, err := Chain(handler, Deadline(5*time.Second))(ctx, events.APIGatewayProxyRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !handlerDeadline.Equal(lambdaDeadline.Add(-5 * time.Second)) {
+		t.Fatalf("handler deadline %s does not leave 5s before %s", handlerDeadline, lambdaDeadline)
+	}
+}
+
+func TestDeadlineExceededReturnsGatewayTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	slow := func(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		<-ctx.Done()
+		return errorpackage.ServerError(ctx.Err().Error())
+	}
+
+	response, err := Chain(slow, Deadline(10*time.Millisecond))(ctx, events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected a clean 504, got %d %v", response.StatusCode, err)
+	}
+}
+
+func TestDeadlineKeepsClientErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	handler := func(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		<-ctx.Done()
+		return errorpackage.ClientError(http.StatusBadRequest, "bad input")
+	}
+
+	response, _ := Chain(handler, Deadline(10*time.Millisecond))(ctx, events.APIGatewayProxyRequest{})
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected the client error to pass through, got %d", response.StatusCode)
+	}
+}
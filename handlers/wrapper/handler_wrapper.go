@@ -1,64 +1,98 @@
 package wrapper
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
-	"mentorship-app-backend/components/errorpackage"
 	"mentorship-app-backend/components/notifier"
-	"mentorship-app-backend/components/secrets"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/slack-go/slack"
 )
 
-var (
-	slackWebhookARN = os.Getenv("SLACK_WEBHOOK_SECRET_ARN")
-	environment     = os.Getenv("ENVIRONMENT")
-)
+var environment = os.Getenv("ENVIRONMENT")
 
-func HandlerWrapper(handler func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error), baseChannel, handlerName string) func(events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	return func(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-		response, err := handler(request)
+// HandlerWrapper builds the standard chain every Lambda runs behind. Recovery sits inside Logging and Notify so
+// a panic is still logged and alerted as a 500. Extra middlewares, such as Authenticate, run innermost.
+func HandlerWrapper(handler Handler, notify notifier.Notifier, baseChannel, handlerName string, extra ...Middleware) Handler {
+	middlewares := []Middleware{
+		Logging(handlerName),
+		Notify(notify, baseChannel, handlerName),
+		RequestID(),
+		Recovery(handlerName),
+		CORS(),
+		Deadline(HandlerHeadroom),
+	}
+	return Chain(handler, append(middlewares, extra...)...)
+}
 
-		slackToken, slackErr := secrets.GetSecretValue(slackWebhookARN)
-		if slackErr != nil {
-			log.Printf("Failed to retrieve Slack webhook token: %v", slackErr)
-			return errorpackage.ServerError(fmt.Sprintf("Internal server error: %v", slackErr))
-		}
+const (
+	// notifyFlushBudget is the most a response waits for notifications still being sent. A healthy backend
+	// answers well within it; a slow one costs the notification rather than the response.
+	notifyFlushBudget = 200 * time.Millisecond
+	// notifyFlushMargin is kept back from the Lambda deadline, for handlers that finish close to it.
+	notifyFlushMargin = 500 * time.Millisecond
+)
 
-		var message, level, channel string
-		var fields []slack.AttachmentField
+// Notify reports the outcome of every call, successes to the base channel and failures to its -alerts
+// counterpart. Client errors are warnings, everything else that failed is an error. A notification that cannot
+// be delivered is logged; the handler's response is returned as is. Lambda freezes the container once the
+// handler returns, so Notify waits for notifications still being sent, but never longer than notifyFlushBudget
+// and never into the last notifyFlushMargin before the deadline.
+func Notify(notify notifier.Notifier, baseChannel, handlerName string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
 
-		switch {
-		case response.StatusCode >= 200 && response.StatusCode < 300:
-			channel = getEnvironmentChannel(baseChannel)
-			message = fmt.Sprintf("%s executed successfully", handlerName)
-			level = "info"
-			fields = []slack.AttachmentField{
-				{Title: "Handler", Value: handlerName, Short: true},
-				{Title: "Status", Value: "Success", Short: true},
-				{Title: "Environment", Value: environment, Short: true},
-				{Title: "Response", Value: response.Body, Short: true},
+			notification := notifier.Notification{
+				Fields: []notifier.Field{
+					{Title: "Handler", Value: handlerName, Short: true},
+					{Title: "Environment", Value: environment, Short: true},
+				},
 			}
-		default:
-			channel = getEnvironmentChannel(baseChannel + "-alerts")
-			message = fmt.Sprintf("%s execution failed", handlerName)
-			level = "error"
-			fields = []slack.AttachmentField{
-				{Title: "Handler", Value: handlerName, Short: true},
-				{Title: "Status", Value: "Failure", Short: true},
-				{Title: "Environment", Value: environment, Short: true},
+
+			switch {
+			case response.StatusCode >= 200 && response.StatusCode < 300:
+				notification.Channel = getEnvironmentChannel(baseChannel)
+				notification.Message = fmt.Sprintf("%s executed successfully", handlerName)
+				notification.Level = notifier.LevelInfo
+				notification.Fields = append(notification.Fields,
+					notifier.Field{Title: "Status", Value: "Success", Short: true},
+					notifier.Field{Title: "Response", Value: response.Body, Short: true},
+				)
+			default:
+				notification.Channel = getEnvironmentChannel(baseChannel + "-alerts")
+				notification.Message = fmt.Sprintf("%s execution failed", handlerName)
+				notification.Level = notifier.LevelError
+				if response.StatusCode >= 400 && response.StatusCode < 500 && err == nil {
+					notification.Level = notifier.LevelWarning
+				}
+				notification.Fields = append(notification.Fields, notifier.Field{Title: "Status", Value: "Failure", Short: true})
+				if err != nil {
+					notification.Fields = append(notification.Fields, notifier.Field{Title: "Error", Value: err.Error()})
+				} else {
+					notification.Fields = append(notification.Fields, notifier.Field{Title: "Response", Value: response.Body})
+				}
 			}
-			if err != nil {
-				fields = append(fields, slack.AttachmentField{Title: "Error", Value: err.Error(), Short: false})
+
+			if notifyErr := notify.Notify(ctx, notification); notifyErr != nil {
+				log.Printf("%s: failed to send notification: %v", handlerName, notifyErr)
 			}
-		}
 
-		notifier.NotifySlack(slackToken, channel, message, fields, level)
+			flushCtx, cancel := context.WithTimeout(ctx, notifyFlushBudget)
+			defer cancel()
+			if deadline, ok := ctx.Deadline(); ok {
+				flushCtx, cancel = context.WithDeadline(flushCtx, deadline.Add(-notifyFlushMargin))
+				defer cancel()
+			}
+			if flushErr := notifier.Flush(flushCtx, notify); flushErr != nil {
+				log.Printf("%s: notifications still pending after the flush budget: %v", handlerName, flushErr)
+			}
 
-		return response, err
+			return response, err
+		}
 	}
 }
 
@@ -0,0 +1,134 @@
+package wrapper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type Handler func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Middleware decorates a Handler with one cross-cutting concern.
+type Middleware func(next Handler) Handler
+
+type TokenVerifier interface {
+	VerifyAuthorizationHeader(ctx context.Context, header string) (*entity.IDTokenPayload, error)
+}
+
+type callerKey struct{}
+
+// Chain applies middlewares so that the first one listed is the outermost.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Recovery turns a panic anywhere below it into a 500 instead of crashing the Lambda runtime.
+func Recovery(handlerName string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (response events.APIGatewayProxyResponse, err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					log.Printf("%s panicked: %v\n%s", handlerName, recovered, debug.Stack())
+					response, err = errorpackage.ServerError(fmt.Sprintf("%s failed unexpectedly", handlerName))
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+func Logging(handlerName string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			started := time.Now()
+			response, err := next(ctx, request)
+
+			log.Printf("%s %s %s -> %d in %s (request %s)", handlerName, request.HTTPMethod, request.Path,
+				response.StatusCode, time.Since(started).Round(time.Millisecond), request.RequestContext.RequestID)
+			if err != nil {
+				log.Printf("%s error: %v", handlerName, err)
+			}
+			return response, err
+		}
+	}
+}
+
+// CORS fills in the CORS headers for responses that did not set their own, such as authentication failures.
+func CORS() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
+
+			if response.Headers == nil {
+				response.Headers = map[string]string{}
+			}
+			if _, ok := response.Headers["Access-Control-Allow-Origin"]; !ok {
+				for name, value := range SetAccessControl() {
+					response.Headers[name] = value
+				}
+			}
+			if _, ok := response.Headers["Access-Control-Allow-Methods"]; !ok && request.HTTPMethod != "" {
+				response.Headers["Access-Control-Allow-Methods"] = request.HTTPMethod + ", OPTIONS"
+			}
+			return response, err
+		}
+	}
+}
+
+// RequestID echoes the API Gateway request ID in an X-Request-Id header and in the body of error envelopes, so
+// a client report can be matched to the Lambda logs.
+func RequestID() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
+
+			requestID := request.RequestContext.RequestID
+			if requestID == "" {
+				return response, err
+			}
+			response = errorpackage.WithRequestID(response, requestID)
+			if response.Headers == nil {
+				response.Headers = map[string]string{}
+			}
+			response.Headers["X-Request-Id"] = requestID
+			return response, err
+		}
+	}
+}
+
+// Authenticate verifies the Authorization header and makes the caller available to the handler via Caller. A
+// rejected token is a 401; a verification that could not run is a 503, so clients do not sign the user out.
+func Authenticate(verifier TokenVerifier) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			payload, err := verifier.VerifyAuthorizationHeader(ctx, request.Headers["Authorization"])
+			if err != nil {
+				return errorpackage.HandleTokenError(err)
+			}
+			return next(WithCaller(ctx, payload), request)
+		}
+	}
+}
+
+func WithCaller(ctx context.Context, payload *entity.IDTokenPayload) context.Context {
+	return context.WithValue(ctx, callerKey{}, payload)
+}
+
+// Caller returns the identity Authenticate verified for this request.
+func Caller(ctx context.Context) (*entity.IDTokenPayload, error) {
+	payload, ok := ctx.Value(callerKey{}).(*entity.IDTokenPayload)
+	if !ok || payload == nil {
+		return nil, errorpackage.ErrMissingAuthorization
+	}
+	return payload, nil
+}
@@ -0,0 +1,337 @@
+package wrapper
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/components/notifier"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/entity"
+	"mentorship-app-backend/handlers/validator"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type stubVerifier struct {
+	payload *entity.IDTokenPayload
+	err     error
+}
+
+func (s stubVerifier) VerifyAuthorizationHeader(context.Context, string) (*entity.IDTokenPayload, error) {
+	return s.payload, s.err
+}
+
+func okHandler(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				calls = append(calls, name)
+				return next(ctx, request)
+			}
+		}
+	}
+
+	_, _ = Chain(okHandler, record("outer"), record("inner"))(context.Background(), events.APIGatewayProxyRequest{})
+
+	if strings.Join(calls, ",") != "outer,inner" {
+		t.Fatalf("unexpected call order %v", calls)
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	panicking := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		panic("boom")
+	}
+
+	response, err := Chain(panicking, Recovery("TestHandler"))(context.Background(), events.APIGatewayProxyRequest{})
+	if response.StatusCode != http.StatusInternalServerError || err != nil {
+		t.Fatalf("expected a 500 envelope, got %d %v", response.StatusCode, err)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	caller := &entity.IDTokenPayload{Sub: "alice-sub"}
+
+	var seen *entity.IDTokenPayload
+	handler := func(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		payload, err := Caller(ctx)
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, err
+		}
+		seen = payload
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	}
+
+	response, err := Chain(handler, Authenticate(stubVerifier{payload: caller}))(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusOK || seen != caller {
+		t.Fatalf("expected the verified caller to reach the handler, got %d %v %v", response.StatusCode, seen, err)
+	}
+
+	seen = nil
+	response, _ = Chain(handler, Authenticate(stubVerifier{err: errorpackage.ErrInvalidTokenSignature}))(context.Background(), events.APIGatewayProxyRequest{})
+	if response.StatusCode != http.StatusUnauthorized || seen != nil {
+		t.Fatalf("expected 401 without reaching the handler, got %d", response.StatusCode)
+	}
+
+	if _, err = Caller(context.Background()); err == nil {
+		t.Fatal("expected an error when no caller is present")
+	}
+}
+
+const (
+	testPoolArn  = "arn:aws:cognito-idp:us-east-1:123456789012:userpool/us-east-1_TestPool"
+	testClientID = "test-client-id"
+	testKeyID    = "test-key"
+)
+
+// signedHeader returns an Authorization header carrying a valid ID token signed by key.
+func signedHeader(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		raw, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	signingInput := encode(map[string]interface{}{"alg": "RS256", "kid": testKeyID}) + "." + encode(map[string]interface{}{
+		"sub":         "alice-sub",
+		"email":       "alice@example.com",
+		"custom:role": "mentee",
+		"iss":         "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_TestPool",
+		"aud":         testClientID,
+		"token_use":   "id",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+	})
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return "Bearer " + signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+type failingDenylist struct{ err error }
+
+func (f failingDenylist) Revoked(context.Context, *entity.IDTokenPayload) (bool, error) {
+	return false, f.err
+}
+
+func TestAuthenticateDependencyFailures(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	cfg := config.Config{CognitoPoolArn: testPoolArn, CognitoClientID: testClientID}
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "upstream exploded", http.StatusInternalServerError)
+	}))
+	defer jwks.Close()
+	unreachableKeys, err := validator.NewTokenVerifier(cfg, validator.NewJWKSKeySource(jwks.URL, nil, 0))
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	staticKeys, err := validator.NewTokenVerifier(cfg, validator.StaticKeySource{testKeyID: &key.PublicKey})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	throttledDenylist := staticKeys.WithDenylist(failingDenylist{err: errors.New("ProvisionedThroughputExceededException: table RevokedTokens")})
+
+	tests := []struct {
+		name     string
+		verifier TokenVerifier
+		leak     string
+	}{
+		{"failing JWKS fetch", unreachableKeys, jwks.URL},
+		{"failing denylist", throttledDenylist, "RevokedTokens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{Headers: map[string]string{"Authorization": signedHeader(t, key)}}
+			response, err := Chain(okHandler, Authenticate(tt.verifier))(context.Background(), request)
+			if err != nil || response.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("expected 503, got %d %v", response.StatusCode, err)
+			}
+			if !strings.Contains(response.Body, string(errorpackage.CodeDependencyUnavailable)) || strings.Contains(response.Body, tt.leak) {
+				t.Fatalf("unexpected envelope %s", response.Body)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRejectedTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{"expired", errorpackage.ErrTokenExpired, errorpackage.ErrTokenExpired.Error()},
+		{"revoked", errorpackage.ErrTokenRevoked, errorpackage.ErrTokenRevoked.Error()},
+		{"wrapped claim failure", fmt.Errorf("%w: unexpected issuer", errorpackage.ErrInvalidTokenClaims), errorpackage.ErrInvalidTokenClaims.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := Chain(okHandler, Authenticate(stubVerifier{err: tt.err}))(context.Background(), events.APIGatewayProxyRequest{})
+
+			var envelope errorpackage.Envelope
+			if err := json.Unmarshal([]byte(response.Body), &envelope); err != nil {
+				t.Fatalf("body is not an envelope: %s", response.Body)
+			}
+			if response.StatusCode != http.StatusUnauthorized || envelope.Code != errorpackage.CodeInvalidToken || envelope.Message != tt.message {
+				t.Fatalf("unexpected response %d %+v", response.StatusCode, envelope)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	response, _ := Chain(okHandler, CORS())(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet})
+
+	if response.Headers["Access-Control-Allow-Origin"] != "*" {
+		t.Fatalf("missing allow-origin header: %v", response.Headers)
+	}
+	if response.Headers["Access-Control-Allow-Methods"] != "GET, OPTIONS" {
+		t.Fatalf("unexpected allow-methods header: %v", response.Headers)
+	}
+
+	withHeaders := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Headers: SetHeadersPost()}, nil
+	}
+	response, _ = Chain(withHeaders, CORS())(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet})
+	if response.Headers["Access-Control-Allow-Methods"] != "POST, OPTIONS" {
+		t.Fatalf("handler headers were overwritten: %v", response.Headers)
+	}
+
+	failing := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return errorpackage.CodedError(errorpackage.CodeProfileConflict, "Profile was modified")
+	}
+	response, _ = Chain(failing, CORS())(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodPatch})
+	if response.Headers["Access-Control-Allow-Methods"] != "PATCH, OPTIONS" || response.Headers["Access-Control-Allow-Origin"] != "*" {
+		t.Fatalf("error response does not carry the route's CORS headers: %v", response.Headers)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	failing := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return errorpackage.CodedError(errorpackage.CodeProfileNotFound, "Profile not found")
+	}
+	request := events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: "req-123"}}
+
+	response, _ := Chain(failing, RequestID())(context.Background(), request)
+	if response.Headers["X-Request-Id"] != "req-123" {
+		t.Fatalf("missing request ID header: %v", response.Headers)
+	}
+	if !strings.Contains(response.Body, `"request_id":"req-123"`) {
+		t.Fatalf("request ID not stamped into the envelope: %s", response.Body)
+	}
+
+	response, _ = Chain(okHandler, RequestID())(context.Background(), request)
+	if response.Headers["X-Request-Id"] != "req-123" || strings.Contains(response.Body, "request_id") {
+		t.Fatalf("unexpected success response: %v %s", response.Headers, response.Body)
+	}
+}
+
+func TestNotifyFailureKeepsResponse(t *testing.T) {
+	recorder := &notifier.Recorder{Err: errors.New("slack is down")}
+
+	response, err := Chain(okHandler, Notify(recorder, "auth", "TestHandler"))(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("notification failure changed the response: %d %v", response.StatusCode, err)
+	}
+
+	sent := recorder.Sent()
+	if len(sent) != 1 || sent[0].Level != notifier.LevelInfo || sent[0].Channel != "auth" {
+		t.Fatalf("unexpected notifications %+v", sent)
+	}
+}
+
+// slowNotifier takes delay to deliver each notification, unless ctx is done first.
+type slowNotifier struct {
+	delay time.Duration
+	notifier.Recorder
+}
+
+func (s *slowNotifier) Notify(ctx context.Context, notification notifier.Notification) error {
+	select {
+	case <-time.After(s.delay):
+		return s.Recorder.Notify(ctx, notification)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestNotifyFlushesBeforeReturning(t *testing.T) {
+	backend := &slowNotifier{delay: 50 * time.Millisecond}
+	filter := notifier.NewFilter(notifier.NewAsync(backend, 1), notifier.Policy{MinLevel: notifier.LevelInfo, SuccessSampleRate: 1})
+
+	_, _ = Chain(okHandler, Notify(filter, "auth", "TestHandler"))(context.Background(), events.APIGatewayProxyRequest{})
+	if len(backend.Sent()) != 1 {
+		t.Fatal("the handler returned before its notification was delivered")
+	}
+}
+
+func TestNotifyFlushStopsBeforeTheDeadline(t *testing.T) {
+	backend := &slowNotifier{delay: time.Minute}
+	async := notifier.NewAsync(backend, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifyFlushMargin+100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	response, err := Chain(okHandler, Notify(async, "auth", "TestHandler"))(ctx, events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("a pending notification changed the response: %d %v", response.StatusCode, err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("waited %s for the notification, past the flush margin", elapsed)
+	}
+}
+
+func TestNotifyDoesNotWaitForABlockedBackend(t *testing.T) {
+	backend := &slowNotifier{delay: time.Minute}
+	async := notifier.NewAsync(backend, 1)
+
+	started := time.Now()
+	response, err := Chain(okHandler, Notify(async, "auth", "TestHandler"))(context.Background(), events.APIGatewayProxyRequest{})
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("a blocked notification changed the response: %d %v", response.StatusCode, err)
+	}
+	if elapsed := time.Since(started); elapsed > notifyFlushBudget+100*time.Millisecond {
+		t.Fatalf("waited %s for a blocked backend, past the %s flush budget", elapsed, notifyFlushBudget)
+	}
+	if len(backend.Sent()) != 0 {
+		t.Fatal("the blocked backend should not have delivered anything yet")
+	}
+}
+
+func TestNotifyRoutesFailuresToAlerts(t *testing.T) {
+	recorder := &notifier.Recorder{}
+	failing := func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return errorpackage.ServerError("boom")
+	}
+
+	_, _ = Chain(failing, Notify(recorder, "auth", "TestHandler"))(context.Background(), events.APIGatewayProxyRequest{})
+
+	sent := recorder.Sent()
+	if len(sent) != 1 || sent[0].Level != notifier.LevelError || sent[0].Channel != "auth-alerts" {
+		t.Fatalf("unexpected notifications %+v", sent)
+	}
+}
@@ -38,3 +38,21 @@ func SetHeadersPost() map[string]string {
 		"Access-Control-Allow-Headers": "Content-Type, Authorization, x-file-content-type",
 	}
 }
+
+func SetHeadersPatch() map[string]string {
+	return entity.Headers{
+		"Content-Type":                 "application/json",
+		"Access-Control-Allow-Origin":  "*",
+		"Access-Control-Allow-Methods": "PATCH, OPTIONS",
+		"Access-Control-Allow-Headers": "Content-Type, Authorization, x-file-content-type",
+	}
+}
+
+func SetHeadersPut() map[string]string {
+	return entity.Headers{
+		"Content-Type":                 "application/json",
+		"Access-Control-Allow-Origin":  "*",
+		"Access-Control-Allow-Methods": "PUT, OPTIONS",
+		"Access-Control-Allow-Headers": "Content-Type, Authorization, x-file-content-type",
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"mentorship-app-backend/api"
+	"mentorship-app-backend/api/routes"
 	"mentorship-app-backend/components/bucket"
 	"mentorship-app-backend/components/cloudfront"
 	"mentorship-app-backend/components/cognito"
@@ -41,23 +42,21 @@ func stackInitializer(scope constructs.Construct, id string, props *awscdk.Stack
 		removalPolicy = awscdk.RemovalPolicy_DESTROY
 	}
 
-	profileTable := dynamoDB.InitializeProfileTable(stack, cfg.UserProfileDDBTableName, removalPolicy)
-
-	uploadLambda := handlers.InitializeLambda(stack, s3Bucket, profileTable, api.UploadLambdaName, nil, cfg)
-
-	lambdas := map[string]awslambda.Function{
-		api.UploadLambdaName: uploadLambda,
-		api.RegisterLambdaName: handlers.InitializeLambda(stack, s3Bucket, profileTable, api.RegisterLambdaName,
-			map[string]awslambda.Function{api.UploadLambdaName: uploadLambda}, cfg),
-		api.LoginLambdaName:    handlers.InitializeLambda(stack, s3Bucket, profileTable, api.LoginLambdaName, nil, cfg),
-		api.DownloadLambdaName: handlers.InitializeLambda(stack, s3Bucket, profileTable, api.DownloadLambdaName, nil, cfg),
-		api.ListLambdaName:     handlers.InitializeLambda(stack, s3Bucket, profileTable, api.ListLambdaName, nil, cfg),
-		api.DeleteLambdaName:   handlers.InitializeLambda(stack, s3Bucket, profileTable, api.DeleteLambdaName, nil, cfg),
-		api.MeLambdaName:       handlers.InitializeLambda(stack, s3Bucket, profileTable, api.MeLambdaName, nil, cfg),
-		api.ConfirmLambdaName:  handlers.InitializeLambda(stack, s3Bucket, profileTable, api.ConfirmLambdaName, nil, cfg),
-		api.ResendLambdaName:   handlers.InitializeLambda(stack, s3Bucket, profileTable, api.ResendLambdaName, nil, cfg),
+	tables := dynamoDB.Tables{
+		Profile:            dynamoDB.InitializeProfileTable(stack, cfg.UserProfileDDBTableName, removalPolicy),
+		MentorshipRequests: dynamoDB.InitializeMentorshipRequestTable(stack, cfg.MentorshipRequestsDDBTableName, removalPolicy),
+		Availability:       dynamoDB.InitializeAvailabilityTable(stack, cfg.AvailabilityDDBTableName, removalPolicy),
+		Bookings:           dynamoDB.InitializeBookingsTable(stack, cfg.BookingsDDBTableName, removalPolicy),
+		RevokedTokens:      dynamoDB.InitializeRevokedTokensTable(stack, cfg.RevokedTokensDDBTableName, removalPolicy),
+		AccountJobs:        dynamoDB.InitializeAccountJobsTable(stack, cfg.AccountJobsDDBTableName, removalPolicy),
 	}
 
+	lambdas := map[string]awslambda.Function{}
+	for _, route := range append(routes.All(), routes.Workers()...) {
+		lambdas[route.Name] = handlers.InitializeLambda(stack, s3Bucket, tables, route, cfg)
+	}
+	handlers.GrantInvocations(lambdas)
+
 	userPool := cognito.InitializeUserPool(stack, cfg.UserPoolName, cfg.CognitoPoolArn)
 	cognitoAuthorizer := cognito.InitializeCognitoAuthorizer(stack, cfg.CognitoAuthorizer, userPool)
 
@@ -1,9 +1,608 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mentorship-app-backend/api/routes"
+	"mentorship-app-backend/config"
+	"mentorship-app-backend/handlers/s3/ownership"
+	"mentorship-app-backend/repository/accountjob"
+	"mentorship-app-backend/repository/revocation"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/aws/aws-cdk-go/awscdk/v2"
+	"github.com/aws/aws-cdk-go/awscdk/v2/assertions"
+	"github.com/aws/jsii-runtime-go"
 )
 
+const productionEnvironment = "production"
+
+// stackTemplate is a synthesized stack together with its raw resources, which the checks below walk to follow
+// references between lambdas, roles, policies and API resources.
+type stackTemplate struct {
+	cfg       config.Config
+	template  assertions.Template
+	resources map[string]map[string]interface{}
+}
+
+// configPath is resolved before TestMain moves into the asset directory.
+var configPath, _ = filepath.Abs("config/config.yaml")
+
+// TestMain runs the tests from a temporary directory holding an empty zip for every Lambda, so the stack can be
+// synthesized without `make build`. The jsii runtime keeps the working directory it was started in, which is why
+// this happens once for the package rather than per synthesis.
+func TestMain(m *testing.M) {
+	os.Exit(runInAssetDir(m))
+}
+
+func runInAssetDir(m *testing.M) int {
+	defer jsii.Close()
+
+	dir, err := os.MkdirTemp("", "mentorship-app-backend-assets")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = os.Mkdir(filepath.Join(dir, "output"), 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range lambdaNames() {
+		if err = os.WriteFile(filepath.Join(dir, "output", name+"_function.zip"), nil, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		log.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	return m.Run()
+}
+
+// synthesize builds the stack for the given environment out of config/config.yaml.
+func synthesize(t *testing.T, environment string) stackTemplate {
+	t.Helper()
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is required to synthesize the CDK stack")
+	}
+
+	cfg, err := config.LoadConfig(environment, configPath)
+	if err != nil {
+		t.Fatalf("failed to load %s config: %v", environment, err)
+	}
+
+	app := awscdk.NewApp(&awscdk.AppProps{Outdir: jsii.String(t.TempDir())})
+	stack := stackInitializer(app, fmt.Sprintf("%s-%s", cfg.AppName, environment), &awscdk.StackProps{
+		Env: &awscdk.Environment{
+			Account: jsii.String(cfg.Account),
+			Region:  jsii.String(cfg.Region),
+		},
+	}, cfg)
+
+	template := assertions.Template_FromStack(stack, nil)
+	raw, err := json.Marshal(template.ToJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed struct {
+		Resources map[string]map[string]interface{}
+	}
+	if err = json.Unmarshal(raw, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	return stackTemplate{cfg: cfg, template: template, resources: parsed.Resources}
+}
+
+func lambdaNames() []string {
+	var names []string
+	for _, route := range append(routes.All(), routes.Workers()...) {
+		names = append(names, route.Name)
+	}
+	return names
+}
+
+// find returns the logical IDs of the resources of the given type that match props.
+func (s stackTemplate) find(resourceType string, props interface{}) []string {
+	found := s.template.FindResources(jsii.String(resourceType), props)
+	if found == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(*found))
+	for id := range *found {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s stackTemplate) properties(logicalID string) map[string]interface{} {
+	properties, _ := s.resources[logicalID]["Properties"].(map[string]interface{})
+	return properties
+}
+
+// functionID returns the logical ID of the Lambda deployed for the given route lambda name.
+func (s stackTemplate) functionID(t *testing.T, name string) string {
+	t.Helper()
+	functionName := fmt.Sprintf("%s-%s", name, s.cfg.Environment)
+	found := s.find("AWS::Lambda::Function", map[string]interface{}{
+		"Properties": map[string]interface{}{"FunctionName": functionName},
+	})
+	if len(found) != 1 {
+		t.Fatalf("expected one function named %s, found %d", functionName, len(found))
+	}
+	return found[0]
+}
+
+// statements returns every action granted to the function's role, mapped to the resources it was granted on.
+func (s stackTemplate) statements(t *testing.T, functionID string) map[string][]interface{} {
+	t.Helper()
+	role, _ := s.properties(functionID)["Role"].(map[string]interface{})
+	getAtt, _ := role["Fn::GetAtt"].([]interface{})
+	if len(getAtt) == 0 {
+		t.Fatalf("function %s has no role", functionID)
+	}
+	roleRef := map[string]interface{}{"Ref": getAtt[0]}
+
+	actions := map[string][]interface{}{}
+	policies := s.find("AWS::IAM::Policy", map[string]interface{}{
+		"Properties": map[string]interface{}{"Roles": assertions.Match_ArrayWith(&[]interface{}{roleRef})},
+	})
+	for _, id := range policies {
+		document, _ := s.properties(id)["PolicyDocument"].(map[string]interface{})
+		statements, _ := document["Statement"].([]interface{})
+		for _, raw := range statements {
+			statement, _ := raw.(map[string]interface{})
+			if statement["Effect"] != "Allow" {
+				continue
+			}
+			for _, action := range asList(statement["Action"]) {
+				actions[action.(string)] = append(actions[action.(string)], asList(statement["Resource"])...)
+			}
+		}
+	}
+	return actions
+}
+
+// must reports a failed Template assertion as a test error; jsii surfaces the assertion error as a panic.
+func must(t *testing.T, assertion func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Error(r)
+		}
+	}()
+	assertion()
+}
+
+// resourceID returns the logical ID of the API resource at the route's path, walking down from the API root.
+func (s stackTemplate) resourceID(route routes.Route) (string, bool) {
+	apis := s.find("AWS::ApiGateway::RestApi", map[string]interface{}{})
+	if len(apis) != 1 {
+		return "", false
+	}
+	var parent interface{} = map[string]interface{}{"Fn::GetAtt": []interface{}{apis[0], "RootResourceId"}}
+	var id string
+	for _, segment := range route.Segments() {
+		found := s.find("AWS::ApiGateway::Resource", map[string]interface{}{
+			"Properties": map[string]interface{}{"PathPart": segment, "ParentId": parent},
+		})
+		if len(found) != 1 {
+			return "", false
+		}
+		id = found[0]
+		parent = map[string]interface{}{"Ref": id}
+	}
+	return id, true
+}
+
+func asList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{value}
+}
+
+func mentions(value interface{}, logicalID string) bool {
+	raw, _ := json.Marshal(value)
+	return strings.Contains(string(raw), fmt.Sprintf("%q", logicalID))
+}
+
 func TestMentorshipAppBackendStack(t *testing.T) {
-	//TODO: write test for the stack other than ci / cd
+	for _, environment := range []string{stagingEnvironment, productionEnvironment} {
+		t.Run(environment, func(t *testing.T) {
+			stack := synthesize(t, environment)
+
+			t.Run("routes", func(t *testing.T) { assertRoutes(t, stack) })
+			t.Run("lambda environment", func(t *testing.T) { assertLambdaEnvironment(t, stack) })
+			t.Run("lambda permissions", func(t *testing.T) { assertLambdaPermissions(t, stack) })
+			t.Run("removal policy", func(t *testing.T) { assertRemovalPolicy(t, stack) })
+			t.Run("cloudfront", func(t *testing.T) { assertCloudFront(t, stack) })
+			t.Run("bucket", func(t *testing.T) { assertBucket(t, stack) })
+		})
+	}
+}
+
+func assertRoutes(t *testing.T, stack stackTemplate) {
+	must(t, func() {
+		stack.template.ResourceCountIs(jsii.String("AWS::Lambda::Function"), jsii.Number(len(lambdaNames())))
+	})
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::ApiGateway::Stage"), map[string]interface{}{
+			"StageName": stack.cfg.Environment,
+		})
+	})
+
+	methods := stack.find("AWS::ApiGateway::Method", map[string]interface{}{
+		"Properties": map[string]interface{}{"HttpMethod": assertions.Match_Not("OPTIONS")},
+	})
+	if len(methods) != len(routes.All()) {
+		t.Errorf("expected %d API methods, found %d", len(routes.All()), len(methods))
+	}
+
+	for _, route := range routes.All() {
+		if route.Handler == "" || route.Channel == "" {
+			t.Errorf("route %s has no handler name or notification channel", route.Name)
+		}
+		resourceID, ok := stack.resourceID(route)
+		if !ok {
+			t.Errorf("no API resource for /%s", route.Path)
+			continue
+		}
+
+		authorization := "NONE"
+		if route.Protected {
+			authorization = "COGNITO_USER_POOLS"
+		}
+		found := stack.find("AWS::ApiGateway::Method", map[string]interface{}{
+			"Properties": map[string]interface{}{
+				"HttpMethod":        route.Method,
+				"ResourceId":        map[string]interface{}{"Ref": resourceID},
+				"AuthorizationType": authorization,
+			},
+		})
+		if len(found) != 1 {
+			t.Errorf("%s /%s: expected one method with %s authorization, found %d", route.Method, route.Path, authorization, len(found))
+			continue
+		}
+
+		functionID := stack.functionID(t, route.Name)
+		for _, id := range found {
+			properties := stack.properties(id)
+			if _, ok := properties["AuthorizerId"]; ok != route.Protected {
+				t.Errorf("%s /%s: authorizer attached = %t, want %t", route.Method, route.Path, ok, route.Protected)
+			}
+			if integration := properties["Integration"]; !mentions(integration, functionID) {
+				t.Errorf("%s /%s: integration does not target %s", route.Method, route.Path, route.Name)
+			}
+		}
+	}
+}
+
+func assertLambdaEnvironment(t *testing.T, stack stackTemplate) {
+	cfg := stack.cfg
+	for _, route := range append(routes.All(), routes.Workers()...) {
+		memory, timeout := route.MemoryMB, route.Timeout
+		if memory == 0 {
+			memory = routes.DefaultMemoryMB
+		}
+		if timeout == 0 {
+			timeout = routes.DefaultTimeout
+		}
+		if route.Method != "" && timeout > routes.APIGatewayTimeout {
+			t.Errorf("%s /%s: timeout %s outlasts API Gateway's %s", route.Method, route.Path, timeout, routes.APIGatewayTimeout)
+		}
+		must(t, func() {
+			stack.template.HasResourceProperties(jsii.String("AWS::Lambda::Function"), map[string]interface{}{
+				"FunctionName": fmt.Sprintf("%s-%s", route.Name, cfg.Environment),
+				"Handler":      "bootstrap",
+				"Runtime":      "provided.al2",
+				"MemorySize":   memory,
+				"Timeout":      timeout.Seconds(),
+				"Environment": map[string]interface{}{
+					"Variables": map[string]interface{}{
+						"ENVIRONMENT":                   cfg.Environment,
+						"COGNITO_CLIENT_ID":             cfg.CognitoClientID,
+						"COGNITO_POOL_ARN":              cfg.CognitoPoolArn,
+						"ACCOUNT":                       cfg.Account,
+						"REGION":                        cfg.Region,
+						"SLACK_WEBHOOK_SECRET_ARN":      cfg.SlackWebhookSecretARN,
+						"BUCKET_NAME":                   assertions.Match_AnyValue(),
+						"DDB_TABLE_NAME":                assertions.Match_AnyValue(),
+						"REQUESTS_DDB_TABLE_NAME":       assertions.Match_AnyValue(),
+						"AVAILABILITY_DDB_TABLE_NAME":   assertions.Match_AnyValue(),
+						"BOOKINGS_DDB_TABLE_NAME":       assertions.Match_AnyValue(),
+						"REVOKED_TOKENS_DDB_TABLE_NAME": assertions.Match_AnyValue(),
+						"ACCOUNT_JOBS_DDB_TABLE_NAME":   assertions.Match_AnyValue(),
+					},
+				},
+			})
+		})
+	}
+}
+
+func assertLambdaPermissions(t *testing.T, stack stackTemplate) {
+	cfg := stack.cfg
+	tableIDs := map[string]string{}
+	for _, table := range tableNames(cfg) {
+		found := stack.find("AWS::DynamoDB::Table", map[string]interface{}{
+			"Properties": map[string]interface{}{"TableName": table},
+		})
+		if len(found) != 1 {
+			t.Fatalf("expected one table named %s, found %d", table, len(found))
+		}
+		tableIDs[table] = found[0]
+	}
+
+	cognito := func(actions ...string) map[string]string {
+		granted := map[string]string{}
+		for _, action := range actions {
+			granted["cognito-idp:"+action] = cfg.CognitoPoolArn
+		}
+		return granted
+	}
+	describe := cognito("DescribeUserPool", "ListUsers", "AdminGetUser", "GetSigningCertificate")
+	account := cognito("ChangePassword", "UpdateUserAttributes", "VerifyUserAttribute", "GetUser")
+
+	exportTables := []string{cfg.MentorshipRequestsDDBTableName, cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName, cfg.AccountJobsDDBTableName}
+	exportS3 := []string{"s3:PutObject", "s3:GetObject*", "s3:List*"}
+
+	cases := []struct {
+		lambda    string
+		cognito   map[string]string
+		tables    []string
+		s3        []string
+		forbidden []string
+		invokes   []string
+	}{
+		{lambda: routes.RegisterLambdaName, cognito: map[string]string{
+			"cognito-idp:SignUp":                    "*",
+			"cognito-idp:AdminCreateUser":           "*",
+			"cognito-idp:AdminDeleteUser":           "*",
+			"cognito-idp:AdminUpdateUserAttributes": "*",
+		}, s3: []string{"s3:PutObject"}, forbidden: []string{"s3:GetObject*", "s3:DeleteObject*"}},
+		{lambda: routes.LoginLambdaName, cognito: cognito("AdminInitiateAuth", "AdminGetUser"), forbidden: []string{"s3:PutObject"}},
+		{lambda: routes.ConfirmLambdaName, cognito: cognito("ConfirmSignUp", "DescribeUserPool")},
+		{lambda: routes.ResendLambdaName, cognito: cognito("ResendConfirmationCode")},
+		{lambda: routes.RefreshLambdaName, cognito: cognito("InitiateAuth")},
+		{lambda: routes.ForgotPasswordLambdaName, cognito: cognito("ForgotPassword", "ConfirmForgotPassword")},
+		{lambda: routes.ResetPasswordLambdaName, cognito: cognito("ForgotPassword", "ConfirmForgotPassword")},
+		{lambda: routes.RespondChallengeLambdaName, cognito: cognito("RespondToAuthChallenge", "AdminGetUser")},
+		{lambda: routes.MFAAssociateLambdaName, cognito: cognito("AssociateSoftwareToken", "VerifySoftwareToken", "SetUserMFAPreference")},
+		{lambda: routes.MFAVerifyLambdaName, cognito: cognito("AssociateSoftwareToken", "VerifySoftwareToken", "SetUserMFAPreference")},
+		{lambda: routes.MFAPreferenceLambdaName, cognito: cognito("AssociateSoftwareToken", "VerifySoftwareToken", "SetUserMFAPreference")},
+		{lambda: routes.LogoutLambdaName, cognito: cognito("RevokeToken", "GlobalSignOut"), tables: []string{cfg.RevokedTokensDDBTableName}},
+		{lambda: routes.LogoutAllLambdaName, cognito: cognito("RevokeToken", "GlobalSignOut"), tables: []string{cfg.RevokedTokensDDBTableName}},
+		{lambda: routes.ChangePasswordLambdaName, cognito: account},
+		{lambda: routes.ChangeEmailLambdaName, cognito: account},
+		{lambda: routes.VerifyEmailLambdaName, cognito: account},
+		{
+			lambda:  routes.DeleteAccountLambdaName,
+			cognito: cognito("ListUsers", "AdminDeleteUser"),
+			tables:  []string{cfg.MentorshipRequestsDDBTableName, cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName, cfg.RevokedTokensDDBTableName, cfg.AccountJobsDDBTableName},
+			s3:      []string{"s3:List*", "s3:DeleteObject*"},
+		},
+		{lambda: routes.ExportLambdaName, cognito: cognito("ListUsers", "AdminGetUser"), tables: exportTables, s3: exportS3, invokes: []string{routes.ExportWorkerLambdaName}},
+		{lambda: routes.ExportStatusLambdaName, tables: []string{cfg.AccountJobsDDBTableName}, s3: []string{"s3:GetObject*"}, forbidden: []string{"s3:PutObject", "s3:DeleteObject*"}},
+		{lambda: routes.ExportWorkerLambdaName, cognito: cognito("ListUsers", "AdminGetUser"), tables: exportTables, s3: exportS3},
+		{lambda: routes.UploadLambdaName, cognito: describe, s3: []string{"s3:PutObject", "s3:GetObject*", "s3:DeleteObject*"}},
+		{lambda: routes.DeleteLambdaName, cognito: describe, s3: []string{"s3:PutObject", "s3:GetObject*", "s3:DeleteObject*"}},
+		{lambda: routes.DownloadLambdaName, cognito: describe, s3: []string{"s3:GetObject*"}, forbidden: []string{"s3:PutObject", "s3:DeleteObject*"}},
+		{lambda: routes.ListLambdaName, cognito: describe, s3: []string{"s3:GetObject*", "s3:List*"}, forbidden: []string{"s3:PutObject", "s3:DeleteObject*"}},
+		{lambda: routes.DownloadURLLambdaName, cognito: describe, s3: []string{"s3:GetObject*"}, forbidden: []string{"s3:PutObject", "s3:DeleteObject*"}},
+		{lambda: routes.UploadURLLambdaName, cognito: describe, s3: []string{"s3:PutObject"}, forbidden: []string{"s3:GetObject*", "s3:DeleteObject*"}},
+		{lambda: routes.MeLambdaName, cognito: describe, forbidden: []string{"s3:PutObject"}},
+		{lambda: routes.UpdateProfileLambdaName, cognito: describe},
+		{lambda: routes.MentorsLambdaName, cognito: describe},
+		{lambda: routes.SendRequestLambdaName, tables: []string{cfg.MentorshipRequestsDDBTableName}},
+		{lambda: routes.ListRequestsLambdaName, tables: []string{cfg.MentorshipRequestsDDBTableName}},
+		{lambda: routes.UpdateRequestLambdaName, tables: []string{cfg.MentorshipRequestsDDBTableName}},
+		{lambda: routes.SetAvailabilityLambdaName, tables: []string{cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName}},
+		{lambda: routes.GetAvailabilityLambdaName, tables: []string{cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName}},
+		{lambda: routes.ListBookingsLambdaName, tables: []string{cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName}},
+		{lambda: routes.CancelBookingLambdaName, tables: []string{cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName}},
+		{lambda: routes.BookSessionLambdaName, tables: []string{cfg.MentorshipRequestsDDBTableName, cfg.AvailabilityDDBTableName, cfg.BookingsDDBTableName}},
+	}
+	if len(cases) != len(lambdaNames()) {
+		t.Fatalf("permissions are asserted for %d lambdas, the stack has %d", len(cases), len(lambdaNames()))
+	}
+	protected := map[string]bool{}
+	for _, route := range routes.All() {
+		protected[route.Name] = route.Protected
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.lambda, func(t *testing.T) {
+			granted := stack.statements(t, stack.functionID(t, tc.lambda))
+
+			for action, resource := range tc.cognito {
+				if !containsValue(granted[action], resource) {
+					t.Errorf("%s is not granted on %s (got %v)", action, resource, granted[action])
+				}
+			}
+			for action := range granted {
+				if _, expected := tc.cognito[action]; strings.HasPrefix(action, "cognito-idp:") && !expected {
+					t.Errorf("unexpected grant %s", action)
+				}
+			}
+
+			for _, action := range tc.s3 {
+				if len(granted[action]) == 0 {
+					t.Errorf("%s is not granted", action)
+				}
+			}
+			for _, action := range tc.forbidden {
+				if len(granted[action]) != 0 {
+					t.Errorf("unexpected grant %s", action)
+				}
+			}
+
+			if got := len(granted["lambda:InvokeFunction"]); got != len(tc.invokes) {
+				t.Errorf("lambda:InvokeFunction granted on %d functions, want %d", got, len(tc.invokes))
+			}
+			for _, target := range tc.invokes {
+				if !mentions(granted["lambda:InvokeFunction"], stack.functionID(t, target)) {
+					t.Errorf("lambda:InvokeFunction is not granted on %s", target)
+				}
+			}
+
+			// Every lambda reads and writes profiles; other tables are granted per handler group.
+			tables := append([]string{cfg.UserProfileDDBTableName}, tc.tables...)
+			for _, table := range tableNames(cfg) {
+				want := containsValue(toInterfaces(tables), table)
+				if got := mentions(granted["dynamodb:PutItem"], tableIDs[table]); got != want {
+					t.Errorf("dynamodb:PutItem on %s granted = %t, want %t", table, got, want)
+				}
+			}
+			// The token verifier of every protected lambda consults the denylist.
+			if got := mentions(granted["dynamodb:BatchGetItem"], tableIDs[cfg.RevokedTokensDDBTableName]); got != protected[tc.lambda] {
+				t.Errorf("dynamodb:BatchGetItem on %s granted = %t, want %t", cfg.RevokedTokensDDBTableName, got, protected[tc.lambda])
+			}
+
+			for _, action := range []string{"secretsmanager:GetSecretValue", "secretsmanager:PutSecretValue"} {
+				if !containsValue(granted[action], cfg.SlackWebhookSecretARN) {
+					t.Errorf("%s is not granted on %s", action, cfg.SlackWebhookSecretARN)
+				}
+			}
+			if got, want := len(granted["sns:Publish"]) != 0, cfg.NotificationTopicARN != ""; got != want {
+				t.Errorf("sns:Publish granted = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func tableNames(cfg config.Config) []string {
+	return []string{
+		cfg.UserProfileDDBTableName,
+		cfg.MentorshipRequestsDDBTableName,
+		cfg.AvailabilityDDBTableName,
+		cfg.BookingsDDBTableName,
+		cfg.RevokedTokensDDBTableName,
+		cfg.AccountJobsDDBTableName,
+	}
+}
+
+func containsValue(values []interface{}, want interface{}) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
+
+func assertRemovalPolicy(t *testing.T, stack stackTemplate) {
+	policy := "Retain"
+	if stack.cfg.Environment == stagingEnvironment {
+		policy = "Delete"
+	}
+
+	count := len(tableNames(stack.cfg))
+	must(t, func() {
+		stack.template.ResourceCountIs(jsii.String("AWS::DynamoDB::Table"), jsii.Number(count))
+	})
+	tables := stack.find("AWS::DynamoDB::Table", map[string]interface{}{
+		"DeletionPolicy":      policy,
+		"UpdateReplacePolicy": policy,
+	})
+	if len(tables) != count {
+		t.Errorf("expected every table to have removal policy %s, %d of %d do", policy, len(tables), count)
+	}
+
+	// Denylist entries expire with the tokens they cover.
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::DynamoDB::Table"), map[string]interface{}{
+			"TableName":               stack.cfg.RevokedTokensDDBTableName,
+			"TimeToLiveSpecification": map[string]interface{}{"AttributeName": revocation.TTLAttribute, "Enabled": true},
+		})
+	})
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::DynamoDB::Table"), map[string]interface{}{
+			"TableName":               stack.cfg.AccountJobsDDBTableName,
+			"TimeToLiveSpecification": map[string]interface{}{"AttributeName": accountjob.TTLAttribute, "Enabled": true},
+		})
+	})
+}
+
+// Data exports expire with their job records.
+func assertBucket(t *testing.T, stack stackTemplate) {
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::S3::Bucket"), map[string]interface{}{
+			"PublicAccessBlockConfiguration": map[string]interface{}{
+				"BlockPublicAcls":       true,
+				"BlockPublicPolicy":     true,
+				"IgnorePublicAcls":      true,
+				"RestrictPublicBuckets": true,
+			},
+		})
+	})
+	must(t, func() {
+		stack.template.ResourcePropertiesCountIs(jsii.String("AWS::S3::BucketPolicy"), map[string]interface{}{
+			"PolicyDocument": map[string]interface{}{
+				"Statement": assertions.Match_ArrayWith(&[]interface{}{
+					assertions.Match_ObjectLike(&map[string]interface{}{"Principal": map[string]interface{}{"AWS": "*"}}),
+				}),
+			},
+		}, jsii.Number(0))
+	})
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::S3::Bucket"), map[string]interface{}{
+			"LifecycleConfiguration": map[string]interface{}{
+				"Rules": assertions.Match_ArrayWith(&[]interface{}{map[string]interface{}{
+					"Prefix":           ownership.ExportsPrefix,
+					"ExpirationInDays": ownership.ExportRetentionDays,
+					"Status":           "Enabled",
+				}}),
+			},
+		})
+	})
+}
+
+func assertCloudFront(t *testing.T, stack stackTemplate) {
+	must(t, func() {
+		stack.template.ResourceCountIs(jsii.String("AWS::CloudFront::Distribution"), jsii.Number(1))
+	})
+	must(t, func() {
+		stack.template.HasResourceProperties(jsii.String("AWS::CloudFront::Distribution"), map[string]interface{}{
+			"DistributionConfig": map[string]interface{}{
+				"DefaultCacheBehavior": assertions.Match_ObjectLike(&map[string]interface{}{
+					"AllowedMethods": assertions.Match_ArrayWith(&[]interface{}{"POST"}),
+				}),
+			},
+		})
+	})
+	for _, pattern := range []string{"/public/*", "/protected/*"} {
+		must(t, func() {
+			stack.template.HasResourceProperties(jsii.String("AWS::CloudFront::Distribution"), map[string]interface{}{
+				"DistributionConfig": map[string]interface{}{
+					"CacheBehaviors": assertions.Match_ArrayWith(&[]interface{}{
+						assertions.Match_ObjectLike(&map[string]interface{}{"PathPattern": pattern}),
+					}),
+				},
+			})
+		})
+	}
+	must(t, func() {
+		stack.template.HasOutput(jsii.String("*"), map[string]interface{}{
+			"Export": map[string]interface{}{"Name": fmt.Sprintf("CloudFrontDistributionUrl-%s", stack.cfg.Environment)},
+		})
+	})
 }
@@ -7,16 +7,22 @@ import (
 	"github.com/aws/aws-cdk-go/awscdk/v2/awss3"
 	"github.com/aws/aws-cdk-go/awscdk/v2/awssqs"
 	"github.com/aws/jsii-runtime-go"
-	"mentorship-app-backend/api"
 )
 
-func GrantAccessForBucket(lambda awslambda.Function, bucket awss3.Bucket, functionName string) {
-	switch functionName {
-	case api.UploadLambdaName, api.DeleteLambdaName:
-		bucket.GrantReadWrite(lambda, "*")
-	case api.DownloadLambdaName, api.ListLambdaName:
-		bucket.GrantRead(lambda, "*")
-	}
+func GrantBucketRead(lambda awslambda.Function, bucket awss3.Bucket) {
+	bucket.GrantRead(lambda, "*")
+}
+
+func GrantBucketReadWrite(lambda awslambda.Function, bucket awss3.Bucket) {
+	bucket.GrantReadWrite(lambda, "*")
+}
+
+func GrantBucketPut(lambda awslambda.Function, bucket awss3.Bucket) {
+	bucket.GrantPut(lambda, "*")
+}
+
+func GrantProfilePictureUpload(lambdaFunction awslambda.Function, bucket awss3.Bucket) {
+	bucket.GrantPut(lambdaFunction, "users/*")
 }
 
 func GrantCognitoConfirmationPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
@@ -27,21 +33,6 @@ func GrantCognitoConfirmationPermissions(lambdaFunction awslambda.Function, cogn
 	lambdaFunction.AddToRolePolicy(policy)
 }
 
-func GrantPublicReadAccess(bucket awss3.Bucket) {
-	bucket.AddToResourcePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
-		Effect: awsiam.Effect_ALLOW,
-		Actions: jsii.Strings(
-			"s3:GetObject",
-		),
-		Resources: jsii.Strings(
-			*bucket.BucketArn() + "/*",
-		),
-		Principals: &[]awsiam.IPrincipal{
-			awsiam.NewAnyPrincipal(),
-		},
-	}))
-}
-
 func GrantLambdaInvokePermission(lambdaFunction, targetLambda awslambda.Function) {
 	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
 		Actions:   jsii.Strings("lambda:InvokeFunction"),
@@ -68,6 +59,72 @@ func GrantCognitoLoginPermissions(lambdaFunction awslambda.Function, cognitoPool
 	}))
 }
 
+func GrantCognitoRefreshPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:InitiateAuth"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+// GrantCognitoChallengePermissions covers answering a sign-in challenge and reading the user the sign-in completes.
+func GrantCognitoChallengePermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:RespondToAuthChallenge", "cognito-idp:AdminGetUser"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoMFAPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions: jsii.Strings(
+			"cognito-idp:AssociateSoftwareToken",
+			"cognito-idp:VerifySoftwareToken",
+			"cognito-idp:SetUserMFAPreference",
+		),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoSignOutPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:RevokeToken", "cognito-idp:GlobalSignOut"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoAccountPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions: jsii.Strings(
+			"cognito-idp:ChangePassword",
+			"cognito-idp:UpdateUserAttributes",
+			"cognito-idp:VerifyUserAttribute",
+			"cognito-idp:GetUser",
+		),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoReadUserPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:ListUsers", "cognito-idp:AdminGetUser"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoDeleteUserPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:ListUsers", "cognito-idp:AdminDeleteUser"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
+func GrantCognitoPasswordResetPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
+	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
+		Actions:   jsii.Strings("cognito-idp:ForgotPassword", "cognito-idp:ConfirmForgotPassword"),
+		Resources: jsii.Strings(cognitoPoolArn),
+	}))
+}
+
 func GrantCognitoResendPermissions(lambdaFunction awslambda.Function, cognitoPoolArn string) {
 	lambdaFunction.AddToRolePolicy(awsiam.NewPolicyStatement(&awsiam.PolicyStatementProps{
 		Effect: awsiam.Effect_ALLOW,
@@ -89,6 +146,10 @@ func GrantDynamoDBPermissions(lambdaFunction awslambda.Function, table awsdynamo
 	table.GrantReadWriteData(lambdaFunction)
 }
 
+func GrantDynamoDBReadPermissions(lambdaFunction awslambda.Function, table awsdynamodb.Table) {
+	table.GrantReadData(lambdaFunction)
+}
+
 func GrantDynamoDBStreamPermissions(lambdaFunction awslambda.Function, table awsdynamodb.Table) {
 	table.GrantStreamRead(lambdaFunction)
 }
@@ -0,0 +1,55 @@
+package accountjob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+// MemoryRepository is an in-memory Repository for handler tests and local runs.
+type MemoryRepository struct {
+	awsapi.Faults
+
+	mu   sync.Mutex
+	jobs map[string]entity.AccountJob
+	now  func() time.Time
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{jobs: map[string]entity.AccountJob{}, now: time.Now}
+}
+
+func (r *MemoryRepository) Get(_ context.Context, userID, jobID string) (*entity.AccountJob, error) {
+	if err := r.Err("Get"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[userID+"|"+jobID]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	job.CompletedSteps = append([]string{}, job.CompletedSteps...)
+	return &job, nil
+}
+
+func (r *MemoryRepository) Put(_ context.Context, job *entity.AccountJob) error {
+	if err := r.Err("Put"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job.UpdatedAt = r.now().UTC().Format(time.RFC3339)
+	stored := *job
+	stored.CompletedSteps = append([]string{}, job.CompletedSteps...)
+	r.jobs[job.UserID+"|"+job.JobID] = stored
+	return nil
+}
+
+var _ Repository = (*MemoryRepository)(nil)
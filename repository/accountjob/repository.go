@@ -0,0 +1,83 @@
+// Package accountjob stores the progress records of account-wide operations such as deleting an account. A user
+// has at most one record per job ID, so starting a job again picks up the existing record.
+package accountjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TTLAttribute holds when DynamoDB may drop a job record. Export records expire with their archive.
+const TTLAttribute = "ExpiresAt"
+
+type Repository interface {
+	// Get returns the job, or errorpackage.ErrNoSuchKey if it was never started.
+	Get(ctx context.Context, userID, jobID string) (*entity.AccountJob, error)
+	// Put creates or replaces the job, stamping UpdatedAt.
+	Put(ctx context.Context, job *entity.AccountJob) error
+}
+
+type DynamoRepository struct {
+	client awsapi.DynamoDBAPI
+	table  string
+	now    func() time.Time
+}
+
+func NewDynamoRepository(client awsapi.DynamoDBAPI, table string) *DynamoRepository {
+	return &DynamoRepository{client: client, table: table, now: time.Now}
+}
+
+func (r *DynamoRepository) Get(ctx context.Context, userID, jobID string) (*entity.AccountJob, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.table),
+		Key:            jobKey(userID, jobID),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account job: %w", err)
+	}
+	if result.Item == nil {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+
+	var job entity.AccountJob
+	if err = attributevalue.UnmarshalMap(result.Item, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal account job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *DynamoRepository) Put(ctx context.Context, job *entity.AccountJob) error {
+	job.UpdatedAt = r.now().UTC().Format(time.RFC3339)
+
+	item, err := attributevalue.MarshalMap(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account job: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.table),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save account job: %w", err)
+	}
+	return nil
+}
+
+func jobKey(userID, jobID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"UserId": &types.AttributeValueMemberS{Value: userID},
+		"JobId":  &types.AttributeValueMemberS{Value: jobID},
+	}
+}
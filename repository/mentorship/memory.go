@@ -0,0 +1,143 @@
+package mentorship
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+// MemoryRepository is an in-memory Repository for handler tests and local runs. Transition applies the same
+// conditions as the DynamoDB update, so a stale or expired decision fails the same way, and Create checks for a
+// pending or accepted request of the pair under the same lock it writes with, as the pair reservation does.
+type MemoryRepository struct {
+	awsapi.Faults
+
+	mu       sync.Mutex
+	requests map[string]entity.MentorshipRequest
+	now      func() time.Time
+}
+
+func NewMemoryRepository(requests ...entity.MentorshipRequest) *MemoryRepository {
+	r := &MemoryRepository{requests: map[string]entity.MentorshipRequest{}, now: time.Now}
+	for _, request := range requests {
+		r.requests[request.RequestID] = request
+	}
+	return r
+}
+
+func (r *MemoryRepository) Create(_ context.Context, mentorID, menteeID, message string) (*entity.MentorshipRequest, error) {
+	if err := r.Err("Create"); err != nil {
+		return nil, err
+	}
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now().UTC()
+	request := entity.MentorshipRequest{
+		RequestID: requestID,
+		MentorID:  mentorID,
+		MenteeID:  menteeID,
+		Message:   message,
+		Status:    entity.MentorshipStatusPending,
+		CreatedAt: now.Format(time.RFC3339),
+		UpdatedAt: now.Format(time.RFC3339),
+		ExpiresAt: now.Add(RequestTTL).Format(time.RFC3339),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.requests {
+		if existing.MenteeID != menteeID || existing.MentorID != mentorID {
+			continue
+		}
+		if status := EffectiveStatus(&existing, now); status == entity.MentorshipStatusPending || status == entity.MentorshipStatusAccepted {
+			return nil, errorpackage.ErrRequestExists
+		}
+	}
+	r.requests[requestID] = request
+	return &request, nil
+}
+
+func (r *MemoryRepository) Get(_ context.Context, requestID string) (*entity.MentorshipRequest, error) {
+	if err := r.Err("Get"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, ok := r.requests[requestID]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	return &request, nil
+}
+
+func (r *MemoryRepository) ListByMentor(_ context.Context, mentorID string) ([]entity.MentorshipRequest, error) {
+	if err := r.Err("ListByMentor"); err != nil {
+		return nil, err
+	}
+	return r.list(func(request entity.MentorshipRequest) bool { return request.MentorID == mentorID }), nil
+}
+
+func (r *MemoryRepository) ListByMentee(_ context.Context, menteeID string) ([]entity.MentorshipRequest, error) {
+	if err := r.Err("ListByMentee"); err != nil {
+		return nil, err
+	}
+	return r.list(func(request entity.MentorshipRequest) bool { return request.MenteeID == menteeID }), nil
+}
+
+func (r *MemoryRepository) Transition(_ context.Context, requestID string, from, to entity.MentorshipStatus) (*entity.MentorshipRequest, error) {
+	if err := r.Err("Transition"); err != nil {
+		return nil, err
+	}
+	if !CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", errorpackage.ErrInvalidTransition, from, to)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now().UTC().Format(time.RFC3339)
+	request, ok := r.requests[requestID]
+	if !ok || request.Status != from || (to != entity.MentorshipStatusExpired && request.ExpiresAt <= now) {
+		return nil, errorpackage.ErrInvalidTransition
+	}
+	request.Status = to
+	request.UpdatedAt = now
+	r.requests[requestID] = request
+	return &request, nil
+}
+
+func (r *MemoryRepository) Delete(_ context.Context, request *entity.MentorshipRequest) error {
+	if err := r.Err("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.requests, request.RequestID)
+	return nil
+}
+
+// list returns the matching requests newest first, like the index queries.
+func (r *MemoryRepository) list(match func(entity.MentorshipRequest) bool) []entity.MentorshipRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var requests []entity.MentorshipRequest
+	for _, request := range r.requests {
+		if match(request) {
+			requests = append(requests, request)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].CreatedAt > requests[j].CreatedAt })
+	return requests
+}
+
+var _ Repository = (*MemoryRepository)(nil)
@@ -0,0 +1,312 @@
+package mentorship
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	MentorIndex = "MentorIndex"
+	MenteeIndex = "MenteeIndex"
+)
+
+type Repository interface {
+	Create(ctx context.Context, mentorID, menteeID, message string) (*entity.MentorshipRequest, error)
+	Get(ctx context.Context, requestID string) (*entity.MentorshipRequest, error)
+	ListByMentor(ctx context.Context, mentorID string) ([]entity.MentorshipRequest, error)
+	ListByMentee(ctx context.Context, menteeID string) ([]entity.MentorshipRequest, error)
+	Transition(ctx context.Context, requestID string, from, to entity.MentorshipStatus) (*entity.MentorshipRequest, error)
+	Delete(ctx context.Context, request *entity.MentorshipRequest) error
+}
+
+// pairReservationPrefix starts the key of a pair reservation. Request IDs are hex, so the two never collide.
+const pairReservationPrefix = "pair#"
+
+// pairReservation holds a mentee and mentor pair for the request that blocks a new one between them. Create puts
+// it conditionally in the same transaction as the request, so two concurrent sends to the same mentor cannot
+// both succeed. A pending request holds the pair until it expires, an accepted one for good, and declining or
+// withdrawing releases it. It has no MentorId or MenteeId, so it never shows up in the index queries.
+type pairReservation struct {
+	RequestID string `dynamodbav:"RequestId"`
+	HolderID  string `dynamodbav:"HolderId"`
+	ExpiresAt string `dynamodbav:"ExpiresAt"`
+}
+
+type DynamoRepository struct {
+	client    awsapi.DynamoDBAPI
+	tableName string
+	now       func() time.Time
+}
+
+func NewDynamoRepository(client awsapi.DynamoDBAPI, tableName string) *DynamoRepository {
+	return &DynamoRepository{client: client, tableName: tableName, now: time.Now}
+}
+
+func (r *DynamoRepository) Create(ctx context.Context, mentorID, menteeID, message string) (*entity.MentorshipRequest, error) {
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now().UTC()
+	request := &entity.MentorshipRequest{
+		RequestID: requestID,
+		MentorID:  mentorID,
+		MenteeID:  menteeID,
+		Message:   message,
+		Status:    entity.MentorshipStatusPending,
+		CreatedAt: now.Format(time.RFC3339),
+		UpdatedAt: now.Format(time.RFC3339),
+		ExpiresAt: now.Add(RequestTTL).Format(time.RFC3339),
+	}
+
+	item, err := attributevalue.MarshalMap(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mentorship request: %w", err)
+	}
+	pair, err := attributevalue.MarshalMap(pairReservation{
+		RequestID: pairReservationID(menteeID, mentorID),
+		HolderID:  requestID,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pair reservation: %w", err)
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:           aws.String(r.tableName),
+				Item:                pair,
+				ConditionExpression: aws.String("attribute_not_exists(RequestId) OR ExpiresAt <= :now"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":now": &types.AttributeValueMemberS{Value: request.CreatedAt},
+				},
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:           aws.String(r.tableName),
+				Item:                item,
+				ConditionExpression: aws.String("attribute_not_exists(RequestId)"),
+			},
+		},
+	}})
+	if err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			return nil, errorpackage.ErrRequestExists
+		}
+		return nil, fmt.Errorf("failed to create mentorship request: %w", err)
+	}
+	return request, nil
+}
+
+func (r *DynamoRepository) Get(ctx context.Context, requestID string) (*entity.MentorshipRequest, error) {
+	return r.get(ctx, requestID, false)
+}
+
+func (r *DynamoRepository) get(ctx context.Context, requestID string, consistent bool) (*entity.MentorshipRequest, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.tableName),
+		Key:            requestKey(requestID),
+		ConsistentRead: aws.Bool(consistent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentorship request: %w", err)
+	}
+	if result.Item == nil {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+
+	var request entity.MentorshipRequest
+	if err = attributevalue.UnmarshalMap(result.Item, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mentorship request: %w", err)
+	}
+	return &request, nil
+}
+
+func (r *DynamoRepository) ListByMentor(ctx context.Context, mentorID string) ([]entity.MentorshipRequest, error) {
+	return r.listByIndex(ctx, MentorIndex, "MentorId", mentorID)
+}
+
+func (r *DynamoRepository) ListByMentee(ctx context.Context, menteeID string) ([]entity.MentorshipRequest, error) {
+	return r.listByIndex(ctx, MenteeIndex, "MenteeId", menteeID)
+}
+
+// Transition moves a request between states. The write is conditional on the status the caller observed,
+// so two concurrent decisions on the same request cannot both succeed. An expired request's pair reservation
+// has lapsed with it, so persisting the expiry writes the request alone.
+func (r *DynamoRepository) Transition(ctx context.Context, requestID string, from, to entity.MentorshipStatus) (*entity.MentorshipRequest, error) {
+	if !CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", errorpackage.ErrInvalidTransition, from, to)
+	}
+
+	now := r.now().UTC().Format(time.RFC3339)
+	condition := "#status = :from"
+	attributeValues := map[string]types.AttributeValue{
+		":from": &types.AttributeValueMemberS{Value: string(from)},
+		":to":   &types.AttributeValueMemberS{Value: string(to)},
+		":now":  &types.AttributeValueMemberS{Value: now},
+	}
+	if to != entity.MentorshipStatusExpired {
+		condition += " AND ExpiresAt > :now"
+		return r.decide(ctx, requestID, to, now, condition, attributeValues)
+	}
+
+	result, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       requestKey(requestID),
+		UpdateExpression:          aws.String("SET #status = :to, UpdatedAt = :now"),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  map[string]string{"#status": "Status"},
+		ExpressionAttributeValues: attributeValues,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return nil, errorpackage.ErrInvalidTransition
+		}
+		return nil, fmt.Errorf("failed to update mentorship request: %w", err)
+	}
+
+	var request entity.MentorshipRequest
+	if err = attributevalue.UnmarshalMap(result.Attributes, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mentorship request: %w", err)
+	}
+	return &request, nil
+}
+
+// decide writes a decision on a live request in one transaction with its pair reservation: accepting keeps the
+// pair reserved for good, declining or withdrawing releases it. Requests created before pairs were reserved
+// have none, which the pair's condition allows for.
+func (r *DynamoRepository) decide(ctx context.Context, requestID string, to entity.MentorshipStatus, now, condition string, attributeValues map[string]types.AttributeValue) (*entity.MentorshipRequest, error) {
+	request, err := r.get(ctx, requestID, true)
+	if err != nil {
+		if errors.Is(err, errorpackage.ErrNoSuchKey) {
+			return nil, errorpackage.ErrInvalidTransition
+		}
+		return nil, err
+	}
+
+	holderCondition := aws.String("attribute_not_exists(RequestId) OR HolderId = :holder")
+	holder := map[string]types.AttributeValue{":holder": &types.AttributeValueMemberS{Value: requestID}}
+	pair := types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName:                 aws.String(r.tableName),
+			Key:                       requestKey(pairReservationID(request.MenteeID, request.MentorID)),
+			ConditionExpression:       holderCondition,
+			ExpressionAttributeValues: holder,
+		},
+	}
+	if to == entity.MentorshipStatusAccepted {
+		pair = types.TransactWriteItem{
+			Update: &types.Update{
+				TableName:                 aws.String(r.tableName),
+				Key:                       requestKey(pairReservationID(request.MenteeID, request.MentorID)),
+				UpdateExpression:          aws.String("SET HolderId = :holder REMOVE ExpiresAt"),
+				ConditionExpression:       holderCondition,
+				ExpressionAttributeValues: holder,
+			},
+		}
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		{
+			Update: &types.Update{
+				TableName:                 aws.String(r.tableName),
+				Key:                       requestKey(requestID),
+				UpdateExpression:          aws.String("SET #status = :to, UpdatedAt = :now"),
+				ConditionExpression:       aws.String(condition),
+				ExpressionAttributeNames:  map[string]string{"#status": "Status"},
+				ExpressionAttributeValues: attributeValues,
+			},
+		},
+		pair,
+	}})
+	if err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			return nil, errorpackage.ErrInvalidTransition
+		}
+		return nil, fmt.Errorf("failed to update mentorship request: %w", err)
+	}
+
+	request.Status = to
+	request.UpdatedAt = now
+	return request, nil
+}
+
+// Delete removes a request and its pair's reservation outright, whatever their state. Only account deletion does
+// this, and it deletes every request of the pair; everything else moves requests through Transition.
+func (r *DynamoRepository) Delete(ctx context.Context, request *entity.MentorshipRequest) error {
+	_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		{Delete: &types.Delete{TableName: aws.String(r.tableName), Key: requestKey(request.RequestID)}},
+		{Delete: &types.Delete{TableName: aws.String(r.tableName), Key: requestKey(pairReservationID(request.MenteeID, request.MentorID))}},
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to delete mentorship request: %w", err)
+	}
+	return nil
+}
+
+func (r *DynamoRepository) listByIndex(ctx context.Context, indexName, attribute, userID string) ([]entity.MentorshipRequest, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:                aws.String(r.tableName),
+		IndexName:                aws.String(indexName),
+		KeyConditionExpression:   aws.String("#user = :user"),
+		ExpressionAttributeNames: map[string]string{"#user": attribute},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":user": &types.AttributeValueMemberS{Value: userID},
+		},
+		ScanIndexForward: aws.Bool(false),
+	})
+
+	var requests []entity.MentorshipRequest
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list mentorship requests: %w", err)
+		}
+
+		var batch []entity.MentorshipRequest
+		if err = attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal mentorship requests: %w", err)
+		}
+		requests = append(requests, batch...)
+	}
+	return requests, nil
+}
+
+func requestKey(requestID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"RequestId": &types.AttributeValueMemberS{Value: requestID},
+	}
+}
+
+// pairReservationID keys the reservation of a mentee and mentor pair.
+func pairReservationID(menteeID, mentorID string) string {
+	return pairReservationPrefix + menteeID + "#" + mentorID
+}
+
+func newRequestID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,63 @@
+package mentorship
+
+import (
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/entity"
+)
+
+const (
+	ActionAccept   = "accept"
+	ActionDecline  = "decline"
+	ActionWithdraw = "withdraw"
+
+	RequestTTL = 14 * 24 * time.Hour
+)
+
+// transitions is the whole lifecycle: a request is only ever decided once, and every decision is final.
+var transitions = map[entity.MentorshipStatus][]entity.MentorshipStatus{
+	entity.MentorshipStatusPending: {
+		entity.MentorshipStatusAccepted,
+		entity.MentorshipStatusDeclined,
+		entity.MentorshipStatusWithdrawn,
+		entity.MentorshipStatusExpired,
+	},
+}
+
+func CanTransition(from, to entity.MentorshipStatus) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveAction maps a caller action onto the target status and the role allowed to perform it:
+// mentors accept or decline requests addressed to them, mentees withdraw requests they sent.
+func ResolveAction(action string) (entity.MentorshipStatus, string, error) {
+	switch action {
+	case ActionAccept:
+		return entity.MentorshipStatusAccepted, "mentor", nil
+	case ActionDecline:
+		return entity.MentorshipStatusDeclined, "mentor", nil
+	case ActionWithdraw:
+		return entity.MentorshipStatusWithdrawn, "mentee", nil
+	default:
+		return "", "", fmt.Errorf("invalid action %q; must be one of accept, decline, withdraw", action)
+	}
+}
+
+// EffectiveStatus reports a pending request whose deadline has passed as expired, even before the
+// expiry has been persisted.
+func EffectiveStatus(request *entity.MentorshipRequest, now time.Time) entity.MentorshipStatus {
+	if request.Status != entity.MentorshipStatusPending {
+		return request.Status
+	}
+	expiresAt, err := time.Parse(time.RFC3339, request.ExpiresAt)
+	if err == nil && !now.Before(expiresAt) {
+		return entity.MentorshipStatusExpired
+	}
+	return request.Status
+}
@@ -0,0 +1,63 @@
+package mentorship
+
+import (
+	"testing"
+	"time"
+
+	"mentorship-app-backend/entity"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to entity.MentorshipStatus
+		want     bool
+	}{
+		{entity.MentorshipStatusPending, entity.MentorshipStatusAccepted, true},
+		{entity.MentorshipStatusPending, entity.MentorshipStatusDeclined, true},
+		{entity.MentorshipStatusPending, entity.MentorshipStatusWithdrawn, true},
+		{entity.MentorshipStatusPending, entity.MentorshipStatusExpired, true},
+		{entity.MentorshipStatusAccepted, entity.MentorshipStatusDeclined, false},
+		{entity.MentorshipStatusDeclined, entity.MentorshipStatusAccepted, false},
+		{entity.MentorshipStatusWithdrawn, entity.MentorshipStatusPending, false},
+		{entity.MentorshipStatusExpired, entity.MentorshipStatusAccepted, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAction(t *testing.T) {
+	status, actor, err := ResolveAction(ActionWithdraw)
+	if err != nil || status != entity.MentorshipStatusWithdrawn || actor != "mentee" {
+		t.Fatalf("unexpected withdraw resolution: %s %s %v", status, actor, err)
+	}
+	if _, _, err = ResolveAction("approve"); err == nil {
+		t.Fatal("expected an error for an unknown action")
+	}
+}
+
+func TestEffectiveStatus(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	request := &entity.MentorshipRequest{
+		Status:    entity.MentorshipStatusPending,
+		ExpiresAt: now.Add(-time.Second).Format(time.RFC3339),
+	}
+
+	if got := EffectiveStatus(request, now); got != entity.MentorshipStatusExpired {
+		t.Fatalf("expected an overdue pending request to be expired, got %s", got)
+	}
+
+	request.ExpiresAt = now.Add(time.Hour).Format(time.RFC3339)
+	if got := EffectiveStatus(request, now); got != entity.MentorshipStatusPending {
+		t.Fatalf("expected request to still be pending, got %s", got)
+	}
+
+	request.Status = entity.MentorshipStatusAccepted
+	request.ExpiresAt = now.Add(-time.Hour).Format(time.RFC3339)
+	if got := EffectiveStatus(request, now); got != entity.MentorshipStatusAccepted {
+		t.Fatalf("expected accepted request to stay accepted, got %s", got)
+	}
+}
@@ -0,0 +1,230 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MemoryRepository is an in-memory Repository for handler tests and local runs. It enforces the same
+// conditions as DynamoRepository: creates must not overwrite and updates must match the stored version.
+type MemoryRepository struct {
+	awsapi.Faults
+
+	mu       sync.Mutex
+	profiles map[string]entity.Profile
+	now      func() time.Time
+}
+
+func NewMemoryRepository(profiles ...entity.Profile) *MemoryRepository {
+	r := &MemoryRepository{profiles: map[string]entity.Profile{}, now: time.Now}
+	for _, profile := range profiles {
+		r.profiles[memoryKey(profile.UserID, profile.Role)] = profile
+	}
+	return r
+}
+
+func (r *MemoryRepository) Get(_ context.Context, userID, profileType string) (*entity.Profile, error) {
+	if err := r.Err("Get"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile, ok := r.profiles[memoryKey(userID, profileType)]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	return &profile, nil
+}
+
+func (r *MemoryRepository) Create(_ context.Context, profile *entity.Profile) error {
+	if err := r.Err("Create"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memoryKey(profile.UserID, profile.Role)
+	if _, exists := r.profiles[key]; exists {
+		return errorpackage.ErrUserAlreadyExists
+	}
+	now := r.now().UTC().Format(time.RFC3339)
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+	profile.Version = 1
+	r.profiles[key] = *profile
+	return nil
+}
+
+// Update applies changes through the profile's DynamoDB attribute names, exactly as the UpdateItem would.
+func (r *MemoryRepository) Update(_ context.Context, userID, profileType string, expectedVersion int, changes Changes) (*entity.Profile, error) {
+	if err := r.Err("Update"); err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no profile changes supplied")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memoryKey(userID, profileType)
+	current, ok := r.profiles[key]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	if current.Version != expectedVersion {
+		return nil, errorpackage.ErrVersionConflict
+	}
+
+	item, err := attributevalue.MarshalMap(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	for attribute, value := range changes {
+		if attribute == userIDAttribute || attribute == profileTypeAttribute || attribute == versionAttribute {
+			return nil, fmt.Errorf("attribute %s cannot be updated", attribute)
+		}
+		if item[attribute], err = attributevalue.Marshal(value); err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", attribute, err)
+		}
+	}
+	item["UpdatedAt"] = &types.AttributeValueMemberS{Value: r.now().UTC().Format(time.RFC3339)}
+	item[versionAttribute] = &types.AttributeValueMemberN{Value: fmt.Sprint(expectedVersion + 1)}
+
+	var updated entity.Profile
+	if err = attributevalue.UnmarshalMap(item, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal updated profile: %w", err)
+	}
+	r.profiles[key] = updated
+	return &updated, nil
+}
+
+func (r *MemoryRepository) Delete(_ context.Context, userID, profileType string) error {
+	if err := r.Err("Delete"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.profiles, memoryKey(userID, profileType))
+	return nil
+}
+
+func (r *MemoryRepository) Query(_ context.Context, userID string) ([]entity.Profile, error) {
+	if err := r.Err("Query"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var profiles []entity.Profile
+	for _, profile := range r.sorted() {
+		if profile.UserID == userID {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+// SearchMentors pages through mentors in user ID order and accepts the same cursors as DynamoRepository. Like
+// it, a call evaluates at most maxEvaluatedMentors mentors before handing back a cursor.
+func (r *MemoryRepository) SearchMentors(_ context.Context, filter entity.MentorSearchFilter, limit int32, cursor string) ([]entity.Profile, string, error) {
+	if err := r.Err("SearchMentors"); err != nil {
+		return nil, "", err
+	}
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var after string
+	if startKey != nil {
+		userID, ok := startKey[userIDAttribute].(*types.AttributeValueMemberS)
+		if !ok {
+			return nil, "", errorpackage.ErrInvalidCursor
+		}
+		after = userID.Value
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mentors := make([]entity.Profile, 0, limit)
+	var lastKey map[string]types.AttributeValue
+	var lastEvaluated string
+	evaluated := 0
+	for _, profile := range r.sorted() {
+		if profile.Role != mentorProfileType || profile.UserID <= after {
+			continue
+		}
+		if int32(len(mentors)) == limit || evaluated == maxEvaluatedMentors {
+			lastKey = profileKey(lastEvaluated, mentorProfileType)
+			break
+		}
+		evaluated++
+		lastEvaluated = profile.UserID
+		if matchesFilter(profile, filter) {
+			mentors = append(mentors, profile)
+		}
+	}
+
+	nextCursor, err := encodeCursor(lastKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return mentors, nextCursor, nil
+}
+
+func (r *MemoryRepository) sorted() []entity.Profile {
+	profiles := make([]entity.Profile, 0, len(r.profiles))
+	for _, profile := range r.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].UserID != profiles[j].UserID {
+			return profiles[i].UserID < profiles[j].UserID
+		}
+		return profiles[i].Role < profiles[j].Role
+	})
+	return profiles
+}
+
+// matchesFilter mirrors the filter expression built by mentorFilter.
+func matchesFilter(profile entity.Profile, filter entity.MentorSearchFilter) bool {
+	if filter.Skill != "" && !contains(profile.Skills, filter.Skill) {
+		return false
+	}
+	if filter.Language != "" && !contains(profile.Languages, filter.Language) {
+		return false
+	}
+	if filter.Industry != "" && profile.Industry != filter.Industry {
+		return false
+	}
+	if filter.Seniority != "" && profile.Seniority != filter.Seniority {
+		return false
+	}
+	return filter.Available == nil || profile.Available == *filter.Available
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func memoryKey(userID, profileType string) string {
+	return userID + "\x00" + profileType
+}
+
+var _ Repository = (*MemoryRepository)(nil)
@@ -0,0 +1,263 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	userIDAttribute      = "UserId"
+	profileTypeAttribute = "ProfileType"
+	versionAttribute     = "Version"
+
+	ProfileTypeIndex = "ProfileTypeIndex"
+)
+
+// Changes maps profile attribute names to their new values for a partial update.
+type Changes map[string]interface{}
+
+// Repository is the single access path to the profile table, so every handler reads and writes the same schema.
+type Repository interface {
+	Get(ctx context.Context, userID, profileType string) (*entity.Profile, error)
+	Create(ctx context.Context, profile *entity.Profile) error
+	Update(ctx context.Context, userID, profileType string, expectedVersion int, changes Changes) (*entity.Profile, error)
+	Delete(ctx context.Context, userID, profileType string) error
+	Query(ctx context.Context, userID string) ([]entity.Profile, error)
+	SearchMentors(ctx context.Context, filter entity.MentorSearchFilter, limit int32, cursor string) ([]entity.Profile, string, error)
+}
+
+type DynamoRepository struct {
+	client    awsapi.DynamoDBAPI
+	tableName string
+	now       func() time.Time
+}
+
+func NewDynamoRepository(client awsapi.DynamoDBAPI, tableName string) *DynamoRepository {
+	return &DynamoRepository{client: client, tableName: tableName, now: time.Now}
+}
+
+func (r *DynamoRepository) Get(ctx context.Context, userID, profileType string) (*entity.Profile, error) {
+	if userID == "" || profileType == "" {
+		return nil, fmt.Errorf("userID or profileType is empty")
+	}
+
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       profileKey(userID, profileType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if result.Item == nil {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+
+	var profile entity.Profile
+	if err = attributevalue.UnmarshalMap(result.Item, &profile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal profile: %w", err)
+	}
+	return &profile, nil
+}
+
+func (r *DynamoRepository) Create(ctx context.Context, profile *entity.Profile) error {
+	now := r.now().UTC().Format(time.RFC3339)
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+	profile.Version = 1
+
+	item, err := attributevalue.MarshalMap(profile)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(UserId)"),
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return errorpackage.ErrUserAlreadyExists
+		}
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	return nil
+}
+
+func (r *DynamoRepository) Update(ctx context.Context, userID, profileType string, expectedVersion int, changes Changes) (*entity.Profile, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no profile changes supplied")
+	}
+
+	set := Changes{
+		"UpdatedAt":      r.now().UTC().Format(time.RFC3339),
+		versionAttribute: expectedVersion + 1,
+	}
+	for attribute, value := range changes {
+		if attribute == userIDAttribute || attribute == profileTypeAttribute || attribute == versionAttribute {
+			return nil, fmt.Errorf("attribute %s cannot be updated", attribute)
+		}
+		set[attribute] = value
+	}
+
+	attributes := make([]string, 0, len(set))
+	for attribute := range set {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	attributeNames := map[string]string{"#version": versionAttribute}
+	attributeValues := map[string]types.AttributeValue{
+		":expectedVersion": &types.AttributeValueMemberN{Value: fmt.Sprint(expectedVersion)},
+	}
+	setClauses := make([]string, 0, len(attributes))
+	for i, attribute := range attributes {
+		value, err := attributevalue.Marshal(set[attribute])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", attribute, err)
+		}
+		namePlaceholder, valuePlaceholder := fmt.Sprintf("#f%d", i), fmt.Sprintf(":v%d", i)
+		attributeNames[namePlaceholder] = attribute
+		attributeValues[valuePlaceholder] = value
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", namePlaceholder, valuePlaceholder))
+	}
+
+	// Profiles written before versioning was introduced have no Version attribute and count as version 0.
+	condition := "attribute_exists(UserId) AND #version = :expectedVersion"
+	if expectedVersion == 0 {
+		condition = "attribute_exists(UserId) AND (attribute_not_exists(#version) OR #version = :expectedVersion)"
+	}
+
+	result, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                           aws.String(r.tableName),
+		Key:                                 profileKey(userID, profileType),
+		UpdateExpression:                    aws.String("SET " + strings.Join(setClauses, ", ")),
+		ConditionExpression:                 aws.String(condition),
+		ExpressionAttributeNames:            attributeNames,
+		ExpressionAttributeValues:           attributeValues,
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			if len(conditionErr.Item) == 0 {
+				return nil, errorpackage.ErrNoSuchKey
+			}
+			return nil, errorpackage.ErrVersionConflict
+		}
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	var profile entity.Profile
+	if err = attributevalue.UnmarshalMap(result.Attributes, &profile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal updated profile: %w", err)
+	}
+	return &profile, nil
+}
+
+func (r *DynamoRepository) Delete(ctx context.Context, userID, profileType string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       profileKey(userID, profileType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
+
+func (r *DynamoRepository) Query(ctx context.Context, userID string) ([]entity.Profile, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("UserId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userID},
+		},
+	})
+
+	var profiles []entity.Profile
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query profiles: %w", err)
+		}
+
+		var batch []entity.Profile
+		if err = attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal profiles: %w", err)
+		}
+		profiles = append(profiles, batch...)
+	}
+	return profiles, nil
+}
+
+// ChangesFromRequest translates the fields present in a PATCH /me body into profile attribute changes.
+func ChangesFromRequest(req *entity.ProfileUpdateRequest) Changes {
+	changes := Changes{}
+	if req.Name != nil {
+		changes["Name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Bio != nil {
+		changes["Bio"] = *req.Bio
+	}
+	if req.ProfilePicture != nil {
+		changes["ProfilePicURL"] = *req.ProfilePicture
+	}
+	if req.Languages != nil {
+		changes["Languages"] = *req.Languages
+	}
+	if req.SocialLinks != nil {
+		changes["SocialLinks"] = *req.SocialLinks
+	}
+	if req.Skills != nil {
+		changes["Skills"] = *req.Skills
+	}
+	if req.Industry != nil {
+		changes["Industry"] = *req.Industry
+	}
+	if req.Seniority != nil {
+		changes["Seniority"] = *req.Seniority
+	}
+	if req.YearsOfExperience != nil {
+		changes["YearsOfExperience"] = *req.YearsOfExperience
+	}
+	if req.Available != nil {
+		changes["Available"] = *req.Available
+	}
+	if req.Goals != nil {
+		changes["Goals"] = *req.Goals
+	}
+	if req.Interests != nil {
+		changes["Interests"] = *req.Interests
+	}
+	return changes
+}
+
+// UserID identifies the authenticated caller in every table: the profile partition key, the mentor and mentee of
+// mentorship requests and bookings, and the owner of availability. It is the Cognito sub rather than the email,
+// which the user can change.
+func UserID(payload *entity.IDTokenPayload) string {
+	return payload.Sub
+}
+
+func profileKey(userID, profileType string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		userIDAttribute:      &types.AttributeValueMemberS{Value: userID},
+		profileTypeAttribute: &types.AttributeValueMemberS{Value: profileType},
+	}
+}
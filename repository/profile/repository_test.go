@@ -0,0 +1,85 @@
+package profile
+
+import (
+	"reflect"
+	"testing"
+
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	original := entity.Profile{
+		UserID: "mentor@example.com",
+		User: entity.User{
+			Email:          "mentor@example.com",
+			Name:           "Ada Mentor",
+			ProfilePicture: "https://big-bucket-staging.s3.amazonaws.com/ada.png",
+			Role:           "mentor",
+		},
+		Languages:         []string{"en", "tr"},
+		SocialLinks:       map[string]string{"linkedin": "https://linkedin.com/in/ada"},
+		Skills:            []string{"go", "aws"},
+		Industry:          "fintech",
+		Seniority:         "senior",
+		YearsOfExperience: 12,
+		Version:           3,
+	}
+
+	item, err := attributevalue.MarshalMap(original)
+	if err != nil {
+		t.Fatalf("failed to marshal profile: %v", err)
+	}
+
+	if _, ok := item["YearsOfExperience"].(*types.AttributeValueMemberN); !ok {
+		t.Fatalf("expected YearsOfExperience to be stored as a number, got %T", item["YearsOfExperience"])
+	}
+	if _, ok := item["Skills"].(*types.AttributeValueMemberL); !ok {
+		t.Fatalf("expected Skills to be stored as a list, got %T", item["Skills"])
+	}
+	if _, ok := item["SocialLinks"].(*types.AttributeValueMemberM); !ok {
+		t.Fatalf("expected SocialLinks to be stored as a map, got %T", item["SocialLinks"])
+	}
+
+	var decoded entity.Profile
+	if err = attributevalue.UnmarshalMap(item, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal profile: %v", err)
+	}
+	if !reflect.DeepEqual(original, decoded) {
+		t.Fatalf("profile did not survive the round trip:\nwant %+v\ngot  %+v", original, decoded)
+	}
+}
+
+func TestLegacyProfileItem(t *testing.T) {
+	legacy := map[string]types.AttributeValue{
+		"UserId":        &types.AttributeValueMemberS{Value: "mentee@example.com"},
+		"Name":          &types.AttributeValueMemberS{Value: "Grace Mentee"},
+		"ProfileType":   &types.AttributeValueMemberS{Value: "mentee"},
+		"Email":         &types.AttributeValueMemberS{Value: "mentee@example.com"},
+		"ProfilePicURL": &types.AttributeValueMemberS{Value: "https://example.com/grace.png"},
+	}
+
+	var decoded entity.Profile
+	if err := attributevalue.UnmarshalMap(legacy, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal legacy profile: %v", err)
+	}
+	if decoded.UserID != "mentee@example.com" || decoded.Role != "mentee" || decoded.ProfilePicture != "https://example.com/grace.png" {
+		t.Fatalf("legacy attributes were not mapped: %+v", decoded)
+	}
+	if decoded.Version != 0 {
+		t.Fatalf("expected legacy profile to have version 0, got %d", decoded.Version)
+	}
+}
+
+func TestChangesFromRequest(t *testing.T) {
+	name, years := "  Ada  ", 7
+	skills := []string{"go"}
+	changes := ChangesFromRequest(&entity.ProfileUpdateRequest{Name: &name, YearsOfExperience: &years, Skills: &skills})
+
+	want := Changes{"Name": "Ada", "YearsOfExperience": 7, "Skills": []string{"go"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
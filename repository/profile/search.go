@@ -0,0 +1,159 @@
+package profile
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	mentorProfileType = "mentor"
+	// maxSearchPages and maxEvaluatedMentors bound the work one SearchMentors call does. A selective filter can
+	// leave most of the index unmatched, so the call stops at either limit and hands back a cursor instead.
+	maxSearchPages      = 4
+	maxEvaluatedMentors = 200
+)
+
+// SearchMentors pages through the ProfileTypeIndex for mentor profiles. Filters are applied by DynamoDB after
+// the key condition, so a single Query page can come back short; the loop keeps reading until the page is full,
+// the index is exhausted or it has read maxSearchPages pages or evaluated maxEvaluatedMentors profiles. The result
+// can therefore be short, or even empty, with a cursor to continue from.
+func (r *DynamoRepository) SearchMentors(ctx context.Context, filter entity.MentorSearchFilter, limit int32, cursor string) ([]entity.Profile, string, error) {
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filterExpression, attributeNames, attributeValues := mentorFilter(filter)
+	attributeNames["#profileType"] = profileTypeAttribute
+	attributeValues[":profileType"] = &types.AttributeValueMemberS{Value: mentorProfileType}
+
+	mentors := make([]entity.Profile, 0, limit)
+	var evaluated int32
+	for pages := 0; pages < maxSearchPages; pages++ {
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String(r.tableName),
+			IndexName:                 aws.String(ProfileTypeIndex),
+			KeyConditionExpression:    aws.String("#profileType = :profileType"),
+			ExpressionAttributeNames:  attributeNames,
+			ExpressionAttributeValues: attributeValues,
+			ExclusiveStartKey:         startKey,
+			Limit:                     aws.Int32(maxEvaluatedMentors - evaluated),
+		}
+		if filterExpression != "" {
+			input.FilterExpression = aws.String(filterExpression)
+		}
+
+		page, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to search mentors: %w", err)
+		}
+
+		var batch []entity.Profile
+		if err = attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal mentor profiles: %w", err)
+		}
+		mentors = append(mentors, batch...)
+		evaluated += page.ScannedCount
+		startKey = page.LastEvaluatedKey
+
+		// A page evaluates more profiles than are still needed, so the next call resumes after the last one kept.
+		if int32(len(mentors)) > limit {
+			mentors = mentors[:limit]
+			startKey = profileKey(mentors[limit-1].UserID, mentorProfileType)
+		}
+		if len(startKey) == 0 || int32(len(mentors)) == limit || evaluated >= maxEvaluatedMentors {
+			break
+		}
+	}
+
+	nextCursor, err := encodeCursor(startKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return mentors, nextCursor, nil
+}
+
+func mentorFilter(filter entity.MentorSearchFilter) (string, map[string]string, map[string]types.AttributeValue) {
+	var conditions []string
+	names := map[string]string{}
+	values := map[string]types.AttributeValue{}
+
+	if filter.Skill != "" {
+		conditions = append(conditions, "contains(#skills, :skill)")
+		names["#skills"] = "Skills"
+		values[":skill"] = &types.AttributeValueMemberS{Value: filter.Skill}
+	}
+	if filter.Language != "" {
+		conditions = append(conditions, "contains(#languages, :language)")
+		names["#languages"] = "Languages"
+		values[":language"] = &types.AttributeValueMemberS{Value: filter.Language}
+	}
+	if filter.Industry != "" {
+		conditions = append(conditions, "#industry = :industry")
+		names["#industry"] = "Industry"
+		values[":industry"] = &types.AttributeValueMemberS{Value: filter.Industry}
+	}
+	if filter.Seniority != "" {
+		conditions = append(conditions, "#seniority = :seniority")
+		names["#seniority"] = "Seniority"
+		values[":seniority"] = &types.AttributeValueMemberS{Value: filter.Seniority}
+	}
+	if filter.Available != nil {
+		conditions = append(conditions, "#available = :available")
+		names["#available"] = "Available"
+		values[":available"] = &types.AttributeValueMemberBOOL{Value: *filter.Available}
+	}
+
+	return strings.Join(conditions, " AND "), names, values
+}
+
+// Cursors are the opaque, URL-safe form of DynamoDB's LastEvaluatedKey. Every key attribute of the
+// profile table and its index is a string, so the key round-trips through a flat JSON object.
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	var plain map[string]string
+	if err := attributevalue.UnmarshalMap(key, &plain); err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	raw, err := json.Marshal(plain)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errorpackage.ErrInvalidCursor
+	}
+
+	var plain map[string]string
+	if err = json.Unmarshal(raw, &plain); err != nil || plain[userIDAttribute] == "" || plain[profileTypeAttribute] != mentorProfileType {
+		return nil, errorpackage.ErrInvalidCursor
+	}
+
+	key, err := attributevalue.MarshalMap(plain)
+	if err != nil {
+		return nil, errorpackage.ErrInvalidCursor
+	}
+	return key, nil
+}
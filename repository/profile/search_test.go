@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	key := map[string]types.AttributeValue{
+		"UserId":      &types.AttributeValueMemberS{Value: "mentor@example.com"},
+		"ProfileType": &types.AttributeValueMemberS{Value: "mentor"},
+	}
+
+	cursor, err := encodeCursor(key)
+	if err != nil {
+		t.Fatalf("failed to encode cursor: %v", err)
+	}
+
+	decoded, err := decodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("failed to decode cursor: %v", err)
+	}
+	if decoded["UserId"].(*types.AttributeValueMemberS).Value != "mentor@example.com" {
+		t.Fatalf("unexpected decoded key: %+v", decoded)
+	}
+
+	if empty, _ := encodeCursor(nil); empty != "" {
+		t.Fatalf("expected empty cursor for the last page, got %q", empty)
+	}
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	for _, cursor := range []string{"%%%", "bm90LWpzb24", "eyJVc2VySWQiOiJ4IiwiUHJvZmlsZVR5cGUiOiJtZW50ZWUifQ"} {
+		if _, err := decodeCursor(cursor); !errors.Is(err, errorpackage.ErrInvalidCursor) {
+			t.Fatalf("expected invalid cursor error for %q, got %v", cursor, err)
+		}
+	}
+}
+
+func TestMentorFilter(t *testing.T) {
+	available := true
+	expression, names, values := mentorFilter(entity.MentorSearchFilter{Skill: "go", Seniority: "senior", Available: &available})
+
+	if expression != "contains(#skills, :skill) AND #seniority = :seniority AND #available = :available" {
+		t.Fatalf("unexpected filter expression %q", expression)
+	}
+	if len(names) != 3 || len(values) != 3 {
+		t.Fatalf("expected 3 names and values, got %d and %d", len(names), len(values))
+	}
+
+	if expression, _, _ = mentorFilter(entity.MentorSearchFilter{}); expression != "" {
+		t.Fatalf("expected no filter expression, got %q", expression)
+	}
+}
+
+func TestSearchMentorsStopsAfterEvaluationBudget(t *testing.T) {
+	var seed []entity.Profile
+	for i := 0; i < maxEvaluatedMentors; i++ {
+		seed = append(seed, entity.Profile{UserID: fmt.Sprintf("sub-%04d", i), User: entity.User{Role: "mentor"}, Skills: []string{"cobol"}})
+	}
+	seed = append(seed, entity.Profile{UserID: "sub-9999", User: entity.User{Role: "mentor"}, Skills: []string{"go"}})
+	repo := NewMemoryRepository(seed...)
+	filter := entity.MentorSearchFilter{Skill: "go"}
+
+	mentors, cursor, err := repo.SearchMentors(context.Background(), filter, 10, "")
+	if err != nil || len(mentors) != 0 || cursor == "" {
+		t.Fatalf("expected an empty page with a cursor, got %+v %q %v", mentors, cursor, err)
+	}
+
+	mentors, cursor, err = repo.SearchMentors(context.Background(), filter, 10, cursor)
+	if err != nil || len(mentors) != 1 || mentors[0].UserID != "sub-9999" || cursor != "" {
+		t.Fatalf("expected the last mentor and no cursor, got %+v %q %v", mentors, cursor, err)
+	}
+}
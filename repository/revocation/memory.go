@@ -0,0 +1,76 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/entity"
+)
+
+// MemoryRepository is an in-memory Repository for handler tests and local runs. Entries past their ExpiresAt
+// are ignored, as if the table's time to live had removed them.
+type MemoryRepository struct {
+	awsapi.Faults
+
+	mu      sync.Mutex
+	entries map[string]entity.RevokedToken
+	now     func() time.Time
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{entries: map[string]entity.RevokedToken{}, now: time.Now}
+}
+
+func (r *MemoryRepository) RevokeTokens(_ context.Context, tokenIDs []string, expiresAt time.Time) error {
+	if err := r.Err("RevokeTokens"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revokedAt := r.now().Unix()
+	for _, tokenID := range tokenIDs {
+		if tokenID != "" {
+			r.entries[tokenID] = entity.RevokedToken{TokenID: tokenID, RevokedAt: revokedAt, ExpiresAt: expiresAt.Unix()}
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) RevokeUser(_ context.Context, sub string) error {
+	if err := r.Err("RevokeUser"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.entries[userKey(sub)] = entity.RevokedToken{TokenID: userKey(sub), RevokedAt: now.Unix(), ExpiresAt: now.Add(MaxTokenLifetime).Unix()}
+	return nil
+}
+
+// Entry returns the denylist entry stored under tokenID, expired or not.
+func (r *MemoryRepository) Entry(tokenID string) (entity.RevokedToken, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[tokenID]
+	return entry, ok
+}
+
+func (r *MemoryRepository) Revoked(_ context.Context, payload *entity.IDTokenPayload) (bool, error) {
+	if err := r.Err("Revoked"); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now().Unix()
+	for _, key := range lookupKeys(payload) {
+		if revoked, ok := r.entries[key]; ok && revoked.ExpiresAt > now && covers(revoked, payload) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
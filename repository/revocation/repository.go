@@ -0,0 +1,140 @@
+// Package revocation keeps the denylist of ID and access tokens that were signed out before they expired.
+// Cognito stops honouring revoked tokens in its own APIs, but this service verifies tokens locally, so it has
+// to be told.
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxTokenLifetime is the longest Cognito lets an ID or access token live. A user-wide revocation is kept for
+// that long, after which every token it covers has expired anyway.
+const MaxTokenLifetime = 24 * time.Hour
+
+// TTLAttribute holds when DynamoDB may drop a denylist entry, once no token it covers can still verify.
+const TTLAttribute = "ExpiresAt"
+
+type Repository interface {
+	// RevokeTokens denylists the given jti or origin_jti values until expiresAt.
+	RevokeTokens(ctx context.Context, tokenIDs []string, expiresAt time.Time) error
+	// RevokeUser denylists every token issued to sub up to now.
+	RevokeUser(ctx context.Context, sub string) error
+	// Revoked reports whether a token has been denylisted by its jti, its origin_jti or a sign-out of its user.
+	Revoked(ctx context.Context, payload *entity.IDTokenPayload) (bool, error)
+}
+
+type DynamoRepository struct {
+	client awsapi.DynamoDBAPI
+	table  string
+	now    func() time.Time
+}
+
+func NewDynamoRepository(client awsapi.DynamoDBAPI, table string) *DynamoRepository {
+	return &DynamoRepository{client: client, table: table, now: time.Now}
+}
+
+func (r *DynamoRepository) RevokeTokens(ctx context.Context, tokenIDs []string, expiresAt time.Time) error {
+	revokedAt := r.now().Unix()
+	for _, tokenID := range tokenIDs {
+		if tokenID == "" {
+			continue
+		}
+		if err := r.put(ctx, entity.RevokedToken{TokenID: tokenID, RevokedAt: revokedAt, ExpiresAt: expiresAt.Unix()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DynamoRepository) RevokeUser(ctx context.Context, sub string) error {
+	now := r.now()
+	return r.put(ctx, entity.RevokedToken{TokenID: userKey(sub), RevokedAt: now.Unix(), ExpiresAt: now.Add(MaxTokenLifetime).Unix()})
+}
+
+func (r *DynamoRepository) put(ctx context.Context, revoked entity.RevokedToken) error {
+	item, err := attributevalue.MarshalMap(revoked)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revoked token: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.table),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// Revoked looks up all entries that could cover the token in one batch. Items past their ExpiresAt are ignored,
+// since DynamoDB may take a while to delete them.
+func (r *DynamoRepository) Revoked(ctx context.Context, payload *entity.IDTokenPayload) (bool, error) {
+	keys := make([]map[string]types.AttributeValue, 0, 3)
+	for _, tokenID := range lookupKeys(payload) {
+		keys = append(keys, map[string]types.AttributeValue{"TokenId": &types.AttributeValueMemberS{Value: tokenID}})
+	}
+
+	now := r.now().Unix()
+	request := map[string]types.KeysAndAttributes{r.table: {
+		Keys:                 keys,
+		ConsistentRead:       aws.Bool(true),
+		ProjectionExpression: aws.String("TokenId, RevokedAt, " + TTLAttribute),
+	}}
+	for len(request) > 0 {
+		result, err := r.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+		if err != nil {
+			return false, fmt.Errorf("failed to look up revoked tokens: %w", err)
+		}
+
+		var entries []entity.RevokedToken
+		if err = attributevalue.UnmarshalListOfMaps(result.Responses[r.table], &entries); err != nil {
+			return false, fmt.Errorf("failed to unmarshal revoked tokens: %w", err)
+		}
+		for _, revoked := range entries {
+			if revoked.ExpiresAt > now && covers(revoked, payload) {
+				return true, nil
+			}
+		}
+		request = result.UnprocessedKeys
+	}
+	return false, nil
+}
+
+// lookupKeys lists the entries that could revoke payload: its own jti, the origin_jti it shares with the other
+// tokens of the same sign-in, and its user's sign-out marker.
+func lookupKeys(payload *entity.IDTokenPayload) []string {
+	var keys []string
+	for _, key := range []string{payload.TokenID, payload.OriginTokenID} {
+		if key != "" && (len(keys) == 0 || keys[0] != key) {
+			keys = append(keys, key)
+		}
+	}
+	if payload.Sub != "" {
+		keys = append(keys, userKey(payload.Sub))
+	}
+	return keys
+}
+
+// covers applies a user-wide entry only to tokens issued no later than the sign-out. iat has one-second
+// resolution, so a token issued in the same second as the sign-out is treated as revoked.
+func covers(revoked entity.RevokedToken, payload *entity.IDTokenPayload) bool {
+	if payload.Sub != "" && revoked.TokenID == userKey(payload.Sub) {
+		return payload.IssuedAt <= revoked.RevokedAt
+	}
+	return true
+}
+
+func userKey(sub string) string {
+	return "user#" + sub
+}
@@ -0,0 +1,51 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/entity"
+)
+
+func TestRevoked(t *testing.T) {
+	signOut := time.Unix(1_700_000_000, 0)
+	token := func(tokenID, originTokenID string, issuedAt time.Time) *entity.IDTokenPayload {
+		return &entity.IDTokenPayload{Sub: "sub-1", TokenID: tokenID, OriginTokenID: originTokenID, IssuedAt: issuedAt.Unix()}
+	}
+
+	tests := []struct {
+		name    string
+		token   *entity.IDTokenPayload
+		now     time.Time
+		revoked bool
+	}{
+		{name: "revoked jti", token: token("revoked-jti", "origin", signOut.Add(time.Hour)), now: signOut, revoked: true},
+		{name: "revoked origin_jti", token: token("jti", "revoked-origin", signOut.Add(time.Hour)), now: signOut, revoked: true},
+		{name: "issued before the user signed out", token: token("jti", "origin", signOut.Add(-time.Hour)), now: signOut, revoked: true},
+		{name: "issued in the second of the sign-out", token: token("jti", "origin", signOut), now: signOut, revoked: true},
+		{name: "issued after the user signed out", token: token("jti", "origin", signOut.Add(time.Second)), now: signOut},
+		{name: "other user", token: &entity.IDTokenPayload{Sub: "sub-2", TokenID: "jti", IssuedAt: signOut.Add(-time.Hour).Unix()}, now: signOut},
+		{name: "entry expired", token: token("revoked-jti", "origin", signOut.Add(-time.Hour)), now: signOut.Add(MaxTokenLifetime + time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := NewMemoryRepository()
+			repository.now = func() time.Time { return signOut }
+			ctx := context.Background()
+			if err := repository.RevokeTokens(ctx, []string{"revoked-jti", "revoked-origin"}, signOut.Add(time.Hour)); err != nil {
+				t.Fatalf("failed to revoke tokens: %v", err)
+			}
+			if err := repository.RevokeUser(ctx, "sub-1"); err != nil {
+				t.Fatalf("failed to revoke user: %v", err)
+			}
+
+			repository.now = func() time.Time { return tt.now }
+			revoked, err := repository.Revoked(ctx, tt.token)
+			if err != nil || revoked != tt.revoked {
+				t.Fatalf("expected revoked=%v, got %v %v", tt.revoked, revoked, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package scheduling
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+// MemoryRepository is an in-memory Repository for handler tests and local runs. Like the bookings table it
+// keeps one copy of each booking per participant, keyed by user and start time, and reserves the slots each
+// booking covers for both participants.
+type MemoryRepository struct {
+	awsapi.Faults
+
+	mu           sync.Mutex
+	availability map[string]entity.Availability
+	bookings     map[string]map[string]entity.Booking
+	slots        map[string]map[string]string
+	now          func() time.Time
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		availability: map[string]entity.Availability{},
+		bookings:     map[string]map[string]entity.Booking{},
+		slots:        map[string]map[string]string{},
+		now:          time.Now,
+	}
+}
+
+func (r *MemoryRepository) GetAvailability(_ context.Context, mentorID string) (*entity.Availability, error) {
+	if err := r.Err("GetAvailability"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	availability, ok := r.availability[mentorID]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	return &availability, nil
+}
+
+func (r *MemoryRepository) PutAvailability(_ context.Context, availability *entity.Availability) error {
+	if err := r.Err("PutAvailability"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	availability.UpdatedAt = r.now().UTC().Format(time.RFC3339)
+	r.availability[availability.MentorID] = *availability
+	return nil
+}
+
+func (r *MemoryRepository) DeleteAvailability(_ context.Context, mentorID string) error {
+	if err := r.Err("DeleteAvailability"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.availability, mentorID)
+	return nil
+}
+
+func (r *MemoryRepository) Book(_ context.Context, booking *entity.Booking) error {
+	if err := r.Err("Book"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slots, err := reservedSlots(booking)
+	if err != nil {
+		return err
+	}
+	owners := []string{booking.MentorID, booking.MenteeID}
+	for _, owner := range owners {
+		if _, taken := r.bookings[owner][booking.StartTime]; taken {
+			return errorpackage.ErrSlotAlreadyBooked
+		}
+		for _, slot := range slots {
+			if _, taken := r.slots[owner][slot]; taken {
+				return errorpackage.ErrSlotAlreadyBooked
+			}
+		}
+	}
+
+	bookingID, err := newBookingID()
+	if err != nil {
+		return err
+	}
+	booking.BookingID = bookingID
+	booking.CreatedAt = r.now().UTC().Format(time.RFC3339)
+
+	for _, owner := range owners {
+		copied := *booking
+		copied.UserID = owner
+		if r.bookings[owner] == nil {
+			r.bookings[owner] = map[string]entity.Booking{}
+		}
+		r.bookings[owner][booking.StartTime] = copied
+		if r.slots[owner] == nil {
+			r.slots[owner] = map[string]string{}
+		}
+		for _, slot := range slots {
+			r.slots[owner][slot] = bookingID
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) ListBookings(_ context.Context, userID, from, to string) ([]entity.Booking, error) {
+	if err := r.Err("ListBookings"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var bookings []entity.Booking
+	for startTime, booking := range r.bookings[userID] {
+		if startTime >= from && startTime <= to {
+			bookings = append(bookings, booking)
+		}
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].StartTime < bookings[j].StartTime })
+	return bookings, nil
+}
+
+func (r *MemoryRepository) GetBooking(_ context.Context, userID, startTime string) (*entity.Booking, error) {
+	if err := r.Err("GetBooking"); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[userID][startTime]
+	if !ok {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+	return &booking, nil
+}
+
+func (r *MemoryRepository) Cancel(_ context.Context, booking *entity.Booking) error {
+	if err := r.Err("Cancel"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owners := []string{booking.MentorID, booking.MenteeID}
+	for _, owner := range owners {
+		stored, ok := r.bookings[owner][booking.StartTime]
+		if !ok || stored.BookingID != booking.BookingID {
+			return errorpackage.ErrNoSuchKey
+		}
+	}
+	for _, owner := range owners {
+		r.release(owner, booking)
+	}
+	return nil
+}
+
+func (r *MemoryRepository) DeleteBooking(_ context.Context, userID string, booking *entity.Booking) error {
+	if err := r.Err("DeleteBooking"); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release(userID, booking)
+	return nil
+}
+
+// release removes owner's copy of booking and the slots it reserved for them.
+func (r *MemoryRepository) release(owner string, booking *entity.Booking) {
+	delete(r.bookings[owner], booking.StartTime)
+	slots, _ := reservedSlots(booking)
+	for _, slot := range slots {
+		if r.slots[owner][slot] == booking.BookingID {
+			delete(r.slots[owner], slot)
+		}
+	}
+}
+
+var _ Repository = (*MemoryRepository)(nil)
@@ -0,0 +1,311 @@
+package scheduling
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"mentorship-app-backend/components/awsapi"
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type Repository interface {
+	GetAvailability(ctx context.Context, mentorID string) (*entity.Availability, error)
+	PutAvailability(ctx context.Context, availability *entity.Availability) error
+	DeleteAvailability(ctx context.Context, mentorID string) error
+	Book(ctx context.Context, booking *entity.Booking) error
+	ListBookings(ctx context.Context, userID, from, to string) ([]entity.Booking, error)
+	GetBooking(ctx context.Context, userID, startTime string) (*entity.Booking, error)
+	Cancel(ctx context.Context, booking *entity.Booking) error
+	DeleteBooking(ctx context.Context, userID string, booking *entity.Booking) error
+}
+
+// slotReservationPrefix starts the sort key of a slot reservation. It sorts after every RFC 3339 start time,
+// so listing bookings by time range never returns reservations.
+const slotReservationPrefix = "slot#"
+
+// slotReservation holds one SlotGranularity slot of a participant's time for a booking. A booking reserves
+// every slot it covers for both participants, which is what stops overlapping sessions: the conditional puts
+// only compare whole items, so two sessions with different starts or lengths collide on their shared slots.
+type slotReservation struct {
+	UserID    string `dynamodbav:"UserId"`
+	StartTime string `dynamodbav:"StartTime"`
+	BookingID string `dynamodbav:"BookingId"`
+}
+
+type DynamoRepository struct {
+	client            awsapi.DynamoDBAPI
+	availabilityTable string
+	bookingsTable     string
+	now               func() time.Time
+}
+
+func NewDynamoRepository(client awsapi.DynamoDBAPI, availabilityTable, bookingsTable string) *DynamoRepository {
+	return &DynamoRepository{
+		client:            client,
+		availabilityTable: availabilityTable,
+		bookingsTable:     bookingsTable,
+		now:               time.Now,
+	}
+}
+
+func (r *DynamoRepository) GetAvailability(ctx context.Context, mentorID string) (*entity.Availability, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.availabilityTable),
+		Key:       availabilityKey(mentorID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	if result.Item == nil {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+
+	var availability entity.Availability
+	if err = attributevalue.UnmarshalMap(result.Item, &availability); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal availability: %w", err)
+	}
+	return &availability, nil
+}
+
+func (r *DynamoRepository) PutAvailability(ctx context.Context, availability *entity.Availability) error {
+	availability.UpdatedAt = r.now().UTC().Format(time.RFC3339)
+
+	item, err := attributevalue.MarshalMap(availability)
+	if err != nil {
+		return fmt.Errorf("failed to marshal availability: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.availabilityTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save availability: %w", err)
+	}
+	return nil
+}
+
+func (r *DynamoRepository) DeleteAvailability(ctx context.Context, mentorID string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.availabilityTable),
+		Key:       availabilityKey(mentorID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete availability: %w", err)
+	}
+	return nil
+}
+
+// Book writes the mentor's and the mentee's copy of a booking and reserves the slots it covers for both, in one
+// transaction. Every put is conditional on its item not existing, so a booking that overlaps one of either
+// participant's sessions fails as a whole, even when two mentees race for the same mentor.
+func (r *DynamoRepository) Book(ctx context.Context, booking *entity.Booking) error {
+	slots, err := reservedSlots(booking)
+	if err != nil {
+		return err
+	}
+	bookingID, err := newBookingID()
+	if err != nil {
+		return err
+	}
+	booking.BookingID = bookingID
+	booking.CreatedAt = r.now().UTC().Format(time.RFC3339)
+
+	items := make([]types.TransactWriteItem, 0, 2*(len(slots)+1))
+	for _, owner := range []string{booking.MentorID, booking.MenteeID} {
+		copied := *booking
+		copied.UserID = owner
+		records := []interface{}{copied}
+		for _, slot := range slots {
+			records = append(records, slotReservation{UserID: owner, StartTime: slot, BookingID: bookingID})
+		}
+
+		for _, record := range records {
+			item, err := attributevalue.MarshalMap(record)
+			if err != nil {
+				return fmt.Errorf("failed to marshal booking: %w", err)
+			}
+			items = append(items, types.TransactWriteItem{
+				Put: &types.Put{
+					TableName:           aws.String(r.bookingsTable),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(UserId)"),
+				},
+			})
+		}
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	if err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			return errorpackage.ErrSlotAlreadyBooked
+		}
+		return fmt.Errorf("failed to book session: %w", err)
+	}
+	return nil
+}
+
+func (r *DynamoRepository) ListBookings(ctx context.Context, userID, from, to string) ([]entity.Booking, error) {
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:              aws.String(r.bookingsTable),
+		KeyConditionExpression: aws.String("UserId = :userId AND StartTime BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userID},
+			":from":   &types.AttributeValueMemberS{Value: from},
+			":to":     &types.AttributeValueMemberS{Value: to},
+		},
+	})
+
+	var bookings []entity.Booking
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list bookings: %w", err)
+		}
+
+		var batch []entity.Booking
+		if err = attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bookings: %w", err)
+		}
+		bookings = append(bookings, batch...)
+	}
+	return bookings, nil
+}
+
+func (r *DynamoRepository) GetBooking(ctx context.Context, userID, startTime string) (*entity.Booking, error) {
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.bookingsTable),
+		Key:       bookingKey(userID, startTime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	if result.Item == nil {
+		return nil, errorpackage.ErrNoSuchKey
+	}
+
+	var booking entity.Booking
+	if err = attributevalue.UnmarshalMap(result.Item, &booking); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal booking: %w", err)
+	}
+	return &booking, nil
+}
+
+// Cancel removes both copies of a booking and releases its slots. Each delete is conditional on the booking ID
+// so a slot that was cancelled and re-booked in the meantime is left untouched. Bookings made before slots were
+// reserved have none to release.
+func (r *DynamoRepository) Cancel(ctx context.Context, booking *entity.Booking) error {
+	slots, err := reservedSlots(booking)
+	if err != nil {
+		return err
+	}
+	bookingID := map[string]types.AttributeValue{
+		":bookingId": &types.AttributeValueMemberS{Value: booking.BookingID},
+	}
+
+	items := make([]types.TransactWriteItem, 0, 2*(len(slots)+1))
+	for _, owner := range []string{booking.MentorID, booking.MenteeID} {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName:                 aws.String(r.bookingsTable),
+				Key:                       bookingKey(owner, booking.StartTime),
+				ConditionExpression:       aws.String("BookingId = :bookingId"),
+				ExpressionAttributeValues: bookingID,
+			},
+		})
+		for _, slot := range slots {
+			items = append(items, types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName:                 aws.String(r.bookingsTable),
+					Key:                       bookingKey(owner, slot),
+					ConditionExpression:       aws.String("attribute_not_exists(UserId) OR BookingId = :bookingId"),
+					ExpressionAttributeValues: bookingID,
+				},
+			})
+		}
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	if err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			return errorpackage.ErrNoSuchKey
+		}
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+	return nil
+}
+
+// DeleteBooking removes one participant's copy of a booking and their slot reservations unconditionally. Unlike
+// Cancel it succeeds when the other copy is already gone, which account deletion relies on to be retryable.
+func (r *DynamoRepository) DeleteBooking(ctx context.Context, userID string, booking *entity.Booking) error {
+	slots, err := reservedSlots(booking)
+	if err != nil {
+		return err
+	}
+
+	items := []types.TransactWriteItem{{
+		Delete: &types.Delete{TableName: aws.String(r.bookingsTable), Key: bookingKey(userID, booking.StartTime)},
+	}}
+	for _, slot := range slots {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{TableName: aws.String(r.bookingsTable), Key: bookingKey(userID, slot)},
+		})
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	if err != nil {
+		return fmt.Errorf("failed to delete booking: %w", err)
+	}
+	return nil
+}
+
+// reservedSlots returns the sort keys of the slot reservations booking holds.
+func reservedSlots(booking *entity.Booking) ([]string, error) {
+	start, err := time.Parse(time.RFC3339, booking.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking start %q: %w", booking.StartTime, err)
+	}
+	end, err := time.Parse(time.RFC3339, booking.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking end %q: %w", booking.EndTime, err)
+	}
+
+	var keys []string
+	for _, slot := range ReservedSlots(start, end) {
+		keys = append(keys, slotReservationPrefix+SlotKey(slot))
+	}
+	return keys, nil
+}
+
+func availabilityKey(mentorID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"MentorId": &types.AttributeValueMemberS{Value: mentorID},
+	}
+}
+
+func bookingKey(userID, startTime string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"UserId":    &types.AttributeValueMemberS{Value: userID},
+		"StartTime": &types.AttributeValueMemberS{Value: startTime},
+	}
+}
+
+func newBookingID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate booking ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,232 @@
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// Lambda's provided runtimes do not ship a zoneinfo database, so embed one for IANA time zone lookups.
+	_ "time/tzdata"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+const (
+	BookingHorizon = 90 * 24 * time.Hour
+	// SlotGranularity is the grid bookings reserve time on. Session lengths and window bounds are multiples of
+	// it, so each session covers whole slots and two sessions that overlap share at least one.
+	SlotGranularity = 15 * time.Minute
+	// MaxSessionLength bounds the session length, and so how long before a range a booking overlapping it starts.
+	MaxSessionLength = 4 * time.Hour
+	dateLayout       = "2006-01-02"
+	clockLayout      = "15:04"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+type window struct {
+	start, end time.Time
+}
+
+func ParseWeekday(name string) (time.Weekday, error) {
+	weekday, ok := weekdays[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday %q", name)
+	}
+	return weekday, nil
+}
+
+func ParseClock(value string) (int, int, error) {
+	parsed, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q; expected HH:MM", value)
+	}
+	return parsed.Hour(), parsed.Minute(), nil
+}
+
+func ParseDate(value string) (time.Time, error) {
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q; expected YYYY-MM-DD", value)
+	}
+	return parsed, nil
+}
+
+// SlotKey is the canonical form of a session start used as the bookings sort key, so the same instant
+// always maps to the same item regardless of the offset the client sent.
+func SlotKey(start time.Time) string {
+	return start.UTC().Format(time.RFC3339)
+}
+
+// ReservedSlots returns the start of every SlotGranularity slot the session from start to end covers.
+func ReservedSlots(start, end time.Time) []time.Time {
+	var slots []time.Time
+	for slot := start.UTC().Truncate(SlotGranularity); slot.Before(end); slot = slot.Add(SlotGranularity) {
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+// CheckSlot verifies that a session starting at start lies inside the mentor's availability, is aligned to
+// the session grid of its window and is not blocked by an exception. It returns the session end time.
+func CheckSlot(availability *entity.Availability, start, now time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(availability.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid mentor time zone: %w", err)
+	}
+
+	duration := time.Duration(availability.SessionMinutes) * time.Minute
+	end := start.Add(duration)
+
+	if !start.After(now) {
+		return time.Time{}, fmt.Errorf("%w: session must start in the future", errorpackage.ErrSlotOutsideAvailability)
+	}
+	if start.After(now.Add(BookingHorizon)) {
+		return time.Time{}, fmt.Errorf("%w: sessions can be booked at most %d days ahead", errorpackage.ErrSlotOutsideAvailability, int(BookingHorizon.Hours()/24))
+	}
+
+	local := start.In(location)
+	open, blocked := windowsOn(availability, location, time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location))
+
+	fits := false
+	for _, w := range open {
+		if !start.Before(w.start) && !end.After(w.end) && start.Sub(w.start)%duration == 0 {
+			fits = true
+			break
+		}
+	}
+	if !fits || overlapsAny(start, end, blocked) {
+		return time.Time{}, fmt.Errorf("%w: the mentor is not available at that time", errorpackage.ErrSlotOutsideAvailability)
+	}
+
+	return end, nil
+}
+
+// OpenSlots lists every bookable session start in [from, to) that overlaps none of booked. Bookings made under
+// another session length need not sit on the current grid, so they are compared by overlap rather than start.
+func OpenSlots(availability *entity.Availability, from, to time.Time, booked []entity.Booking) ([]string, error) {
+	location, err := time.LoadLocation(availability.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mentor time zone: %w", err)
+	}
+	duration := time.Duration(availability.SessionMinutes) * time.Minute
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid session length")
+	}
+
+	taken, err := bookedWindows(booked)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var slots []string
+
+	localFrom := from.In(location)
+	for day := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day(), 0, 0, 0, 0, location); day.Before(to); day = day.AddDate(0, 0, 1) {
+		open, blocked := windowsOn(availability, location, day)
+		for _, w := range open {
+			for start := w.start; !start.Add(duration).After(w.end); start = start.Add(duration) {
+				key := SlotKey(start)
+				end := start.Add(duration)
+				if !start.After(from) || !start.Before(to) || seen[key] || overlapsAny(start, end, blocked) || overlapsAny(start, end, taken) {
+					continue
+				}
+				seen[key] = true
+				slots = append(slots, key)
+			}
+		}
+	}
+
+	sort.Strings(slots)
+	return slots, nil
+}
+
+func bookedWindows(bookings []entity.Booking) ([]window, error) {
+	windows := make([]window, 0, len(bookings))
+	for _, booking := range bookings {
+		start, err := time.Parse(time.RFC3339, booking.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking start %q: %w", booking.StartTime, err)
+		}
+		end, err := time.Parse(time.RFC3339, booking.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking end %q: %w", booking.EndTime, err)
+		}
+		windows = append(windows, window{start: start, end: end})
+	}
+	return windows, nil
+}
+
+func windowsOn(availability *entity.Availability, location *time.Location, day time.Time) ([]window, []window) {
+	var open, blocked []window
+
+	for _, weekly := range availability.Weekly {
+		weekday, err := ParseWeekday(weekly.Weekday)
+		if err != nil || weekday != day.Weekday() {
+			continue
+		}
+		if w, ok := clockWindow(day, location, weekly.Start, weekly.End); ok {
+			open = append(open, w)
+		}
+	}
+
+	date := day.Format(dateLayout)
+	for _, exception := range availability.Exceptions {
+		if exception.Date != date {
+			continue
+		}
+
+		w, ok := clockWindow(day, location, exception.Start, exception.End)
+		if exception.Start == "" && exception.End == "" {
+			w, ok = window{start: day, end: time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, location)}, !exception.Available
+		}
+		if !ok {
+			continue
+		}
+
+		if exception.Available {
+			open = append(open, w)
+		} else {
+			blocked = append(blocked, w)
+		}
+	}
+
+	return open, blocked
+}
+
+func clockWindow(day time.Time, location *time.Location, start, end string) (window, bool) {
+	startHour, startMinute, err := ParseClock(start)
+	if err != nil {
+		return window{}, false
+	}
+	endHour, endMinute, err := ParseClock(end)
+	if err != nil {
+		return window{}, false
+	}
+
+	w := window{
+		start: time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, location),
+		end:   time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, location),
+	}
+	return w, w.start.Before(w.end)
+}
+
+func overlapsAny(start, end time.Time, windows []window) bool {
+	for _, w := range windows {
+		if start.Before(w.end) && end.After(w.start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package scheduling
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mentorship-app-backend/components/errorpackage"
+	"mentorship-app-backend/entity"
+)
+
+func testAvailability() *entity.Availability {
+	return &entity.Availability{
+		MentorID:       "mentor@example.com",
+		TimeZone:       "Europe/Istanbul",
+		SessionMinutes: 30,
+		Weekly: []entity.WeeklyWindow{
+			{Weekday: "monday", Start: "09:00", End: "11:00"},
+		},
+		Exceptions: []entity.AvailabilityException{
+			{Date: "2025-01-13", Start: "10:00", End: "10:30", Available: false},
+			{Date: "2025-01-20", Available: false},
+			{Date: "2025-01-18", Start: "14:00", End: "15:00", Available: true},
+		},
+	}
+}
+
+func TestCheckSlot(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		start   string
+		wantErr bool
+	}{
+		{"start of weekly window", "2025-01-13T06:00:00Z", false},
+		{"same instant with offset", "2025-01-13T09:30:00+03:00", false},
+		{"last slot of window", "2025-01-13T07:30:00Z", false},
+		{"blocked by exception", "2025-01-13T07:00:00Z", true},
+		{"not aligned to session grid", "2025-01-13T06:15:00Z", true},
+		{"runs past window end", "2025-01-13T08:00:00Z", true},
+		{"whole day blocked", "2025-01-20T06:00:00Z", true},
+		{"extra window on saturday", "2025-01-18T11:30:00Z", false},
+		{"in the past", "2025-01-06T06:00:00Z", true},
+		{"beyond horizon", "2025-06-02T06:00:00Z", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, err := time.Parse(time.RFC3339, tt.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			end, err := CheckSlot(testAvailability(), start, now)
+			if tt.wantErr {
+				if !errors.Is(err, errorpackage.ErrSlotOutsideAvailability) {
+					t.Fatalf("expected ErrSlotOutsideAvailability, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if end.Sub(start) != 30*time.Minute {
+				t.Fatalf("unexpected session end %s", end)
+			}
+		})
+	}
+}
+
+func TestOpenSlots(t *testing.T) {
+	from := time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	booked := []entity.Booking{
+		{StartTime: "2025-01-13T06:30:00Z", EndTime: "2025-01-13T07:00:00Z"},
+		// Booked on the grid of an earlier session length, so it starts on no current slot but overlaps one.
+		{StartTime: "2025-01-13T07:15:00Z", EndTime: "2025-01-13T07:45:00Z"},
+	}
+
+	slots, err := OpenSlots(testAvailability(), from, to, booked)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"2025-01-13T06:00:00Z"}
+	if len(slots) != len(want) {
+		t.Fatalf("got slots %v, want %v", slots, want)
+	}
+	for i := range want {
+		if slots[i] != want[i] {
+			t.Fatalf("got slots %v, want %v", slots, want)
+		}
+	}
+}
+
+func TestReservedSlots(t *testing.T) {
+	start := time.Date(2025, 1, 13, 6, 0, 0, 0, time.UTC)
+	slots := ReservedSlots(start, start.Add(time.Hour))
+	if len(slots) != 4 || !slots[0].Equal(start) || !slots[3].Equal(start.Add(45*time.Minute)) {
+		t.Fatalf("unexpected slots %v", slots)
+	}
+
+	// A session off the grid still reserves every slot it touches.
+	offGrid := start.Add(10 * time.Minute)
+	if slots = ReservedSlots(offGrid, offGrid.Add(30*time.Minute)); len(slots) != 3 || !slots[0].Equal(start) {
+		t.Fatalf("unexpected slots %v", slots)
+	}
+}